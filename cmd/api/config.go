@@ -22,9 +22,12 @@ type config struct {
 	BootstrapBackupURL string `default:"" env:"BOOTSTRAP_BACKUP_URL"`
 
 	HTTP             HTTPConfig
+	GRPC             GRPCConfig
+	Admin            AdminConfig
 	Gateway          GatewayConfig
 	TableConstraints TableConstraints
 	QueryConstraints QueryConstraints
+	Relay            RelayConfig
 
 	Metrics struct {
 		Port string `default:"9090"`
@@ -52,6 +55,55 @@ type HTTPConfig struct {
 	RateLimInterval       string `default:"1s"`
 	MaxRequestPerInterval uint64 `default:"10"`
 	APIKey                string `default:""` // if client passes the key it will not be affected by rate limiter
+
+	// APIKeys are API keys with their own rate limiting tier. More keys are loaded from the
+	// system_api_keys table, and keys defined here take precedence. API keys are case-insensitive.
+	APIKeys []APIKeyConfig
+	// APIKeysReloadInterval is how often the API keys of the system_api_keys table are reloaded.
+	APIKeysReloadInterval string `default:"1m"`
+	// RouteRateLimits override the default rate limiting of the routes with the given names.
+	RouteRateLimits []RouteRateLimitConfig
+	// RateLimStorePath is the path of a SQLite database keeping the rate limiting state. Replicas on the
+	// same host using the same database share the limits. Network filesystems aren't supported, since
+	// SQLite file locks aren't reliable on them.
+	RateLimStorePath string `default:""`
+	// RateLimRedisURL is the URL of a Redis-protocol server keeping the rate limiting state, with the form
+	// redis://[:password@]host[:port][/db]. Replicas on any host using the same server share the limits.
+	// It can't be set together with RateLimStorePath. If both are empty, the state is kept in memory.
+	RateLimRedisURL string `default:""`
+
+	// CacheMaxAge is how long caches can serve read responses without revalidating their ETag. Since responses
+	// change with every processed block, zero makes caches revalidate them on every request.
+	CacheMaxAge string `default:"0s"`
+}
+
+// APIKeyConfig contains the rate limiting tier of an API key.
+type APIKeyConfig struct {
+	Key                   string
+	RateLimInterval       string // defaults to the HTTP RateLimInterval
+	MaxRequestPerInterval uint64
+}
+
+// RouteRateLimitConfig contains the rate limiting of a route, e.g. QueryByStatementPost.
+type RouteRateLimitConfig struct {
+	Route                 string
+	RateLimInterval       string // defaults to the HTTP RateLimInterval
+	MaxRequestPerInterval uint64
+}
+
+// GRPCConfig contains configuration for the gRPC server serving APIs. It uses the TLS certificate of the
+// HTTP server if there's one.
+type GRPCConfig struct {
+	Enabled bool   `default:"false"`
+	Port    string `default:"50051"`
+}
+
+// AdminConfig contains configuration for the admin HTTP server, used by operators to pause, resume and inspect
+// chain stacks. It's served apart from the public APIs, and requests must have the APIKey as bearer token.
+type AdminConfig struct {
+	Enabled bool   `default:"false"`
+	Port    string `default:"8081"`
+	APIKey  string `default:""`
 }
 
 // GatewayConfig contains configuration for the Gateway.
@@ -59,6 +111,55 @@ type GatewayConfig struct {
 	ExternalURIPrefix    string `default:"https://testnets.tableland.network"`
 	MetadataRendererURI  string `default:""`
 	AnimationRendererURI string `default:""`
+	// RenderImages makes the metadata of tables point to images rendered by the validator when
+	// MetadataRendererURI is empty.
+	RenderImages bool `default:"false"`
+
+	// ReadCache caches read query results until the queried tables change.
+	ReadCache struct {
+		Enabled      bool `default:"false"`
+		MaxEntries   int  `default:"10000"`
+		MaxEntryRows int  `default:"1000"`     // larger results aren't cached
+		MaxBytes     int  `default:"67108864"` // estimated memory of all the cached results
+	}
+
+	// SignedReads signs read query responses with a dedicated wallet, so consumers can prove what the
+	// validator returned. Signed responses are buffered to sign them, so they aren't streamed, and their size
+	// is bounded by QueryConstraints.MaxReadResponseSize.
+	SignedReads struct {
+		Enabled bool `default:"false"`
+		// WalletPrivateKey is the key signing responses, which is required if signed reads are enabled. The
+		// wallet only signs, so it doesn't need funds and shouldn't be a wallet sending transactions, like the
+		// relay wallet. Its address is returned with every signature.
+		WalletPrivateKey string `default:""`
+	}
+}
+
+// RelayConfig contains configuration for relaying writes signed by users, so they don't need a funded wallet.
+// The relay wallet sends the transactions, so it must be funded on every chain. Validators see it as the caller
+// of the relayed writes, so tables must grant it the privileges of the writes too.
+type RelayConfig struct {
+	Enabled          bool   `default:"false"`
+	WalletPrivateKey string `default:""`
+
+	// MaxWritesPerInterval is the number of writes each signer can relay in QuotaInterval, counted across all
+	// chains. Zero means no limit.
+	MaxWritesPerInterval int    `default:"100"`
+	QuotaInterval        string `default:"24h"`
+
+	// MaxChainWritesPerInterval and MaxChainGasPerInterval cap what the relay wallet spends on each chain in
+	// QuotaInterval, for all signers together. Zero means no limit.
+	MaxChainWritesPerInterval int    `default:"1000"`
+	MaxChainGasPerInterval    uint64 `default:"0"`
+
+	// AllowedSigners are the addresses whose writes are relayed. Empty means any signer.
+	AllowedSigners []string
+
+	NonceTracker struct {
+		CheckInterval string `default:"10s"`
+		StuckInterval string `default:"10m"`
+		MinBlockDepth int    `default:"5"`
+	}
 }
 
 // BackupConfig contains configuration for automatic database backups.
@@ -93,6 +194,15 @@ type TableConstraints struct {
 type QueryConstraints struct {
 	MaxWriteQuerySize int `default:"35000"`
 	MaxReadQuerySize  int `default:"35000"`
+
+	// MaxReadRowCount and MaxReadResponseSize limit the results of a read query. Zero means no limit.
+	MaxReadRowCount     int `default:"1000000"`
+	MaxReadResponseSize int `default:"104857600"` // 100 MiB
+
+	// ReadQueryTimeout and MaxReadQueryInstructions interrupt expensive read queries. The instructions are
+	// SQLite virtual machine instructions, enforced with a progress handler. Zero means no limit.
+	ReadQueryTimeout         string `default:"10s"`
+	MaxReadQueryInstructions int    `default:"1000000000"`
 }
 
 // ChainConfig contains all the chain execution stack configuration for a particular EVM chain.
@@ -116,6 +226,11 @@ type ChainConfig struct {
 		WebhookURL                  string `default:""`
 	}
 	HashCalculationStep int64 `default:"1000"`
+
+	// ChangeLogRetention is the number of blocks of row changes kept to answer reads at past blocks, and to read
+	// the pages of a paginated read query at the height of its first page. It must be positive, since paginated
+	// reads are always enabled, and cursors expire once the queried tables change in more blocks than the retention.
+	ChangeLogRetention int64 `default:"1000"`
 }
 
 func setupConfig() (*config, string) {
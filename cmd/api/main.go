@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"fmt"
+	"net"
 	"net/http"
 	"path"
 	"strings"
@@ -24,6 +25,8 @@ import (
 	"github.com/textileio/go-tableland/internal/gateway"
 	gatewayimpl "github.com/textileio/go-tableland/internal/gateway/impl"
 	"github.com/textileio/go-tableland/internal/router"
+	"github.com/textileio/go-tableland/internal/router/controllers"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
 	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/internal/tableland/impl"
 	"github.com/textileio/go-tableland/pkg/backup"
@@ -32,15 +35,24 @@ import (
 	"github.com/textileio/go-tableland/pkg/eventprocessor"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
 	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	efimpl "github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed/impl"
 	epimpl "github.com/textileio/go-tableland/pkg/eventprocessor/impl"
 	executor "github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor/impl"
 	"github.com/textileio/go-tableland/pkg/logging"
 	"github.com/textileio/go-tableland/pkg/metrics"
+	nonceimpl "github.com/textileio/go-tableland/pkg/nonce/impl"
 	"github.com/textileio/go-tableland/pkg/parsing"
 	parserimpl "github.com/textileio/go-tableland/pkg/parsing/impl"
+	"github.com/textileio/go-tableland/pkg/relay"
+	relayimpl "github.com/textileio/go-tableland/pkg/relay/impl"
+	"github.com/textileio/go-tableland/pkg/tables"
+	"github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
+	"github.com/textileio/go-tableland/pkg/wallet"
 
+	"github.com/textileio/go-tableland/pkg/pubsub"
 	"github.com/textileio/go-tableland/pkg/sharedmemory"
 
 	"github.com/textileio/go-tableland/pkg/telemetry"
@@ -89,34 +101,78 @@ func main() {
 	}
 
 	sm := sharedmemory.NewSharedMemory()
+	hub := pubsub.NewHub(pubsub.DefaultBufferSize)
+
+	// Relay wallet.
+	var relayWallet *wallet.Wallet
+	if config.Relay.Enabled {
+		relayWallet, err = wallet.NewWallet(config.Relay.WalletPrivateKey)
+		if err != nil {
+			log.Fatal().Err(err).Msg("creating relay wallet")
+		}
+	}
 
 	// Chain stacks.
 	chainStacks, closeChainStacks, err := createChainStacks(
 		db,
 		parser,
 		sm,
+		hub,
 		config.Chains,
 		config.TableConstraints,
-		config.Analytics.FetchExtraBlockInfo)
+		config.Analytics.FetchExtraBlockInfo,
+		config.Relay,
+		relayWallet)
 	if err != nil {
 		log.Fatal().Err(err).Msg("creating chains stack")
 	}
 
+	// Relay.
+	var writeRelay relay.Relay
+	if config.Relay.Enabled {
+		writeRelay, err = createRelay(config.Relay, db, parser, relayWallet, chainStacks)
+		if err != nil {
+			log.Fatal().Err(err).Msg("creating relay")
+		}
+	}
+
 	// HTTP API server.
-	closeHTTPServer, err := createAPIServer(config.HTTP, config.Gateway, parser, db, sm, chainStacks)
+	closeHTTPServer, err := createAPIServer(
+		config.HTTP,
+		config.GRPC,
+		config.Gateway,
+		config.TableConstraints,
+		config.QueryConstraints,
+		parser,
+		db,
+		sm,
+		hub,
+		chainStacks,
+		writeRelay,
+	)
 	if err != nil {
 		log.Fatal().Err(err).Msg("creating HTTP server")
 	}
 
 	// Backuper.
+	var backupScheduler *backup.Scheduler
 	closeBackupScheduler := closerNoop
 	if config.Backup.Enabled {
-		closeBackupScheduler, err = createBackuper(dirPath, config.Backup)
+		backupScheduler, closeBackupScheduler, err = createBackuper(dirPath, config.Backup)
 		if err != nil {
 			log.Fatal().Err(err).Msg("creating backuper")
 		}
 	}
 
+	// Admin HTTP server.
+	closeAdminServer := closerNoop
+	if config.Admin.Enabled {
+		closeAdminServer, err = createAdminServer(config.Admin, chainStacks, backupScheduler)
+		if err != nil {
+			log.Fatal().Err(err).Msg("creating admin server")
+		}
+	}
+
 	// Telemetry
 	closeTelemetryModule, err := configureTelemetry(dirPath, db, chainStacks, config.TelemetryPublisher)
 	if err != nil {
@@ -130,6 +186,9 @@ func main() {
 		if err := closeHTTPServer(ctx); err != nil {
 			log.Error().Err(err).Msg("shutting down http server")
 		}
+		if err := closeAdminServer(ctx); err != nil {
+			log.Error().Err(err).Msg("shutting down admin server")
+		}
 
 		// Close chains syncing.
 		ctx, cls = context.WithTimeout(context.Background(), time.Second*20)
@@ -162,8 +221,11 @@ func createChainIDStack(
 	db *database.SQLiteDB,
 	parser parsing.SQLValidator,
 	sm *sharedmemory.SharedMemory,
+	hub *pubsub.Hub,
 	tableConstraints TableConstraints,
 	fetchExtraBlockInfo bool,
+	relayConfig RelayConfig,
+	relayWallet *wallet.Wallet,
 ) (chains.ChainStack, error) {
 	chainAPIBackoff, err := time.ParseDuration(config.EventFeed.ChainAPIBackoff)
 	if err != nil {
@@ -219,6 +281,7 @@ func createChainIDStack(
 		eventprocessor.WithBlockFailedExecutionBackoff(blockFailedExecutionBackoff),
 		eventprocessor.WithDedupExecutedTxns(config.EventProcessor.DedupExecutedTxns),
 		eventprocessor.WithHashCalcStep(config.HashCalculationStep),
+		eventprocessor.WithReceiptPublisher(hub),
 	}
 
 	// Add the webhook config if it is enabled for this chain.
@@ -229,7 +292,17 @@ func createChainIDStack(
 			eventprocessor.WithWebhook(whURL))
 	}
 
-	ex, err := executor.NewExecutor(config.ChainID, db, parser, tableConstraints.MaxRowCount, impl.NewACL(db))
+	if config.ChangeLogRetention <= 0 {
+		return chains.ChainStack{}, fmt.Errorf("change log retention must be positive, since paginated reads need it")
+	}
+	ex, err := executor.NewExecutor(
+		config.ChainID,
+		db,
+		parser,
+		tableConstraints.MaxRowCount,
+		impl.NewACL(db),
+		executor.WithChangeLogRetention(config.ChangeLogRetention),
+	)
 	if err != nil {
 		return chains.ChainStack{}, fmt.Errorf("creating txn processor: %s", err)
 	}
@@ -238,22 +311,112 @@ func createChainIDStack(
 	if err != nil {
 		return chains.ChainStack{}, fmt.Errorf("creating event processor: %s", err)
 	}
+
+	// The relay sends transactions with its own wallet, tracking its nonce.
+	var registry tables.TablelandTables
+	closeTracker := func() {}
+	if relayWallet != nil {
+		checkInterval, err := time.ParseDuration(relayConfig.NonceTracker.CheckInterval)
+		if err != nil {
+			return chains.ChainStack{}, fmt.Errorf("parsing nonce tracker check interval: %s", err)
+		}
+		stuckInterval, err := time.ParseDuration(relayConfig.NonceTracker.StuckInterval)
+		if err != nil {
+			return chains.ChainStack{}, fmt.Errorf("parsing nonce tracker stuck interval: %s", err)
+		}
+		ctx, cls := context.WithTimeout(context.Background(), time.Second*30)
+		defer cls()
+		tracker, err := nonceimpl.NewLocalTracker(
+			ctx,
+			relayWallet,
+			nonceimpl.NewNonceStore(db),
+			config.ChainID,
+			conn,
+			checkInterval,
+			relayConfig.NonceTracker.MinBlockDepth,
+			stuckInterval,
+		)
+		if err != nil {
+			return chains.ChainStack{}, fmt.Errorf("creating nonce tracker: %s", err)
+		}
+		registry, err = ethereum.NewClient(
+			conn,
+			config.ChainID,
+			common.HexToAddress(config.Registry.ContractAddress),
+			relayWallet,
+			tracker,
+		)
+		if err != nil {
+			tracker.Close()
+			return chains.ChainStack{}, fmt.Errorf("creating registry client: %s", err)
+		}
+		closeTracker = tracker.Close
+	}
+
 	if err := ep.Start(); err != nil {
+		closeTracker()
 		return chains.ChainStack{}, fmt.Errorf("starting event processor: %s", err)
 	}
 	return chains.ChainStack{
-		EventProcessor: ep,
+		EventProcessor:  ep,
+		Registry:        registry,
+		RegistryAddress: common.HexToAddress(config.Registry.ContractAddress),
 		Close: func(ctx context.Context) error {
 			log.Info().Int64("chain_id", int64(config.ChainID)).Msg("closing stack...")
 			defer log.Info().Int64("chain_id", int64(config.ChainID)).Msg("stack closed")
 
 			ep.Stop()
+			closeTracker()
 			conn.Close()
 			return nil
 		},
 	}, nil
 }
 
+func createRelay(
+	config RelayConfig,
+	db *database.SQLiteDB,
+	parser parsing.SQLValidator,
+	relayWallet *wallet.Wallet,
+	chainStacks map[tableland.ChainID]chains.ChainStack,
+) (relay.Relay, error) {
+	quotaInterval, err := time.ParseDuration(config.QuotaInterval)
+	if err != nil {
+		return nil, fmt.Errorf("parsing relay quota interval: %s", err)
+	}
+	allowedSigners := make([]common.Address, len(config.AllowedSigners))
+	for i, signer := range config.AllowedSigners {
+		if !common.IsHexAddress(signer) {
+			return nil, fmt.Errorf("invalid relay allowed signer %s", signer)
+		}
+		allowedSigners[i] = common.HexToAddress(signer)
+	}
+
+	relayChains := make(map[tableland.ChainID]relayimpl.Chain, len(chainStacks))
+	for chainID, stack := range chainStacks {
+		relayChains[chainID] = relayimpl.Chain{
+			Registry:        stack.Registry,
+			ContractAddress: stack.RegistryAddress,
+		}
+	}
+
+	r, err := relayimpl.NewRelay(
+		db,
+		parser,
+		impl.NewACL(db),
+		relayWallet.Address(),
+		relayChains,
+		relay.WithQuota(config.MaxWritesPerInterval, quotaInterval),
+		relay.WithChainBudget(config.MaxChainWritesPerInterval, config.MaxChainGasPerInterval),
+		relay.WithAllowedSigners(allowedSigners...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating relay: %s", err)
+	}
+	log.Info().Str("wallet", relayWallet.Address().Hex()).Msg("relay enabled")
+	return r, nil
+}
+
 func configureTelemetry(
 	dirPath string,
 	db *database.SQLiteDB,
@@ -382,9 +545,12 @@ func createChainStacks(
 	db *database.SQLiteDB,
 	parser parsing.SQLValidator,
 	sm *sharedmemory.SharedMemory,
+	hub *pubsub.Hub,
 	chainsConfig []ChainConfig,
 	tableConstraintsConfig TableConstraints,
 	fetchExtraBlockInfo bool,
+	relayConfig RelayConfig,
+	relayWallet *wallet.Wallet,
 ) (map[tableland.ChainID]chains.ChainStack, moduleCloser, error) {
 	chainStacks := map[tableland.ChainID]chains.ChainStack{}
 	for _, chainCfg := range chainsConfig {
@@ -396,8 +562,11 @@ func createChainStacks(
 			db,
 			parser,
 			sm,
+			hub,
 			tableConstraintsConfig,
-			fetchExtraBlockInfo)
+			fetchExtraBlockInfo,
+			relayConfig,
+			relayWallet)
 		if err != nil {
 			return nil, nil, fmt.Errorf("creating chain_id=%d stack: %s", chainCfg.ChainID, err)
 		}
@@ -429,11 +598,16 @@ func createChainStacks(
 
 func createAPIServer(
 	httpConfig HTTPConfig,
+	grpcConfig GRPCConfig,
 	gatewayConfig GatewayConfig,
+	tableConstraints TableConstraints,
+	queryConstraints QueryConstraints,
 	parser parsing.SQLValidator,
 	db *database.SQLiteDB,
 	sm *sharedmemory.SharedMemory,
+	hub *pubsub.Hub,
 	chainStacks map[tableland.ChainID]chains.ChainStack,
+	writeRelay relay.Relay,
 ) (moduleCloser, error) {
 	supportedChainIDs := make([]tableland.ChainID, 0, len(chainStacks))
 	eps := make(map[tableland.ChainID]eventprocessor.EventProcessor, len(chainStacks))
@@ -444,13 +618,46 @@ func createAPIServer(
 
 	resolver := parsing.NewReadStatementResolver(sm)
 
+	readQueryTimeout, err := time.ParseDuration(queryConstraints.ReadQueryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("parsing read query timeout: %s", err)
+	}
+	dbStore := gatewayimpl.NewGatewayStore(
+		db,
+		gatewayimpl.WithReadQueryTimeout(readQueryTimeout),
+		gatewayimpl.WithReadQueryBudget(queryConstraints.MaxReadQueryInstructions),
+	)
+	var store gateway.GatewayStore = dbStore
+	closeCache := func() {}
+	if gatewayConfig.ReadCache.Enabled {
+		cachedStore, err := gatewayimpl.NewCachedGatewayStore(
+			dbStore,
+			hub,
+			gatewayConfig.ReadCache.MaxEntries,
+			gatewayConfig.ReadCache.MaxEntryRows,
+			gatewayConfig.ReadCache.MaxBytes,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("creating read cache: %s", err)
+		}
+		store, closeCache = cachedStore, cachedStore.Close
+	}
+
+	gatewayOpts := []gateway.GatewayOption{
+		gateway.WithACL(impl.NewACL(db)),
+		gateway.WithMaxTableRowCount(tableConstraints.MaxRowCount),
+	}
+	if gatewayConfig.RenderImages {
+		gatewayOpts = append(gatewayOpts, gateway.WithRenderedImages())
+	}
 	g, err := gateway.NewGateway(
 		parser,
-		gatewayimpl.NewGatewayStore(db),
+		store,
 		resolver,
 		gatewayConfig.ExternalURIPrefix,
 		gatewayConfig.MetadataRendererURI,
-		gatewayConfig.AnimationRendererURI)
+		gatewayConfig.AnimationRendererURI,
+		gatewayOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating gateway: %s", err)
 	}
@@ -458,18 +665,64 @@ func createAPIServer(
 	if err != nil {
 		return nil, fmt.Errorf("instrumenting gateway: %s", err)
 	}
-	rateLimInterval, err := time.ParseDuration(httpConfig.RateLimInterval)
+	rateLimCfg, err := rateLimiterConfig(httpConfig, db)
 	if err != nil {
-		return nil, fmt.Errorf("parsing http ratelimiter interval: %s", err)
+		return nil, fmt.Errorf("creating rate limiter config: %s", err)
+	}
+	closeRateLimStore := func() error { return nil }
+	switch {
+	case httpConfig.RateLimStorePath != "" && httpConfig.RateLimRedisURL != "":
+		return nil, fmt.Errorf("rate limiter store path and redis url can't be set together")
+	case httpConfig.RateLimStorePath != "":
+		rateLimStore, err := middlewares.NewSQLiteRateLimiterStore(httpConfig.RateLimStorePath)
+		if err != nil {
+			return nil, fmt.Errorf("creating rate limiter store: %s", err)
+		}
+		rateLimCfg.Store, closeRateLimStore = rateLimStore, rateLimStore.Close
+	case httpConfig.RateLimRedisURL != "":
+		rateLimStore, err := middlewares.NewRedisRateLimiterStore(httpConfig.RateLimRedisURL)
+		if err != nil {
+			return nil, fmt.Errorf("creating redis rate limiter store: %s", err)
+		}
+		rateLimCfg.Store, closeRateLimStore = rateLimStore, rateLimStore.Close
+	}
+	rateLim, err := middlewares.NewRateLimiter(rateLimCfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating rate limiter: %s", err)
+	}
+	apiKeysReloadInterval, err := time.ParseDuration(httpConfig.APIKeysReloadInterval)
+	if err != nil {
+		return nil, fmt.Errorf("parsing api keys reload interval: %s", err)
 	}
 
-	router, err := router.ConfiguredRouter(
-		g,
-		httpConfig.MaxRequestPerInterval,
-		rateLimInterval,
-		supportedChainIDs,
-		httpConfig.APIKey,
-	)
+	cacheMaxAge, err := time.ParseDuration(httpConfig.CacheMaxAge)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cache max age: %s", err)
+	}
+
+	ctrlOpts := []controllers.ControllerOption{
+		controllers.WithMaxReadRowCount(queryConstraints.MaxReadRowCount),
+		controllers.WithMaxReadResponseSize(queryConstraints.MaxReadResponseSize),
+		controllers.WithCacheMaxAge(cacheMaxAge),
+	}
+	if writeRelay != nil {
+		ctrlOpts = append(ctrlOpts, controllers.WithRelay(writeRelay))
+	}
+	if gatewayConfig.SignedReads.Enabled {
+		if gatewayConfig.SignedReads.WalletPrivateKey == "" {
+			return nil, fmt.Errorf("signed reads need a wallet private key")
+		}
+		signer, err := wallet.NewWallet(gatewayConfig.SignedReads.WalletPrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("creating signed reads wallet: %s", err)
+		}
+		if queryConstraints.MaxReadResponseSize <= 0 {
+			return nil, fmt.Errorf("signed reads are buffered, so they need a max read response size")
+		}
+		ctrlOpts = append(ctrlOpts, controllers.WithSignedReads(signer))
+		log.Info().Str("wallet", signer.Address().Hex()).Msg("signed reads enabled")
+	}
+	router, err := router.ConfiguredRouter(g, hub, rateLim, supportedChainIDs, ctrlOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("configuring router: %s", err)
 	}
@@ -509,6 +762,15 @@ func createAPIServer(
 		server.Addr = ":443"
 	}
 
+	closeGRPCServer := closerNoop
+	if grpcConfig.Enabled {
+		closeGRPCServer, err = createGRPCServer(
+			grpcConfig, server.TLSConfig, g, rateLim, supportedChainIDs, queryConstraints)
+		if err != nil {
+			return nil, fmt.Errorf("creating gRPC server: %s", err)
+		}
+	}
+
 	go func() {
 		if httpConfig.TLSCert != "" {
 			if err := server.ListenAndServeTLS("", ""); err != nil {
@@ -529,17 +791,195 @@ func createAPIServer(
 		}
 	}()
 
+	reloadCtx, stopReload := context.WithCancel(context.Background())
+	go rateLim.ReloadAPIKeys(reloadCtx, apiKeysReloadInterval, func(ctx context.Context) (
+		map[string]middlewares.RateLimiterRouteConfig, error,
+	) {
+		return rateLimiterAPIKeys(ctx, httpConfig, db)
+	})
+
 	closeModule := func(ctx context.Context) error {
 		if err := server.Shutdown(ctx); err != nil {
 			return fmt.Errorf("closing HTTP server")
 		}
+		if err := closeGRPCServer(ctx); err != nil {
+			return fmt.Errorf("closing gRPC server: %s", err)
+		}
+		closeCache()
+		stopReload()
+		if err := closeRateLimStore(); err != nil {
+			return fmt.Errorf("closing rate limiter store: %s", err)
+		}
 		return nil
 	}
 
 	return closeModule, nil
 }
 
-func createBackuper(dirPath string, config BackupConfig) (moduleCloser, error) {
+func createGRPCServer(
+	grpcConfig GRPCConfig,
+	tlsConfig *tls.Config,
+	g gateway.Gateway,
+	rateLim *middlewares.RateLimiter,
+	supportedChainIDs []tableland.ChainID,
+	queryConstraints QueryConstraints,
+) (moduleCloser, error) {
+	var serverOpts []grpc.ServerOption
+	if tlsConfig != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	server := router.ConfiguredGRPCServer(
+		g,
+		rateLim,
+		supportedChainIDs,
+		serverOpts,
+		controllers.WithMaxReadRowCount(queryConstraints.MaxReadRowCount),
+		controllers.WithMaxReadResponseSize(queryConstraints.MaxReadResponseSize),
+	)
+
+	listener, err := net.Listen("tcp", ":"+grpcConfig.Port)
+	if err != nil {
+		return nil, fmt.Errorf("listening on port %s: %s", grpcConfig.Port, err)
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatal().Err(err).Str("port", grpcConfig.Port).Msg("couldn't start gRPC server")
+		}
+		log.Info().Msg("gRPC server gracefully closed")
+	}()
+
+	return func(ctx context.Context) error {
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			server.Stop()
+		}
+		return nil
+	}, nil
+}
+
+func rateLimiterConfig(httpConfig HTTPConfig, db *database.SQLiteDB) (middlewares.RateLimiterConfig, error) {
+	rateLimInterval, err := time.ParseDuration(httpConfig.RateLimInterval)
+	if err != nil {
+		return middlewares.RateLimiterConfig{}, fmt.Errorf("parsing http ratelimiter interval: %s", err)
+	}
+	parseInterval := func(interval string) (time.Duration, error) {
+		if interval == "" {
+			return rateLimInterval, nil
+		}
+		return time.ParseDuration(interval)
+	}
+
+	cfg := middlewares.RateLimiterConfig{
+		Default: middlewares.RateLimiterRouteConfig{
+			MaxRPI:   httpConfig.MaxRequestPerInterval,
+			Interval: rateLimInterval,
+			APIKey:   httpConfig.APIKey,
+		},
+		Routes: map[string]middlewares.RateLimiterRouteConfig{},
+	}
+
+	for _, route := range httpConfig.RouteRateLimits {
+		interval, err := parseInterval(route.RateLimInterval)
+		if err != nil {
+			return middlewares.RateLimiterConfig{}, fmt.Errorf("parsing %s ratelimiter interval: %s", route.Route, err)
+		}
+		cfg.Routes[route.Route] = middlewares.RateLimiterRouteConfig{
+			MaxRPI:   route.MaxRequestPerInterval,
+			Interval: interval,
+		}
+	}
+
+	if cfg.APIKeys, err = rateLimiterAPIKeys(context.Background(), httpConfig, db); err != nil {
+		return middlewares.RateLimiterConfig{}, err
+	}
+
+	return cfg, nil
+}
+
+// rateLimiterAPIKeys returns the rate limiting tiers of the API keys in the system_api_keys table and in
+// the config, which take precedence.
+func rateLimiterAPIKeys(
+	ctx context.Context, httpConfig HTTPConfig, db *database.SQLiteDB,
+) (map[string]middlewares.RateLimiterRouteConfig, error) {
+	rateLimInterval, err := time.ParseDuration(httpConfig.RateLimInterval)
+	if err != nil {
+		return nil, fmt.Errorf("parsing http ratelimiter interval: %s", err)
+	}
+
+	tiers := map[string]middlewares.RateLimiterRouteConfig{}
+	apiKeys, err := db.Queries.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading api keys: %s", err)
+	}
+	for _, apiKey := range apiKeys {
+		tiers[strings.ToLower(apiKey.ApiKey)] = middlewares.RateLimiterRouteConfig{
+			MaxRPI:   uint64(apiKey.MaxRpi),
+			Interval: time.Duration(apiKey.IntervalMs) * time.Millisecond,
+		}
+	}
+	for _, apiKey := range httpConfig.APIKeys {
+		interval := rateLimInterval
+		if apiKey.RateLimInterval != "" {
+			if interval, err = time.ParseDuration(apiKey.RateLimInterval); err != nil {
+				return nil, fmt.Errorf("parsing api key ratelimiter interval: %s", err)
+			}
+		}
+		tiers[strings.ToLower(apiKey.Key)] = middlewares.RateLimiterRouteConfig{
+			MaxRPI:   apiKey.MaxRequestPerInterval,
+			Interval: interval,
+		}
+	}
+
+	return tiers, nil
+}
+
+func createAdminServer(
+	adminConfig AdminConfig,
+	chainStacks map[tableland.ChainID]chains.ChainStack,
+	backupScheduler *backup.Scheduler,
+) (moduleCloser, error) {
+	// A nil scheduler must be a nil interface, so the controller knows backups are disabled.
+	var backuper controllers.Backuper
+	if backupScheduler != nil {
+		backuper = backupScheduler
+	}
+	router, err := router.ConfiguredAdminRouter(chainStacks, backuper, adminConfig.APIKey)
+	if err != nil {
+		return nil, fmt.Errorf("configuring admin router: %s", err)
+	}
+
+	server := &http.Server{
+		Addr:         ":" + adminConfig.Port,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 5 * time.Minute, // backups can take a while
+		IdleTimeout:  120 * time.Second,
+		Handler:      router.Handler(),
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil {
+			if err == http.ErrServerClosed {
+				log.Info().Msg("admin server gracefully closed")
+				return
+			}
+			log.Fatal().Err(err).Str("port", adminConfig.Port).Msg("couldn't start admin server")
+		}
+	}()
+
+	return func(ctx context.Context) error {
+		if err := server.Shutdown(ctx); err != nil {
+			return fmt.Errorf("closing admin server: %s", err)
+		}
+		return nil
+	}, nil
+}
+
+func createBackuper(dirPath string, config BackupConfig) (*backup.Scheduler, moduleCloser, error) {
 	backupScheduler, err := backup.NewScheduler(config.Frequency, backup.BackuperOptions{
 		SourcePath: path.Join(dirPath, "database.db"),
 		BackupDir:  path.Join(dirPath, config.Dir),
@@ -550,7 +990,7 @@ func createBackuper(dirPath string, config BackupConfig) (moduleCloser, error) {
 		},
 	}, false)
 	if err != nil {
-		return nil, fmt.Errorf("creating backup scheduler: %s", err)
+		return nil, nil, fmt.Errorf("creating backup scheduler: %s", err)
 	}
 	go backupScheduler.Run()
 
@@ -559,5 +999,5 @@ func createBackuper(dirPath string, config BackupConfig) (moduleCloser, error) {
 		return nil
 	}
 
-	return closeModule, nil
+	return backupScheduler, closeModule, nil
 }
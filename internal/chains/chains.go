@@ -3,12 +3,18 @@ package chains
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/textileio/go-tableland/pkg/eventprocessor"
+	"github.com/textileio/go-tableland/pkg/tables"
 )
 
 // ChainStack contains components running for a specific ChainID.
 type ChainStack struct {
 	EventProcessor eventprocessor.EventProcessor
+	// Registry sends transactions to the registry contract at RegistryAddress with the relay wallet. It's nil
+	// if the relay is disabled.
+	Registry        tables.TablelandTables
+	RegistryAddress common.Address
 	// close gracefully closes all the chain stack components.
 	Close func(ctx context.Context) error
 }
@@ -0,0 +1,214 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/apache/arrow/go/v11/arrow"
+	"github.com/apache/arrow/go/v11/arrow/array"
+	"github.com/apache/arrow/go/v11/arrow/ipc"
+	"github.com/apache/arrow/go/v11/arrow/memory"
+	"github.com/textileio/go-tableland/internal/gateway"
+)
+
+// arrowBatchSize is the number of rows of each Arrow record batch.
+const arrowBatchSize = 1024
+
+// arrowStream writes rows as an Arrow IPC stream of record batches. Since the schema must be known before
+// writing the first batch, column types are inferred from the rows of the first batch.
+type arrowStream struct {
+	w       io.Writer
+	pending *gateway.TableData
+
+	ipc     *ipc.Writer
+	builder *array.RecordBuilder
+	// batchRows is the number of rows appended to the builder since the last record batch.
+	batchRows int
+}
+
+func newArrowStream(w io.Writer, columns []gateway.Column) *arrowStream {
+	return &arrowStream{
+		w:       w,
+		pending: &gateway.TableData{Columns: columns},
+	}
+}
+
+func (s *arrowStream) append(row []*gateway.ColumnValue) error {
+	if s.builder == nil {
+		s.pending.Rows = append(s.pending.Rows, row)
+		if len(s.pending.Rows) < arrowBatchSize {
+			return nil
+		}
+		return s.flushPending()
+	}
+
+	for i, val := range row {
+		if err := appendArrowValue(s.builder.Field(i), val.Value()); err != nil {
+			return fmt.Errorf("column %s: %s", s.pending.Columns[i].Name, err)
+		}
+	}
+	s.batchRows++
+	if s.batchRows < arrowBatchSize {
+		return nil
+	}
+	return s.writeRecord()
+}
+
+// flushPending infers the schema from the pending rows and writes them as the first record batch.
+func (s *arrowStream) flushPending() error {
+	fields := make([]arrow.Field, len(s.pending.Columns))
+	for i, col := range s.pending.Columns {
+		fields[i] = arrow.Field{Name: col.Name, Type: arrowType(s.pending, i), Nullable: true}
+	}
+	schema := arrow.NewSchema(fields, nil)
+
+	s.builder = array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	s.ipc = ipc.NewWriter(s.w, ipc.WithSchema(schema))
+	for _, row := range s.pending.Rows {
+		for i, val := range row {
+			if err := appendArrowValue(s.builder.Field(i), val.Value()); err != nil {
+				return fmt.Errorf("column %s: %s", s.pending.Columns[i].Name, err)
+			}
+		}
+	}
+	s.pending.Rows = nil
+	return s.writeRecord()
+}
+
+func (s *arrowStream) writeRecord() error {
+	rec := s.builder.NewRecord()
+	defer rec.Release()
+	s.batchRows = 0
+	if err := s.ipc.Write(rec); err != nil {
+		return fmt.Errorf("writing record: %w", err)
+	}
+	return nil
+}
+
+func (s *arrowStream) close() error {
+	if s.builder == nil {
+		if err := s.flushPending(); err != nil {
+			return err
+		}
+	} else if s.batchRows > 0 {
+		if err := s.writeRecord(); err != nil {
+			return err
+		}
+	}
+	defer s.builder.Release()
+	if err := s.ipc.Close(); err != nil {
+		return fmt.Errorf("closing writer: %w", err)
+	}
+	return nil
+}
+
+// arrowType returns the Arrow type of a column. The type of the values has precedence over the declared
+// type, since SQLite columns without a declared type, or declared as ANY, can hold values of any type.
+// Columns mixing integers and reals are Float64, and columns mixing any other types are String.
+func arrowType(in *gateway.TableData, col int) arrow.DataType {
+	var typ arrow.DataType
+	for _, row := range in.Rows {
+		valType := valueArrowType(row[col].Value())
+		switch {
+		case valType == nil:
+		case typ == nil:
+			typ = valType
+		case typ.ID() == valType.ID():
+		case isArrowNumeric(typ) && isArrowNumeric(valType):
+			typ = arrow.PrimitiveTypes.Float64
+		default:
+			return arrow.BinaryTypes.String
+		}
+	}
+	if typ != nil {
+		return typ
+	}
+
+	// All values are NULL, so we follow SQLite's type affinity rules on the declared type.
+	declared := strings.ToUpper(in.Columns[col].Type)
+	switch {
+	case strings.Contains(declared, "INT"):
+		return arrow.PrimitiveTypes.Int64
+	case strings.Contains(declared, "CHAR"), strings.Contains(declared, "CLOB"), strings.Contains(declared, "TEXT"):
+		return arrow.BinaryTypes.String
+	case strings.Contains(declared, "BLOB"):
+		return arrow.BinaryTypes.Binary
+	case strings.Contains(declared, "REAL"), strings.Contains(declared, "FLOA"), strings.Contains(declared, "DOUB"):
+		return arrow.PrimitiveTypes.Float64
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+func valueArrowType(v interface{}) arrow.DataType {
+	switch v.(type) {
+	case nil:
+		return nil
+	case int64, int:
+		return arrow.PrimitiveTypes.Int64
+	case float64:
+		return arrow.PrimitiveTypes.Float64
+	case bool:
+		return arrow.FixedWidthTypes.Boolean
+	case []byte:
+		return arrow.BinaryTypes.Binary
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+func isArrowNumeric(t arrow.DataType) bool {
+	return t.ID() == arrow.INT64 || t.ID() == arrow.FLOAT64
+}
+
+func appendArrowValue(b array.Builder, v interface{}) error {
+	if v == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	switch b := b.(type) {
+	case *array.Int64Builder:
+		switch v := v.(type) {
+		case int64:
+			b.Append(v)
+		case int:
+			b.Append(int64(v))
+		default:
+			return fmt.Errorf("unexpected %T value for an integer column", v)
+		}
+	case *array.Float64Builder:
+		switch v := v.(type) {
+		case float64:
+			b.Append(v)
+		case int64:
+			b.Append(float64(v))
+		case int:
+			b.Append(float64(v))
+		default:
+			return fmt.Errorf("unexpected %T value for a real column", v)
+		}
+	case *array.BooleanBuilder:
+		bv, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected %T value for a boolean column", v)
+		}
+		b.Append(bv)
+	case *array.BinaryBuilder:
+		bv, ok := v.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected %T value for a blob column", v)
+		}
+		b.Append(bv)
+	case *array.StringBuilder:
+		s, err := valueToString(v)
+		if err != nil {
+			return err
+		}
+		b.Append(s)
+	default:
+		return fmt.Errorf("unsupported builder %T", b)
+	}
+	return nil
+}
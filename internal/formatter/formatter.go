@@ -2,6 +2,7 @@ package formatter
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 
@@ -16,11 +17,20 @@ const (
 	Table Output = "table"
 	// Objects returns the query results as a JSON array of JSON objects. This is the default.
 	Objects Output = "objects"
+	// CSV returns the query results as CSV with a header row of column names.
+	CSV Output = "csv"
+	// NDJSON returns the query results as newline delimited JSON objects.
+	NDJSON Output = "ndjson"
+	// Arrow returns the query results as an Apache Arrow IPC stream.
+	Arrow Output = "arrow"
 )
 
 var outputsMap = map[string]Output{
 	"table":   Table,
 	"objects": Objects,
+	"csv":     CSV,
+	"ndjson":  NDJSON,
+	"arrow":   Arrow,
 }
 
 // OutputFromString converts a string into an Output.
@@ -34,6 +44,25 @@ type FormatConfig struct {
 	Output  Output
 	Unwrap  bool
 	Extract bool
+
+	// MaxRowCount is the maximum number of rows that can be formatted. Zero means no limit.
+	MaxRowCount int
+	// MaxSize is the maximum size in bytes of the formatted results. Zero means no limit.
+	MaxSize int
+}
+
+// ContentType returns the media type of the formatted results.
+func (c FormatConfig) ContentType() string {
+	switch c.Output {
+	case CSV:
+		return "text/csv"
+	case NDJSON:
+		return "application/x-ndjson"
+	case Arrow:
+		return "application/vnd.apache.arrow.stream"
+	default:
+		return "application/json"
+	}
 }
 
 // FormatOption controls the behavior of calls to Format.
@@ -63,86 +92,86 @@ func WithExtract(extract bool) FormatOption {
 	}
 }
 
-// Format transforms the user rows according to the provided configuration, retuning raw json or jsonl bytes.
-func Format(userRows *gateway.TableData, opts ...FormatOption) ([]byte, FormatConfig, error) {
-	c := FormatConfig{
-		Output: Objects,
-	}
-	for _, opt := range opts {
-		opt(&c)
+// WithMaxRowCount limits the number of rows that can be formatted.
+// Default is zero, which means no limit.
+func WithMaxRowCount(count int) FormatOption {
+	return func(fc *FormatConfig) {
+		fc.MaxRowCount = count
 	}
+}
 
-	if c.Output == Table {
-		b, err := json.Marshal(userRows)
-		if err != nil {
-			return nil, FormatConfig{}, fmt.Errorf("marshaling to json: %v", err)
-		}
-		return b, c, nil
+// WithMaxSize limits the size in bytes of the formatted results.
+// Default is zero, which means no limit.
+func WithMaxSize(size int) FormatOption {
+	return func(fc *FormatConfig) {
+		fc.MaxSize = size
 	}
+}
 
-	objects := toObjects(userRows)
-	var err error
+// ErrMaxRowCountExceeded is returned when the results have more rows than allowed.
+type ErrMaxRowCountExceeded struct {
+	MaxAllowed int
+}
 
-	if c.Extract {
-		objects, err = extract(objects)
-		if err != nil {
-			return nil, FormatConfig{}, fmt.Errorf("extracting values: %s", err)
-		}
-	}
+func (e *ErrMaxRowCountExceeded) Error() string {
+	return fmt.Sprintf("query results have too many rows (max %d)", e.MaxAllowed)
+}
 
-	if !c.Unwrap {
-		b, err := json.Marshal(objects)
-		if err != nil {
-			return nil, FormatConfig{}, fmt.Errorf("marshaling to json: %v", err)
-		}
-		return b, c, nil
-	}
+// ErrMaxSizeExceeded is returned when the formatted results are bigger than allowed.
+type ErrMaxSizeExceeded struct {
+	MaxAllowed int
+}
 
-	unwrapped, err := unwrap(objects)
-	if err != nil {
-		return nil, FormatConfig{}, fmt.Errorf("unwrapping values: %s", err)
-	}
-	return unwrapped, c, nil
+func (e *ErrMaxSizeExceeded) Error() string {
+	return fmt.Sprintf("query results are too large (max %d bytes)", e.MaxAllowed)
 }
 
-func toObjects(in *gateway.TableData) []interface{} {
-	objects := make([]interface{}, len(in.Rows))
-	for i, row := range in.Rows {
-		object := make(map[string]interface{}, len(row))
-		for j, val := range row {
-			object[in.Columns[j].Name] = val
+// Format transforms the user rows according to the provided configuration, retuning raw json or jsonl bytes,
+// or the bytes of the requested output. Unwrap only applies to the Objects output, and Extract to the
+// Objects and NDJSON outputs.
+func Format(userRows *gateway.TableData, opts ...FormatOption) ([]byte, FormatConfig, error) {
+	buf := bytes.NewBuffer([]byte{})
+	w := NewStreamWriter(buf, opts...)
+	if err := w.WriteColumns(userRows.Columns); err != nil {
+		return nil, FormatConfig{}, err
+	}
+	for _, row := range userRows.Rows {
+		if err := w.WriteRow(row); err != nil {
+			return nil, FormatConfig{}, err
 		}
-		objects[i] = object
 	}
-	return objects
+	if err := w.Close(); err != nil {
+		return nil, FormatConfig{}, err
+	}
+
+	return buf.Bytes(), w.Config(), nil
 }
 
-func extract(in []interface{}) ([]interface{}, error) {
-	extracted := make([]interface{}, len(in))
-	for i, item := range in {
-		object := item.(map[string]interface{})
-		if len(object) != 1 {
-			return nil, fmt.Errorf("can only extract values for result sets with one column but this has %d", len(object))
-		}
-		for _, val := range object {
-			extracted[i] = val
-			break
-		}
+func toObject(columns []gateway.Column, row []*gateway.ColumnValue) map[string]interface{} {
+	object := make(map[string]interface{}, len(row))
+	for j, val := range row {
+		object[columns[j].Name] = val
 	}
-	return extracted, nil
+	return object
 }
 
-func unwrap(in []interface{}) ([]byte, error) {
-	buf := bytes.NewBuffer([]byte{})
-	for i, item := range in {
-		if i != 0 {
-			_, _ = buf.Write([]byte("\n"))
-		}
-		b, err := json.Marshal(item)
+// valueToString returns the text representation of a value. NULL is an empty string,
+// blobs are base64 encoded and any other value has its JSON representation.
+func valueToString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.RawMessage:
+		return string(v), nil
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v), nil
+	default:
+		b, err := json.Marshal(v)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
-		_, _ = buf.Write(b)
+		return string(b), nil
 	}
-	return buf.Bytes(), nil
 }
@@ -1,9 +1,13 @@
 package formatter
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 
+	"github.com/apache/arrow/go/v11/arrow"
+	"github.com/apache/arrow/go/v11/arrow/array"
+	"github.com/apache/arrow/go/v11/arrow/ipc"
 	"github.com/stretchr/testify/require"
 	"github.com/textileio/go-tableland/internal/gateway"
 )
@@ -126,6 +130,154 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+func TestFormatCSV(t *testing.T) {
+	t.Parallel()
+
+	got, config, err := Format(input, WithOutput(CSV))
+	require.NoError(t, err)
+	require.Equal(t, "text/csv", config.ContentType())
+	want := "name,age,location\nbob,40,\"{\"\"city\"\":\"\"dallas\"\"}\"\njane,30,\"{\"\"city\"\":\"\"dallas\"\"}\"\n"
+	require.Equal(t, want, string(got))
+}
+
+func TestFormatNDJSON(t *testing.T) {
+	t.Parallel()
+
+	got, config, err := Format(input, WithOutput(NDJSON))
+	require.NoError(t, err)
+	require.Equal(t, "application/x-ndjson", config.ContentType())
+	want := "{\"age\":40,\"location\":{\"city\":\"dallas\"},\"name\":\"bob\"}\n" +
+		"{\"age\":30,\"location\":{\"city\":\"dallas\"},\"name\":\"jane\"}\n"
+	require.Equal(t, want, string(got))
+
+	got, _, err = Format(inputExtractable, WithOutput(NDJSON), WithExtract(true))
+	require.NoError(t, err)
+	require.Equal(t, "\"bob\"\n\"jane\"\n", string(got))
+}
+
+func TestFormatArrow(t *testing.T) {
+	t.Parallel()
+
+	in := &gateway.TableData{
+		Columns: []gateway.Column{
+			{Name: "name", Type: "TEXT"},
+			{Name: "age", Type: "INTEGER"},
+			{Name: "score"},
+			{Name: "location"},
+			{Name: "nothing", Type: "INT"},
+		},
+		Rows: [][]*gateway.ColumnValue{
+			{
+				gateway.OtherColValue("bob"),
+				gateway.OtherColValue(int64(40)),
+				gateway.OtherColValue(int64(1)),
+				gateway.JSONColValue(rawJSON),
+				gateway.OtherColValue(nil),
+			},
+			{
+				gateway.OtherColValue("jane"),
+				gateway.OtherColValue(nil),
+				gateway.OtherColValue(1.5),
+				gateway.JSONColValue(rawJSON),
+				gateway.OtherColValue(nil),
+			},
+		},
+	}
+
+	got, config, err := Format(in, WithOutput(Arrow))
+	require.NoError(t, err)
+	require.Equal(t, "application/vnd.apache.arrow.stream", config.ContentType())
+
+	r, err := ipc.NewReader(bytes.NewReader(got))
+	require.NoError(t, err)
+	defer r.Release()
+
+	schema := r.Schema()
+	require.Equal(t, arrow.STRING, schema.Field(0).Type.ID())
+	require.Equal(t, arrow.INT64, schema.Field(1).Type.ID())
+	require.Equal(t, arrow.FLOAT64, schema.Field(2).Type.ID())
+	require.Equal(t, arrow.STRING, schema.Field(3).Type.ID())
+	require.Equal(t, arrow.INT64, schema.Field(4).Type.ID())
+
+	require.True(t, r.Next())
+	rec := r.Record()
+	require.Equal(t, int64(2), rec.NumRows())
+	require.Equal(t, "jane", rec.Column(0).(*array.String).Value(1))
+	require.Equal(t, int64(40), rec.Column(1).(*array.Int64).Value(0))
+	require.True(t, rec.Column(1).IsNull(1))
+	require.Equal(t, 1.0, rec.Column(2).(*array.Float64).Value(0))
+	require.Equal(t, 1.5, rec.Column(2).(*array.Float64).Value(1))
+	require.Equal(t, string(rawJSON), rec.Column(3).(*array.String).Value(0))
+	require.Equal(t, 2, rec.Column(4).NullN())
+	require.False(t, r.Next())
+}
+
+func TestFormatArrowBatches(t *testing.T) {
+	t.Parallel()
+
+	in := &gateway.TableData{Columns: []gateway.Column{{Name: "id", Type: "INTEGER"}}}
+	for i := 0; i < 2*arrowBatchSize+1; i++ {
+		in.Rows = append(in.Rows, []*gateway.ColumnValue{gateway.OtherColValue(int64(i))})
+	}
+
+	got, _, err := Format(in, WithOutput(Arrow))
+	require.NoError(t, err)
+
+	r, err := ipc.NewReader(bytes.NewReader(got))
+	require.NoError(t, err)
+	defer r.Release()
+
+	var batches, rows int64
+	for r.Next() {
+		rec := r.Record()
+		require.Equal(t, rows, rec.Column(0).(*array.Int64).Value(0))
+		batches++
+		rows += rec.NumRows()
+	}
+	require.NoError(t, r.Err())
+	require.Equal(t, int64(3), batches)
+	require.Equal(t, int64(len(in.Rows)), rows)
+}
+
+func TestFormatLimits(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := Format(input, WithMaxRowCount(2))
+	require.NoError(t, err)
+
+	_, _, err = Format(input, WithMaxRowCount(1))
+	var errRowCount *ErrMaxRowCountExceeded
+	require.ErrorAs(t, err, &errRowCount)
+	require.Equal(t, 1, errRowCount.MaxAllowed)
+
+	got, _, err := Format(input, WithOutput(CSV))
+	require.NoError(t, err)
+	_, _, err = Format(input, WithOutput(CSV), WithMaxSize(len(got)))
+	require.NoError(t, err)
+
+	for _, output := range []Output{Table, Objects, CSV, NDJSON, Arrow} {
+		_, _, err = Format(input, WithOutput(output), WithMaxSize(20))
+		var errSize *ErrMaxSizeExceeded
+		require.ErrorAs(t, err, &errSize, output)
+		require.Equal(t, 20, errSize.MaxAllowed)
+	}
+}
+
+func TestStreamWriter(t *testing.T) {
+	t.Parallel()
+
+	buf := bytes.NewBuffer([]byte{})
+	w := NewStreamWriter(buf, WithOutput(Objects))
+	require.NoError(t, w.WriteColumns(input.Columns))
+	require.Equal(t, "[", buf.String())
+	require.NoError(t, w.WriteRow(input.Rows[0]))
+	require.Equal(t, `[{"age":40,"location":{"city":"dallas"},"name":"bob"}`, buf.String())
+	require.NoError(t, w.WriteRow(input.Rows[1]))
+	require.NoError(t, w.Close())
+	require.Equal(t, 2, w.Rows())
+	require.JSONEq(t, `[{"name":"bob","age":40,"location":{"city":"dallas"}},{"name":"jane","age":30,"location":{"city":"dallas"}}]`, buf.String()) // nolint
+}
+
 func parseJSONLString(val string) []string {
 	s := strings.TrimRight(val, "\n")
 	return strings.Split(s, "\n")
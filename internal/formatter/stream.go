@@ -0,0 +1,194 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/textileio/go-tableland/internal/gateway"
+)
+
+// StreamWriter formats rows as they are written, so results never need to be fully held in memory.
+// WriteColumns must be called once before any call to WriteRow, and Close after the last row.
+type StreamWriter struct {
+	config FormatConfig
+	w      *limitedWriter
+
+	columns []gateway.Column
+	rows    int
+
+	csv   *csv.Writer
+	arrow *arrowStream
+}
+
+var _ gateway.RowWriter = (*StreamWriter)(nil)
+
+// NewStreamWriter creates a new StreamWriter that writes the formatted rows to w.
+func NewStreamWriter(w io.Writer, opts ...FormatOption) *StreamWriter {
+	c := FormatConfig{
+		Output: Objects,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &StreamWriter{
+		config: c,
+		w:      &limitedWriter{w: w, max: c.MaxSize},
+	}
+}
+
+// Config returns the format configuration used by the writer.
+func (s *StreamWriter) Config() FormatConfig {
+	return s.config
+}
+
+// Rows returns the number of rows written so far.
+func (s *StreamWriter) Rows() int {
+	return s.rows
+}
+
+// WriteColumns writes the beginning of the results.
+func (s *StreamWriter) WriteColumns(columns []gateway.Column) error {
+	s.columns = columns
+
+	switch s.config.Output {
+	case Table:
+		b, err := json.Marshal(columns)
+		if err != nil {
+			return fmt.Errorf("marshaling to json: %v", err)
+		}
+		return s.write([]byte(`{"columns":`), b, []byte(`,"rows":[`))
+	case CSV:
+		s.csv = csv.NewWriter(s.w)
+		record := make([]string, len(columns))
+		for i, col := range columns {
+			record[i] = col.Name
+		}
+		if err := s.csv.Write(record); err != nil {
+			return fmt.Errorf("writing csv: %w", err)
+		}
+		return nil
+	case Arrow:
+		s.arrow = newArrowStream(s.w, columns)
+		return nil
+	}
+
+	if s.config.Extract && len(columns) != 1 {
+		return fmt.Errorf(
+			"extracting values: can only extract values for result sets with one column but this has %d", len(columns),
+		)
+	}
+	if s.config.Output == Objects && !s.config.Unwrap {
+		return s.write([]byte("["))
+	}
+	return nil
+}
+
+// WriteRow writes a single row of the results.
+func (s *StreamWriter) WriteRow(row []*gateway.ColumnValue) error {
+	if s.config.MaxRowCount > 0 && s.rows >= s.config.MaxRowCount {
+		return &ErrMaxRowCountExceeded{MaxAllowed: s.config.MaxRowCount}
+	}
+	s.rows++
+
+	switch s.config.Output {
+	case Table:
+		b, err := json.Marshal(row)
+		if err != nil {
+			return fmt.Errorf("marshaling to json: %v", err)
+		}
+		if s.rows > 1 {
+			return s.write([]byte(","), b)
+		}
+		return s.write(b)
+	case CSV:
+		record := make([]string, len(row))
+		for i, val := range row {
+			str, err := valueToString(val.Value())
+			if err != nil {
+				return fmt.Errorf("writing csv: %w", err)
+			}
+			record[i] = str
+		}
+		if err := s.csv.Write(record); err != nil {
+			return fmt.Errorf("writing csv: %w", err)
+		}
+		return nil
+	case Arrow:
+		if err := s.arrow.append(row); err != nil {
+			return fmt.Errorf("writing arrow stream: %w", err)
+		}
+		return nil
+	}
+
+	var item interface{} = toObject(s.columns, row)
+	if s.config.Extract {
+		item = row[0]
+	}
+	b, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("marshaling to json: %v", err)
+	}
+
+	switch {
+	case s.config.Output == NDJSON:
+		return s.write(b, []byte("\n"))
+	case s.rows == 1:
+		return s.write(b)
+	case s.config.Unwrap:
+		return s.write([]byte("\n"), b)
+	default:
+		return s.write([]byte(","), b)
+	}
+}
+
+// Close writes the end of the results. It doesn't close the underlying writer.
+func (s *StreamWriter) Close() error {
+	switch s.config.Output {
+	case Table:
+		return s.write([]byte("]}"))
+	case CSV:
+		s.csv.Flush()
+		if err := s.csv.Error(); err != nil {
+			return fmt.Errorf("writing csv: %w", err)
+		}
+		return nil
+	case Arrow:
+		if err := s.arrow.close(); err != nil {
+			return fmt.Errorf("writing arrow stream: %w", err)
+		}
+		return nil
+	case Objects:
+		if !s.config.Unwrap {
+			return s.write([]byte("]"))
+		}
+	}
+	return nil
+}
+
+func (s *StreamWriter) write(chunks ...[]byte) error {
+	for _, chunk := range chunks {
+		if _, err := s.w.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// limitedWriter fails with ErrMaxSizeExceeded instead of writing more than max bytes. A zero max means no limit.
+type limitedWriter struct {
+	w       io.Writer
+	max     int
+	written int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.max > 0 && l.written+len(p) > l.max {
+		return 0, &ErrMaxSizeExceeded{MaxAllowed: l.max}
+	}
+	n, err := l.w.Write(p)
+	l.written += n
+	return n, err
+}
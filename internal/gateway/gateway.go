@@ -2,12 +2,16 @@ package gateway
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,8 +23,40 @@ import (
 	"github.com/textileio/go-tableland/pkg/tables"
 )
 
-// ErrTableNotFound indicates that the table doesn't exist.
-var ErrTableNotFound = errors.New("table not found")
+var (
+	// ErrTableNotFound indicates that the table doesn't exist.
+	ErrTableNotFound = errors.New("table not found")
+
+	// ErrInvalidCursor indicates that the provided pagination cursor can't be decoded.
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrCursorExpired indicates that the tables queried by a paginated read query changed after the first
+	// page was read, and the change log doesn't reach the heights of the first page to rebuild them.
+	ErrCursorExpired = errors.New("cursor expired")
+
+	// ErrHistoryUnavailable indicates that the state of the queried tables at the requested block
+	// can't be rebuilt, because the block wasn't processed yet or the change log doesn't reach it.
+	ErrHistoryUnavailable = errors.New("table state at the requested block isn't available")
+)
+
+// ErrReadQueryTimeout indicates that a read query was interrupted because it ran longer than allowed.
+type ErrReadQueryTimeout struct {
+	Timeout time.Duration
+}
+
+func (e *ErrReadQueryTimeout) Error() string {
+	return fmt.Sprintf("read query exceeded the timeout of %s", e.Timeout)
+}
+
+// ErrReadQueryBudgetExceeded indicates that a read query was interrupted because it executed more
+// SQLite virtual machine instructions than allowed.
+type ErrReadQueryBudgetExceeded struct {
+	MaxInstructions int
+}
+
+func (e *ErrReadQueryBudgetExceeded) Error() string {
+	return fmt.Sprintf("read query is too expensive (max %d instructions)", e.MaxInstructions)
+}
 
 var log = logger.With().Str("component", "gateway").Logger()
 
@@ -30,21 +66,90 @@ const (
 
 	// DefaultAnimationURL is an empty string. It means that the attribute will not appear in the JSON metadata.
 	DefaultAnimationURL = ""
+
+	// ListTablesPageSize is the maximum number of tables returned in a single ListTables page.
+	ListTablesPageSize = 100
+
+	// ListReceiptsPageSize is the maximum number of receipts returned in a single ListReceipts page.
+	ListReceiptsPageSize = 100
+
+	// ListTableEventsPageSize is the maximum number of events returned in a single GetTableEvents page.
+	ListTableEventsPageSize = 100
+
+	// DefaultReadQueryPageSize is the number of rows returned in a read query page if not specified.
+	DefaultReadQueryPageSize = 1000
+
+	// MaxReadQueryPageSize is the maximum number of rows returned in a read query page.
+	MaxReadQueryPageSize = 10000
+
+	// MaxReadQueryBatchSize is the maximum number of read queries run in a single batch.
+	MaxReadQueryBatchSize = 20
 )
 
-// Gateway defines the gateway operations.
+// Gateway defines the gateway operations. The params of read queries are typed values, as accepted by
+// parsing.ReadStatementResolver.PrepareValues.
 type Gateway interface {
-	RunReadQuery(ctx context.Context, stmt string, params []string) (*TableData, error)
+	RunReadQuery(ctx context.Context, stmt string, params []any) (*TableData, error)
+	RunReadQueryPage(
+		ctx context.Context, stmt string, params []any, cursor string, pageSize int,
+	) (*TableData, string, error)
+	StreamReadQuery(ctx context.Context, stmt string, params []any, w RowWriter) error
+	StreamReadQueryAt(ctx context.Context, stmt string, params []any, block int64, w RowWriter) error
+	RunReadQueryBatch(ctx context.Context, queries []ReadQuery, w BatchWriter) error
 	GetTableMetadata(context.Context, tableland.ChainID, tables.TableID) (TableMetadata, error)
+	GetTableSchema(ctx context.Context, tableName string) (TableSchema, error)
 	GetReceiptByTransactionHash(context.Context, tableland.ChainID, common.Hash) (Receipt, bool, error)
+	GetReceiptsByTransactionHashes(context.Context, []ReceiptLookup) ([]Receipt, error)
+	ListTables(context.Context, tableland.ChainID, common.Address, string) ([]Table, string, error)
+	ListReceiptsAfter(context.Context, tableland.ChainID, int64, int64, int) ([]Receipt, error)
+	ListReceipts(context.Context, tableland.ChainID, ReceiptFilter, string) ([]Receipt, string, error)
+	GetTableACL(context.Context, tableland.ChainID, tables.TableID) (tableland.TableACL, error)
+	GetTableStats(context.Context, tableland.ChainID, tables.TableID) (TableStats, error)
+	GetTableImage(context.Context, tableland.ChainID, tables.TableID) ([]byte, error)
+	GetTableEvents(context.Context, tableland.ChainID, tables.TableID, string) ([]TableEvent, string, error)
+	GetBlockHeights(context.Context, []tableland.ChainID) (BlockHeights, error)
+	GetReadQueryHeights(ctx context.Context, stmt string) (BlockHeights, error)
+	GetStateHash(context.Context, tableland.ChainID, int64) (StateHash, bool, error)
 }
 
 // GatewayStore is the storage layer of the Gateway.
 type GatewayStore interface {
 	Read(context.Context, parsing.ReadStmt, sqlparser.ReadStatementResolver) (*TableData, error)
+	ReadPage(
+		context.Context, parsing.ReadStmt, sqlparser.ReadStatementResolver, BlockHeights, PagePosition, int,
+	) (*TableData, *PagePosition, BlockHeights, error)
+	ReadStream(context.Context, parsing.ReadStmt, sqlparser.ReadStatementResolver, RowWriter) error
+	ReadStreamAt(context.Context, parsing.ReadStmt, sqlparser.ReadStatementResolver, int64, RowWriter) error
+	ReadBatch(ctx context.Context, queries []string, w BatchWriter) error
 	GetTable(context.Context, tableland.ChainID, tables.TableID) (Table, error)
+	GetTableStats(context.Context, tableland.ChainID, tables.TableID) (TableStats, error)
 	GetSchemaByTableName(context.Context, string) (TableSchema, error)
 	GetReceipt(context.Context, tableland.ChainID, string) (Receipt, bool, error)
+	GetReceipts(context.Context, []ReceiptLookup) ([]Receipt, error)
+	ListTables(context.Context, tableland.ChainID, common.Address, int64, int) ([]Table, error)
+	ListReceiptsAfter(context.Context, tableland.ChainID, int64, int64, int) ([]Receipt, error)
+	ListReceipts(context.Context, tableland.ChainID, ReceiptFilter, int64, int64, int) ([]Receipt, error)
+	ListTableEvents(context.Context, tableland.ChainID, tables.TableID, int64, int64, int) ([]TableEvent, error)
+	GetBlockHeights(context.Context, []tableland.ChainID) (BlockHeights, error)
+	GetStateHash(context.Context, tableland.ChainID, int64) (StateHash, bool, error)
+}
+
+// RowWriter receives the results of a read query as they are read from the database.
+// WriteColumns is called once before the rows are written.
+type RowWriter interface {
+	WriteColumns([]Column) error
+	WriteRow([]*ColumnValue) error
+}
+
+// BatchWriter receives the results of the queries of a batch, one query at a time, as they are read from the
+// database. Queries are identified by their index in the batch.
+type BatchWriter interface {
+	// Begin returns the RowWriter receiving the results of a query, right before it runs. An error of the
+	// RowWriter stops reading the query, and it's passed to End as the error of the query.
+	Begin(i int) RowWriter
+	// End is called once for every query of the batch, after its results were written or when it failed,
+	// with its error. Queries that can't run, e.g. because they are invalid, only get End.
+	End(i int, err error)
 }
 
 // GatewayService implements the Gateway interface using SQLStore.
@@ -54,12 +159,41 @@ type GatewayService struct {
 	metadataRendererURI  string
 	animationRendererURI string
 	store                GatewayStore
+	acl                  tableland.ACL
+	maxTableRowCount     int
+	renderImages         bool
 
 	resolver *parsing.ReadStatementResolver
 }
 
 var _ (Gateway) = (*GatewayService)(nil)
 
+// GatewayOption modifies the configuration of a GatewayService.
+type GatewayOption func(*GatewayService)
+
+// WithACL sets the store of the access control rules of tables. Without it, GetTableACL fails.
+func WithACL(acl tableland.ACL) GatewayOption {
+	return func(g *GatewayService) {
+		g.acl = acl
+	}
+}
+
+// WithMaxTableRowCount sets the maximum number of rows of tables, reported in their stats.
+// Default is zero, which means no limit.
+func WithMaxTableRowCount(count int) GatewayOption {
+	return func(g *GatewayService) {
+		g.maxTableRowCount = count
+	}
+}
+
+// WithRenderedImages makes the metadata of tables point to images rendered by the gateway, served at
+// /api/v1/tables/{chainId}/{tableId}/image, when there's no metadata renderer.
+func WithRenderedImages() GatewayOption {
+	return func(g *GatewayService) {
+		g.renderImages = true
+	}
+}
+
 // NewGateway creates a new gateway service.
 func NewGateway(
 	parser parsing.SQLValidator,
@@ -68,6 +202,7 @@ func NewGateway(
 	extURLPrefix string,
 	metadataRendererURI string,
 	animationRendererURI string,
+	opts ...GatewayOption,
 ) (Gateway, error) {
 	if _, err := url.ParseRequestURI(extURLPrefix); err != nil {
 		return nil, fmt.Errorf("invalid external url prefix: %s", err)
@@ -87,14 +222,19 @@ func NewGateway(
 		}
 	}
 
-	return &GatewayService{
+	g := &GatewayService{
 		parser:               parser,
 		extURLPrefix:         extURLPrefix,
 		metadataRendererURI:  metadataRendererURI,
 		animationRendererURI: animationRendererURI,
 		store:                store,
 		resolver:             resolver,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g, nil
 }
 
 // GetTableMetadata returns table's metadata fetched from SQLStore.
@@ -140,6 +280,88 @@ func (g *GatewayService) GetTableMetadata(
 	}, nil
 }
 
+// GetTableACL returns the access control rules of a table.
+func (g *GatewayService) GetTableACL(
+	ctx context.Context, chainID tableland.ChainID, id tables.TableID,
+) (tableland.TableACL, error) {
+	if g.acl == nil {
+		return tableland.TableACL{}, errors.New("the access control rules aren't available")
+	}
+	if _, err := g.store.GetTable(ctx, chainID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return tableland.TableACL{}, ErrTableNotFound
+		}
+		return tableland.TableACL{}, fmt.Errorf("get table: %s", err)
+	}
+
+	acl, err := g.acl.GetTableACL(ctx, chainID, id)
+	if err != nil {
+		return tableland.TableACL{}, fmt.Errorf("get table acl: %s", err)
+	}
+
+	return acl, nil
+}
+
+// GetTableStats returns the statistics of a table.
+func (g *GatewayService) GetTableStats(
+	ctx context.Context, chainID tableland.ChainID, id tables.TableID,
+) (TableStats, error) {
+	stats, err := g.store.GetTableStats(ctx, chainID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return TableStats{}, ErrTableNotFound
+	}
+	if err != nil {
+		return TableStats{}, fmt.Errorf("get table stats: %s", err)
+	}
+	stats.MaxRowCount = g.maxTableRowCount
+
+	return stats, nil
+}
+
+// GetTableSchema returns the schema of a table given its name, like foo_1337_1. ErrTableNotFound is returned if
+// the name isn't the name of a table, including its prefix.
+func (g *GatewayService) GetTableSchema(ctx context.Context, tableName string) (TableSchema, error) {
+	if _, err := sqlparser.ValidateTargetTable(
+		&sqlparser.Table{Name: sqlparser.Identifier(tableName), IsTarget: true},
+	); err != nil {
+		return TableSchema{}, ErrTableNotFound
+	}
+	schema, err := g.store.GetSchemaByTableName(ctx, tableName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return TableSchema{}, ErrTableNotFound
+	}
+	if err != nil {
+		return TableSchema{}, fmt.Errorf("get table schema information: %s", err)
+	}
+	return schema, nil
+}
+
+// GetTableImage renders the SVG image of a table, showing its name, chain, row count, columns and creation date.
+func (g *GatewayService) GetTableImage(
+	ctx context.Context, chainID tableland.ChainID, id tables.TableID,
+) ([]byte, error) {
+	table, err := g.store.GetTable(ctx, chainID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTableNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get table: %s", err)
+	}
+	schema, err := g.store.GetSchemaByTableName(ctx, table.Name())
+	if err != nil {
+		return nil, fmt.Errorf("get table schema information: %s", err)
+	}
+
+	// The row count is taken from the table stats, which are cached until the last processed height changes,
+	// so images don't count the rows of the table on every request.
+	stats, err := g.store.GetTableStats(ctx, chainID, id)
+	if err != nil {
+		return nil, fmt.Errorf("get table stats: %s", err)
+	}
+
+	return renderTableImage(table, schema, stats.RowCount)
+}
+
 // GetReceiptByTransactionHash returns a receipt by transaction hash.
 func (g *GatewayService) GetReceiptByTransactionHash(
 	ctx context.Context, chainID tableland.ChainID, txnHash common.Hash,
@@ -165,26 +387,343 @@ func (g *GatewayService) GetReceiptByTransactionHash(
 	}, true, nil
 }
 
+// ListTables returns a page of the tables owned by an address, ordered by table id.
+// The cursor is the value returned by the previous call, or empty for the first page.
+// The returned cursor is empty when there are no more pages.
+func (g *GatewayService) ListTables(
+	ctx context.Context, chainID tableland.ChainID, owner common.Address, cursor string,
+) ([]Table, string, error) {
+	afterID := int64(-1)
+	if cursor != "" {
+		id, err := decodeListTablesCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		afterID = id
+	}
+
+	// We ask for one extra table to know if there's a next page.
+	tbls, err := g.store.ListTables(ctx, chainID, owner, afterID, ListTablesPageSize+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("listing tables: %s", err)
+	}
+
+	var nextCursor string
+	if len(tbls) > ListTablesPageSize {
+		tbls = tbls[:ListTablesPageSize]
+		nextCursor = encodeListTablesCursor(tbls[len(tbls)-1].ID.ToBigInt().Int64())
+	}
+
+	return tbls, nextCursor, nil
+}
+
+// GetReceiptsByTransactionHashes returns the receipts of the transactions that were found, in no particular
+// order, with a single lookup.
+func (g *GatewayService) GetReceiptsByTransactionHashes(
+	ctx context.Context, lookups []ReceiptLookup,
+) ([]Receipt, error) {
+	receipts, err := g.store.GetReceipts(ctx, lookups)
+	if err != nil {
+		return nil, fmt.Errorf("transaction receipts lookup: %s", err)
+	}
+	return receipts, nil
+}
+
+// ListReceiptsAfter returns up to limit receipts executed after the provided block number and
+// index in block, ordered by execution.
+func (g *GatewayService) ListReceiptsAfter(
+	ctx context.Context, chainID tableland.ChainID, blockNumber int64, indexInBlock int64, limit int,
+) ([]Receipt, error) {
+	receipts, err := g.store.ListReceiptsAfter(ctx, chainID, blockNumber, indexInBlock, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing receipts: %s", err)
+	}
+	return receipts, nil
+}
+
+// ListReceipts returns a page of the receipts that match the filter, ordered by execution.
+// The cursor is the value returned by the previous call, or empty for the first page.
+// The returned cursor is empty when there are no more pages.
+func (g *GatewayService) ListReceipts(
+	ctx context.Context, chainID tableland.ChainID, filter ReceiptFilter, cursor string,
+) ([]Receipt, string, error) {
+	afterBlock, afterIndex := filter.FromBlock-1, int64(math.MaxInt64)
+	if cursor != "" {
+		var err error
+		afterBlock, afterIndex, err = decodePositionCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// We ask for one extra receipt to know if there's a next page.
+	receipts, err := g.store.ListReceipts(ctx, chainID, filter, afterBlock, afterIndex, ListReceiptsPageSize+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("listing receipts: %s", err)
+	}
+
+	var nextCursor string
+	if len(receipts) > ListReceiptsPageSize {
+		receipts = receipts[:ListReceiptsPageSize]
+		last := receipts[len(receipts)-1]
+		nextCursor = encodePositionCursor(last.BlockNumber, last.IndexInBlock)
+	}
+
+	return receipts, nextCursor, nil
+}
+
+// GetTableEvents returns a page of the history of a table, which are the events of the table ordered by
+// execution. It requires the validator to persist the events of the chain.
+// The cursor is the value returned by the previous call, or empty for the first page.
+// The returned cursor is empty when there are no more pages.
+func (g *GatewayService) GetTableEvents(
+	ctx context.Context, chainID tableland.ChainID, id tables.TableID, cursor string,
+) ([]TableEvent, string, error) {
+	afterBlock, afterIndex := int64(-1), int64(0)
+	if cursor != "" {
+		var err error
+		afterBlock, afterIndex, err = decodePositionCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// We ask for one extra event to know if there's a next page.
+	events, err := g.store.ListTableEvents(ctx, chainID, id, afterBlock, afterIndex, ListTableEventsPageSize+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("listing table events: %s", err)
+	}
+
+	var nextCursor string
+	if len(events) > ListTableEventsPageSize {
+		events = events[:ListTableEventsPageSize]
+		last := events[len(events)-1]
+		nextCursor = encodePositionCursor(last.BlockNumber, int64(last.EventIndex))
+	}
+
+	return events, nextCursor, nil
+}
+
 // RunReadQuery allows the user to run SQL.
-func (g *GatewayService) RunReadQuery(ctx context.Context, statement string, params []string) (*TableData, error) {
+func (g *GatewayService) RunReadQuery(ctx context.Context, statement string, params []any) (*TableData, error) {
 	readStmt, err := g.parser.ValidateReadQuery(statement)
 	if err != nil {
 		return nil, fmt.Errorf("validating read query: %s", err)
 	}
 
-	if err := g.resolver.PrepareParams(params); err != nil {
+	if err := g.resolver.PrepareValues(params); err != nil {
 		return nil, fmt.Errorf("prepare params: %s", err)
 	}
 
 	queryResult, err := g.store.Read(ctx, readStmt, g.resolver)
 	if err != nil {
-		return nil, fmt.Errorf("running read statement: %s", err)
+		return nil, fmt.Errorf("running read statement: %w", err)
 	}
 	return queryResult, nil
 }
 
+// StreamReadQuery runs a read query and writes the results to w as they are read, without holding
+// all of them in memory. Errors returned by w are wrapped, so the caller can inspect them.
+func (g *GatewayService) StreamReadQuery(ctx context.Context, statement string, params []any, w RowWriter) error {
+	readStmt, err := g.parser.ValidateReadQuery(statement)
+	if err != nil {
+		return fmt.Errorf("validating read query: %s", err)
+	}
+
+	if err := g.resolver.PrepareValues(params); err != nil {
+		return fmt.Errorf("prepare params: %s", err)
+	}
+
+	if err := g.store.ReadStream(ctx, readStmt, g.resolver, w); err != nil {
+		return fmt.Errorf("running read statement: %w", err)
+	}
+	return nil
+}
+
+// StreamReadQueryAt runs a read query against the state the queried tables had at the end of the provided
+// block, and writes the results to w as they are read. All the queried tables must be in the same chain.
+// ErrHistoryUnavailable is returned if the state at the block can't be rebuilt, and ErrTableNotFound if a queried
+// table was created after the block.
+func (g *GatewayService) StreamReadQueryAt(
+	ctx context.Context, statement string, params []any, block int64, w RowWriter,
+) error {
+	readStmt, err := g.parser.ValidateReadQuery(statement)
+	if err != nil {
+		return fmt.Errorf("validating read query: %s", err)
+	}
+
+	if err := g.resolver.PrepareValues(params); err != nil {
+		return fmt.Errorf("prepare params: %s", err)
+	}
+
+	err = g.store.ReadStreamAt(ctx, readStmt, g.resolver, block, w)
+	if err == ErrHistoryUnavailable || err == ErrTableNotFound {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("running read statement: %w", err)
+	}
+	return nil
+}
+
+// RunReadQueryPage runs a read query and returns a page of up to pageSize rows. The cursor is the value
+// returned by the previous call, or empty for the first page. The returned cursor is empty when there are
+// no more pages. Every page is read at the block heights where the first page was read. If the queried tables
+// changed after those heights and the change log doesn't reach them anymore, ErrCursorExpired is returned and
+// the query must be started again.
+func (g *GatewayService) RunReadQueryPage(
+	ctx context.Context, statement string, params []any, cursor string, pageSize int,
+) (*TableData, string, error) {
+	hash := readQueryHash(statement, params)
+	c := readQueryCursor{Hash: hash, PageSize: pageSize}
+	var after PagePosition
+	if cursor != "" {
+		var err error
+		c, err = decodeReadQueryCursor(cursor)
+		if err != nil || c.Hash != hash {
+			return nil, "", ErrInvalidCursor
+		}
+		after.Offset = c.Offset
+		for _, v := range c.Key {
+			after.Key = append(after.Key, v.v)
+		}
+	}
+	if c.PageSize <= 0 {
+		c.PageSize = DefaultReadQueryPageSize
+	}
+	if c.PageSize > MaxReadQueryPageSize {
+		c.PageSize = MaxReadQueryPageSize
+	}
+
+	readStmt, err := g.parser.ValidateReadQuery(statement)
+	if err != nil {
+		return nil, "", fmt.Errorf("validating read query: %s", err)
+	}
+
+	if err := g.resolver.PrepareValues(params); err != nil {
+		return nil, "", fmt.Errorf("prepare params: %s", err)
+	}
+
+	queryResult, next, heights, err := g.store.ReadPage(ctx, readStmt, g.resolver, c.Heights, after, c.PageSize)
+	if err == ErrInvalidCursor || err == ErrCursorExpired {
+		return nil, "", err
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("running read statement: %w", err)
+	}
+
+	var nextCursor string
+	if next != nil {
+		c.Offset, c.Key = next.Offset, nil
+		for _, v := range next.Key {
+			c.Key = append(c.Key, cursorValue{v: v})
+		}
+		c.Heights = heights
+		nextCursor, err = encodeReadQueryCursor(c)
+		if err != nil {
+			return nil, "", fmt.Errorf("encoding cursor: %s", err)
+		}
+	}
+
+	return queryResult, nextCursor, nil
+}
+
+// GetBlockHeights returns the last processed block heights of the provided chains.
+func (g *GatewayService) GetBlockHeights(
+	ctx context.Context, chainIDs []tableland.ChainID,
+) (BlockHeights, error) {
+	heights, err := g.store.GetBlockHeights(ctx, chainIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get block heights: %s", err)
+	}
+	return heights, nil
+}
+
+// GetReadQueryHeights returns the last processed block heights of the chains of the tables read by a
+// statement. The results of the statement can only change when one of the heights changes.
+func (g *GatewayService) GetReadQueryHeights(ctx context.Context, statement string) (BlockHeights, error) {
+	readStmt, err := g.parser.ValidateReadQuery(statement)
+	if err != nil {
+		return nil, fmt.Errorf("validating read query: %s", err)
+	}
+
+	tbls := readStmt.GetTables()
+	chainIDs := make([]tableland.ChainID, len(tbls))
+	for i, tbl := range tbls {
+		chainIDs[i] = tableland.ChainID(tbl.ChainID())
+	}
+	return g.GetBlockHeights(ctx, chainIDs)
+}
+
+// GetStateHash returns the last state hash of a chain calculated at or before a block.
+func (g *GatewayService) GetStateHash(
+	ctx context.Context, chainID tableland.ChainID, blockNumber int64,
+) (StateHash, bool, error) {
+	stateHash, exists, err := g.store.GetStateHash(ctx, chainID, blockNumber)
+	if err != nil {
+		return StateHash{}, false, fmt.Errorf("state hash lookup: %s", err)
+	}
+	return stateHash, exists, nil
+}
+
+// RunReadQueryBatch runs read queries in the same read transaction, so all of them see the same state of the
+// tables. The results of every query are written to w as they are read, in the order of the queries, and a
+// failing query doesn't fail the others. An error is returned only if the batch couldn't run, e.g. because it ran
+// longer than allowed.
+func (g *GatewayService) RunReadQueryBatch(ctx context.Context, queries []ReadQuery, w BatchWriter) error {
+	if len(queries) > MaxReadQueryBatchSize {
+		return fmt.Errorf("batch has more than %d queries", MaxReadQueryBatchSize)
+	}
+
+	resolved := make([]string, 0, len(queries))
+	indexes := make([]int, 0, len(queries))
+	for i, q := range queries {
+		readStmt, err := g.parser.ValidateReadQuery(q.Statement)
+		if err != nil {
+			w.End(i, fmt.Errorf("validating read query: %s", err))
+			continue
+		}
+		if err := g.resolver.PrepareValues(q.Params); err != nil {
+			w.End(i, fmt.Errorf("prepare params: %s", err))
+			continue
+		}
+		query, err := readStmt.GetQuery(g.resolver)
+		if err != nil {
+			w.End(i, fmt.Errorf("get query: %s", err))
+			continue
+		}
+		resolved = append(resolved, query)
+		indexes = append(indexes, i)
+	}
+	if len(resolved) == 0 {
+		return nil
+	}
+
+	if err := g.store.ReadBatch(ctx, resolved, &indexedBatchWriter{BatchWriter: w, indexes: indexes}); err != nil {
+		return fmt.Errorf("running read batch: %w", err)
+	}
+	return nil
+}
+
+// indexedBatchWriter maps the indexes of the queries run by the store to their indexes in the batch.
+type indexedBatchWriter struct {
+	BatchWriter
+	indexes []int
+}
+
+func (w *indexedBatchWriter) Begin(i int) RowWriter {
+	return w.BatchWriter.Begin(w.indexes[i])
+}
+
+func (w *indexedBatchWriter) End(i int, err error) {
+	w.BatchWriter.End(w.indexes[i], err)
+}
+
 func (g *GatewayService) getMetadataImage(chainID tableland.ChainID, tableID tables.TableID) string {
 	if g.metadataRendererURI == "" {
+		if g.renderImages {
+			return fmt.Sprintf("%s/api/v1/tables/%d/%s/image", g.extURLPrefix, chainID, tableID)
+		}
 		return DefaultMetadataImage
 	}
 
@@ -199,6 +738,150 @@ func (g *GatewayService) getAnimationURL(chainID tableland.ChainID, tableID tabl
 	return fmt.Sprintf("%s/%d/%s.html", g.animationRendererURI, chainID, tableID)
 }
 
+func encodeListTablesCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodeListTablesCursor(cursor string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil || id < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// encodePositionCursor encodes a position in a chain, given by a block number and an index in the block,
+// as a cursor.
+func encodePositionCursor(blockNumber int64, indexInBlock int64) string {
+	cursor := strconv.FormatInt(blockNumber, 10) + ":" + strconv.FormatInt(indexInBlock, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+func decodePositionCursor(cursor string) (int64, int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, ErrInvalidCursor
+	}
+	blockStr, indexStr, ok := strings.Cut(string(b), ":")
+	if !ok {
+		return 0, 0, ErrInvalidCursor
+	}
+	blockNumber, err := strconv.ParseInt(blockStr, 10, 64)
+	if err != nil || blockNumber < 0 {
+		return 0, 0, ErrInvalidCursor
+	}
+	indexInBlock, err := strconv.ParseInt(indexStr, 10, 64)
+	if err != nil || indexInBlock < 0 {
+		return 0, 0, ErrInvalidCursor
+	}
+	return blockNumber, indexInBlock, nil
+}
+
+// PagePosition is the position in the rows of a read query after which a page starts. Rows of queries that
+// can be keyed are located by the key of the last row of the previous page, so reading a page doesn't read the
+// previous pages again. Rows of other queries are located by the number of rows of the previous pages.
+type PagePosition struct {
+	Offset int64
+	Key    []interface{}
+}
+
+// readQueryCursor is the decoded form of a read query page cursor.
+type readQueryCursor struct {
+	// Hash identifies the statement and params the cursor was created for.
+	Hash     string        `json:"h"`
+	Offset   int64         `json:"o,omitempty"`
+	Key      []cursorValue `json:"k,omitempty"`
+	PageSize int           `json:"s"`
+	Heights  BlockHeights  `json:"b"`
+}
+
+// cursorValue is a value of the key of a read query cursor. It's encoded with its type, so it's compared
+// with the rows as the value it was read from.
+type cursorValue struct {
+	v interface{}
+}
+
+func (cv cursorValue) MarshalJSON() ([]byte, error) {
+	switch v := cv.v.(type) {
+	case nil:
+		return []byte("null"), nil
+	case int64:
+		return json.Marshal(map[string]string{"i": strconv.FormatInt(v, 10)})
+	case float64:
+		return json.Marshal(map[string]string{"f": strconv.FormatFloat(v, 'g', -1, 64)})
+	case string:
+		return json.Marshal(map[string]string{"s": v})
+	case []byte:
+		return json.Marshal(map[string]string{"b": base64.StdEncoding.EncodeToString(v)})
+	default:
+		return nil, fmt.Errorf("unsupported cursor value type %T", v)
+	}
+}
+
+func (cv *cursorValue) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		cv.v = nil
+		return nil
+	}
+	var typed map[string]string
+	if err := json.Unmarshal(b, &typed); err != nil {
+		return err
+	}
+	if len(typed) != 1 {
+		return ErrInvalidCursor
+	}
+	var err error
+	for typ, s := range typed {
+		switch typ {
+		case "i":
+			cv.v, err = strconv.ParseInt(s, 10, 64)
+		case "f":
+			cv.v, err = strconv.ParseFloat(s, 64)
+		case "s":
+			cv.v = s
+		case "b":
+			cv.v, err = base64.StdEncoding.DecodeString(s)
+		default:
+			err = ErrInvalidCursor
+		}
+	}
+	return err
+}
+
+func readQueryHash(statement string, params []any) string {
+	h := sha256.New()
+	_, _ = h.Write([]byte(statement))
+	for _, param := range params {
+		_, _ = fmt.Fprintf(h, "\x00%T:%v", param, param)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+func encodeReadQueryCursor(c readQueryCursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeReadQueryCursor(cursor string) (readQueryCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return readQueryCursor{}, ErrInvalidCursor
+	}
+	var c readQueryCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Offset < 0 || (c.Offset == 0) == (len(c.Key) == 0) ||
+		c.Heights == nil {
+		return readQueryCursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
 func (g *GatewayService) emptyMetadataImage() string {
 	svg := `<svg width='512' height='512' xmlns='http://www.w3.org/2000/svg'><rect width='512' height='512' fill='#000'/></svg>` //nolint
 	svgEncoded := base64.StdEncoding.EncodeToString([]byte(svg))
@@ -220,6 +903,52 @@ type Receipt struct {
 	TableID *tables.TableID
 }
 
+// TableEvent is an event of the history of a table, as emitted by the registry contract.
+type TableEvent struct {
+	BlockNumber int64
+	// BlockTimestamp is nil if the timestamp of the block wasn't fetched yet.
+	BlockTimestamp *time.Time
+	TxnHash        string
+	TxnIndex       uint
+	EventIndex     uint
+	EventType      string
+	EventJSON      []byte
+
+	// Executed is false if the transaction of the event wasn't executed yet. Otherwise, Error and
+	// ErrorEventIdx are set if the transaction failed.
+	Executed      bool
+	Error         *string
+	ErrorEventIdx *int
+}
+
+// ReceiptLookup identifies the receipt of a transaction of a chain.
+type ReceiptLookup struct {
+	ChainID tableland.ChainID
+	TxnHash common.Hash
+}
+
+// ReceiptFilter selects the receipts returned by ListReceipts.
+type ReceiptFilter struct {
+	// FromBlock and ToBlock are the inclusive bounds of the block range. Zero means unbounded.
+	FromBlock int64
+	ToBlock   int64
+
+	// TableID selects the receipts of transactions that modified the table, if not nil.
+	TableID *tables.TableID
+}
+
+// BlockHeights maps chains to block heights. A paginated read query is pinned to the block heights
+// of the chains of the queried tables.
+type BlockHeights map[tableland.ChainID]int64
+
+// StateHash is the hash of the state of a chain's tables, calculated by the validator at a block.
+type StateHash struct {
+	ChainID     tableland.ChainID
+	BlockNumber int64
+	Hash        string
+	CreatedAt   time.Time
+}
+
 // Table represents a system-wide table stored in Tableland.
 type Table struct {
 	ID         tables.TableID    `json:"id"` // table id
@@ -235,6 +964,31 @@ func (t Table) Name() string {
 	return fmt.Sprintf("%s_%d_%s", t.Prefix, t.ChainID, t.ID)
 }
 
+// TableStats are the statistics of a table.
+type TableStats struct {
+	RowCount    int64
+	MaxRowCount int // zero means no limit
+	ColumnCount int
+	Indexes     []TableIndex
+	CreatedAt   time.Time
+
+	// Bytes is the approximate size of the table and its indexes on disk. It's nil if the database wasn't
+	// built with the dbstat virtual table.
+	Bytes *int64
+
+	// LastModifiedBlock and LastModifiedTxnHash identify the last transaction that successfully modified
+	// the table, including its creation.
+	LastModifiedBlock   int64
+	LastModifiedTxnHash string
+}
+
+// TableIndex is an index of a table, such as the ones created for primary key and unique constraints.
+type TableIndex struct {
+	Name    string
+	Unique  bool
+	Columns []string
+}
+
 // TableSchema represents the schema of a table.
 type TableSchema struct {
 	Columns          []ColumnSchema
@@ -269,6 +1023,14 @@ type TableMetadataAttribute struct {
 // Column defines a column in table data.
 type Column struct {
 	Name string `json:"name"`
+	// Type is the declared type of the column, such as INTEGER or TEXT. It's empty for expressions.
+	Type string `json:"-"`
+}
+
+// ReadQuery is a read query of a batch, with the typed values bound to its parameters.
+type ReadQuery struct {
+	Statement string
+	Params    []any
 }
 
 // TableData defines a tabular representation of query results.
@@ -277,6 +1039,25 @@ type TableData struct {
 	Rows    [][]*ColumnValue `json:"rows"`
 }
 
+// Clone returns a deep copy of the table data.
+func (td *TableData) Clone() *TableData {
+	clone := &TableData{}
+	if td.Columns != nil {
+		clone.Columns = make([]Column, len(td.Columns))
+		copy(clone.Columns, td.Columns)
+	}
+	if td.Rows != nil {
+		clone.Rows = make([][]*ColumnValue, len(td.Rows))
+	}
+	for i, row := range td.Rows {
+		clone.Rows[i] = make([]*ColumnValue, len(row))
+		for j, cv := range row {
+			clone.Rows[i][j] = cv.clone()
+		}
+	}
+	return clone
+}
+
 // ColumnValue wraps data from the db that may be raw json or any other value.
 type ColumnValue struct {
 	jsonValue  json.RawMessage
@@ -313,6 +1094,20 @@ func (cv *ColumnValue) Scan(src interface{}) error {
 	return nil
 }
 
+func (cv *ColumnValue) clone() *ColumnValue {
+	if cv == nil {
+		return nil
+	}
+	clone := &ColumnValue{otherValue: cv.otherValue}
+	if cv.jsonValue != nil {
+		clone.jsonValue = append(json.RawMessage{}, cv.jsonValue...)
+	}
+	if b, ok := cv.otherValue.([]byte); ok {
+		clone.otherValue = append([]byte{}, b...)
+	}
+	return clone
+}
+
 // MarshalJSON implements MarshalJSON.
 func (cv *ColumnValue) MarshalJSON() ([]byte, error) {
 	if cv.jsonValue != nil {
@@ -58,6 +58,86 @@ func (g *InstrumentedGateway) GetReceiptByTransactionHash(
 	return receipt, exists, err
 }
 
+// GetTableACL returns the access control rules of a table.
+func (g *InstrumentedGateway) GetTableACL(
+	ctx context.Context, chainID tableland.ChainID, id tables.TableID,
+) (tableland.TableACL, error) {
+	start := time.Now()
+	acl, err := g.gateway.GetTableACL(ctx, chainID, id)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetTableACL")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(chainID))},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return acl, err
+}
+
+// GetTableStats returns the statistics of a table.
+func (g *InstrumentedGateway) GetTableStats(
+	ctx context.Context, chainID tableland.ChainID, id tables.TableID,
+) (TableStats, error) {
+	start := time.Now()
+	stats, err := g.gateway.GetTableStats(ctx, chainID, id)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetTableStats")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(chainID))},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return stats, err
+}
+
+// GetTableImage renders the SVG image of a table.
+func (g *InstrumentedGateway) GetTableImage(
+	ctx context.Context, chainID tableland.ChainID, id tables.TableID,
+) ([]byte, error) {
+	start := time.Now()
+	image, err := g.gateway.GetTableImage(ctx, chainID, id)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetTableImage")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(chainID))},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return image, err
+}
+
+// GetTableEvents returns a page of the history of a table.
+func (g *InstrumentedGateway) GetTableEvents(
+	ctx context.Context, chainID tableland.ChainID, id tables.TableID, cursor string,
+) ([]TableEvent, string, error) {
+	start := time.Now()
+	events, nextCursor, err := g.gateway.GetTableEvents(ctx, chainID, id, cursor)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetTableEvents")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(chainID))},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return events, nextCursor, err
+}
+
 // GetTableMetadata returns table's metadata fetched from SQLStore.
 func (g *InstrumentedGateway) GetTableMetadata(
 	ctx context.Context,
@@ -80,8 +160,104 @@ func (g *InstrumentedGateway) GetTableMetadata(
 	return metadata, err
 }
 
+// GetTableSchema returns the schema of a table given its name.
+func (g *InstrumentedGateway) GetTableSchema(ctx context.Context, tableName string) (TableSchema, error) {
+	start := time.Now()
+	schema, err := g.gateway.GetTableSchema(ctx, tableName)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetTableSchema")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return schema, err
+}
+
+// ListTables returns a page of the tables owned by an address.
+func (g *InstrumentedGateway) ListTables(
+	ctx context.Context, chainID tableland.ChainID, owner common.Address, cursor string,
+) ([]Table, string, error) {
+	start := time.Now()
+	tables, nextCursor, err := g.gateway.ListTables(ctx, chainID, owner, cursor)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("ListTables")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(chainID))},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return tables, nextCursor, err
+}
+
+// ListReceipts returns a page of the receipts that match the filter.
+func (g *InstrumentedGateway) ListReceipts(
+	ctx context.Context, chainID tableland.ChainID, filter ReceiptFilter, cursor string,
+) ([]Receipt, string, error) {
+	start := time.Now()
+	receipts, nextCursor, err := g.gateway.ListReceipts(ctx, chainID, filter, cursor)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("ListReceipts")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(chainID))},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return receipts, nextCursor, err
+}
+
+// GetReceiptsByTransactionHashes returns the receipts of the transactions that were found.
+func (g *InstrumentedGateway) GetReceiptsByTransactionHashes(
+	ctx context.Context, lookups []ReceiptLookup,
+) ([]Receipt, error) {
+	start := time.Now()
+	receipts, err := g.gateway.GetReceiptsByTransactionHashes(ctx, lookups)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetReceiptsByTransactionHashes")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return receipts, err
+}
+
+// ListReceiptsAfter returns receipts executed after the provided position.
+func (g *InstrumentedGateway) ListReceiptsAfter(
+	ctx context.Context, chainID tableland.ChainID, blockNumber int64, indexInBlock int64, limit int,
+) ([]Receipt, error) {
+	start := time.Now()
+	receipts, err := g.gateway.ListReceiptsAfter(ctx, chainID, blockNumber, indexInBlock, limit)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("ListReceiptsAfter")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(chainID))},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return receipts, err
+}
+
 // RunReadQuery allows the user to run SQL.
-func (g *InstrumentedGateway) RunReadQuery(ctx context.Context, statement string, params []string) (*TableData, error) {
+func (g *InstrumentedGateway) RunReadQuery(ctx context.Context, statement string, params []any) (*TableData, error) {
 	start := time.Now()
 	data, err := g.gateway.RunReadQuery(ctx, statement, params)
 	latency := time.Since(start).Milliseconds()
@@ -96,3 +272,133 @@ func (g *InstrumentedGateway) RunReadQuery(ctx context.Context, statement string
 
 	return data, err
 }
+
+// RunReadQueryPage allows the user to run SQL, returning a page of the results.
+func (g *InstrumentedGateway) RunReadQueryPage(
+	ctx context.Context, statement string, params []any, cursor string, pageSize int,
+) (*TableData, string, error) {
+	start := time.Now()
+	data, nextCursor, err := g.gateway.RunReadQueryPage(ctx, statement, params, cursor, pageSize)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("RunReadQueryPage")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return data, nextCursor, err
+}
+
+// StreamReadQuery allows the user to run SQL, writing the results as they are read.
+func (g *InstrumentedGateway) StreamReadQuery(
+	ctx context.Context, statement string, params []any, w RowWriter,
+) error {
+	start := time.Now()
+	err := g.gateway.StreamReadQuery(ctx, statement, params, w)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("StreamReadQuery")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return err
+}
+
+// StreamReadQueryAt allows the user to run SQL at a past block, writing the results as they are read.
+func (g *InstrumentedGateway) StreamReadQueryAt(
+	ctx context.Context, statement string, params []any, block int64, w RowWriter,
+) error {
+	start := time.Now()
+	err := g.gateway.StreamReadQueryAt(ctx, statement, params, block, w)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("StreamReadQueryAt")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return err
+}
+
+// GetBlockHeights returns the last processed block heights of chains.
+func (g *InstrumentedGateway) GetBlockHeights(
+	ctx context.Context, chainIDs []tableland.ChainID,
+) (BlockHeights, error) {
+	start := time.Now()
+	heights, err := g.gateway.GetBlockHeights(ctx, chainIDs)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetBlockHeights")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return heights, err
+}
+
+// GetStateHash returns the last state hash of a chain calculated at or before a block.
+func (g *InstrumentedGateway) GetStateHash(
+	ctx context.Context, chainID tableland.ChainID, blockNumber int64,
+) (StateHash, bool, error) {
+	start := time.Now()
+	stateHash, exists, err := g.gateway.GetStateHash(ctx, chainID, blockNumber)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetStateHash")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+		{Key: "chainID", Value: attribute.Int64Value(int64(chainID))},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return stateHash, exists, err
+}
+
+// GetReadQueryHeights returns the last processed block heights of the chains read by a statement.
+func (g *InstrumentedGateway) GetReadQueryHeights(ctx context.Context, statement string) (BlockHeights, error) {
+	start := time.Now()
+	heights, err := g.gateway.GetReadQueryHeights(ctx, statement)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("GetReadQueryHeights")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return heights, err
+}
+
+// RunReadQueryBatch allows the user to run many SQL read queries on the same state of the tables.
+func (g *InstrumentedGateway) RunReadQueryBatch(ctx context.Context, queries []ReadQuery, w BatchWriter) error {
+	start := time.Now()
+	err := g.gateway.RunReadQueryBatch(ctx, queries, w)
+	latency := time.Since(start).Milliseconds()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "method", Value: attribute.StringValue("RunReadQueryBatch")},
+		{Key: "success", Value: attribute.BoolValue(err == nil)},
+	}, metrics.BaseAttrs...)
+
+	g.callCount.Add(ctx, 1, attributes...)
+	g.latencyHistogram.Record(ctx, latency, attributes...)
+
+	return err
+}
@@ -0,0 +1,92 @@
+package gateway
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+
+	"github.com/textileio/go-tableland/pkg/client"
+)
+
+const (
+	// maxImageColumns is the number of columns listed in a table image. The rest are summarized in one line.
+	maxImageColumns = 12
+
+	// maxImageLineLength is the number of characters of a line of a table image. Longer lines are truncated.
+	maxImageLineLength = 40
+)
+
+var tableImageTemplate = template.Must(template.New("table").Parse(`<svg width="512" height="512" ` +
+	`viewBox="0 0 512 512" xmlns="http://www.w3.org/2000/svg">
+<rect width="512" height="512" fill="#000"/>
+<g font-family="monospace" fill="#fff">
+<text x="32" y="64" font-size="22" font-weight="bold">{{.Name}}</text>
+<text x="32" y="104" font-size="16" fill="#aaa">{{.Chain}}</text>
+<text x="32" y="132" font-size="16" fill="#aaa">{{.Rows}}</text>
+<text x="32" y="160" font-size="16" fill="#aaa">{{.Created}}</text>
+<line x1="32" y1="184" x2="480" y2="184" stroke="#444"/>
+{{range .Columns}}<text x="32" y="{{.Y}}" font-size="15">{{.Text}}</text>
+{{end}}</g>
+</svg>
+`))
+
+type tableImage struct {
+	Name    string
+	Chain   string
+	Rows    string
+	Created string
+	Columns []tableImageLine
+}
+
+type tableImageLine struct {
+	Y    int
+	Text string
+}
+
+// renderTableImage renders the SVG image of a table.
+func renderTableImage(table Table, schema TableSchema, rowCount int64) ([]byte, error) {
+	chainName := fmt.Sprintf("Chain %d", table.ChainID)
+	if chain, ok := client.Chains[client.ChainID(table.ChainID)]; ok {
+		chainName = fmt.Sprintf("%s (%d)", chain.Name, table.ChainID)
+	}
+	img := tableImage{
+		Name:    truncateImageLine(table.Name()),
+		Chain:   chainName,
+		Rows:    fmt.Sprintf("%d rows", rowCount),
+		Created: fmt.Sprintf("Created %s", table.CreatedAt.UTC().Format("2006-01-02")),
+	}
+	if rowCount == 1 {
+		img.Rows = "1 row"
+	}
+
+	columns := schema.Columns
+	if len(columns) > maxImageColumns {
+		columns = columns[:maxImageColumns-1]
+	}
+	for i, column := range columns {
+		img.Columns = append(img.Columns, tableImageLine{
+			Y:    216 + 22*i,
+			Text: truncateImageLine(fmt.Sprintf("%s %s", column.Name, column.Type)),
+		})
+	}
+	if more := len(schema.Columns) - len(columns); more > 0 {
+		img.Columns = append(img.Columns, tableImageLine{
+			Y:    216 + 22*len(columns),
+			Text: fmt.Sprintf("+%d more columns", more),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := tableImageTemplate.Execute(&buf, img); err != nil {
+		return nil, fmt.Errorf("executing template: %s", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func truncateImageLine(s string) string {
+	runes := []rune(s)
+	if len(runes) <= maxImageLineLength {
+		return s
+	}
+	return string(runes[:maxImageLineLength-1]) + "…"
+}
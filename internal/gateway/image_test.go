@@ -0,0 +1,82 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+func TestRenderTableImage(t *testing.T) {
+	t.Parallel()
+
+	id, _ := tables.NewTableID("42")
+	table := Table{
+		ID:        id,
+		ChainID:   1,
+		Prefix:    "foo",
+		CreatedAt: time.Date(2023, 4, 5, 10, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("summary", func(t *testing.T) {
+		t.Parallel()
+
+		schema := TableSchema{Columns: []ColumnSchema{
+			{Name: "id", Type: "integer"},
+			{Name: "name", Type: "text"},
+		}}
+		img, err := renderTableImage(table, schema, 1)
+		require.NoError(t, err)
+
+		svg := string(img)
+		require.True(t, strings.HasPrefix(svg, "<svg "))
+		require.Contains(t, svg, ">foo_1_42</text>")
+		require.Contains(t, svg, ">Ethereum (1)</text>")
+		require.Contains(t, svg, ">1 row</text>")
+		require.Contains(t, svg, ">Created 2023-04-05</text>")
+		require.Contains(t, svg, ">id integer</text>")
+		require.Contains(t, svg, ">name text</text>")
+	})
+
+	t.Run("unknown chain", func(t *testing.T) {
+		t.Parallel()
+
+		table := table
+		table.ChainID = 999999
+		img, err := renderTableImage(table, TableSchema{}, 0)
+		require.NoError(t, err)
+		require.Contains(t, string(img), ">Chain 999999</text>")
+		require.Contains(t, string(img), ">0 rows</text>")
+	})
+
+	t.Run("many columns", func(t *testing.T) {
+		t.Parallel()
+
+		var schema TableSchema
+		for i := 0; i < 20; i++ {
+			schema.Columns = append(schema.Columns, ColumnSchema{Name: fmt.Sprintf("c%d", i), Type: "text"})
+		}
+		schema.Columns[0].Name = strings.Repeat("a", 50)
+		img, err := renderTableImage(table, schema, 10)
+		require.NoError(t, err)
+
+		svg := string(img)
+		require.Contains(t, svg, ">"+strings.Repeat("a", maxImageLineLength-1)+"…</text>")
+		require.Contains(t, svg, ">c10 text</text>")
+		require.NotContains(t, svg, ">c11 text</text>")
+		require.Contains(t, svg, "9 more columns</text>")
+	})
+
+	t.Run("escaping", func(t *testing.T) {
+		t.Parallel()
+
+		schema := TableSchema{Columns: []ColumnSchema{{Name: "<b>", Type: "text"}}}
+		img, err := renderTableImage(table, schema, 0)
+		require.NoError(t, err)
+		require.Contains(t, string(img), ">&lt;b&gt; text</text>")
+		require.NotContains(t, string(img), "<b>")
+	})
+}
@@ -0,0 +1,400 @@
+package impl
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tablelandnetwork/sqlparser"
+	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
+	"github.com/textileio/go-tableland/pkg/metrics"
+	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/pubsub"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+)
+
+// CachedGatewayStore is a GatewayStore that caches the results of read statements. Results are keyed by the
+// final query, which is normalized and has the parameters bound, and by the last processed height of the chains
+// of the queried tables, so a new block never serves stale results. Entries of a table are also dropped as soon
+// as a receipt touching the table is published, to free memory. Results are copied in and out of the cache, so
+// callers can't modify cached results.
+type CachedGatewayStore struct {
+	*GatewayStore
+
+	maxEntries   int
+	maxEntryRows int
+	maxBytes     int
+
+	mu      sync.Mutex
+	bytes   int
+	lru     *list.List
+	entries map[string]*list.Element
+	tables  map[cachedTable]map[string]struct{}
+
+	lookupCount instrument.Int64Counter
+
+	closed  chan struct{}
+	stopped chan struct{}
+}
+
+var _ gateway.GatewayStore = (*CachedGatewayStore)(nil)
+
+type cachedTable struct {
+	chainID tableland.ChainID
+	tableID int64
+}
+
+type cacheEntry struct {
+	key    string
+	tables []cachedTable
+	data   *gateway.TableData
+	size   int
+}
+
+// NewCachedGatewayStore creates a new CachedGatewayStore that holds up to maxEntries results of up to
+// maxEntryRows rows each, taking up to about maxBytes of memory. If hub isn't nil, entries are dropped when the
+// tables they read change.
+func NewCachedGatewayStore(
+	store *GatewayStore, hub *pubsub.Hub, maxEntries int, maxEntryRows int, maxBytes int,
+) (*CachedGatewayStore, error) {
+	if maxEntries <= 0 {
+		return nil, fmt.Errorf("max entries should be greater than zero")
+	}
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("max bytes should be greater than zero")
+	}
+
+	meter := global.MeterProvider().Meter("tableland")
+	lookupCount, err := meter.Int64Counter("tableland.gateway.cache.lookup.count")
+	if err != nil {
+		return nil, fmt.Errorf("registering lookup counter: %s", err)
+	}
+
+	s := &CachedGatewayStore{
+		GatewayStore: store,
+		maxEntries:   maxEntries,
+		maxEntryRows: maxEntryRows,
+		maxBytes:     maxBytes,
+		lru:          list.New(),
+		entries:      map[string]*list.Element{},
+		tables:       map[cachedTable]map[string]struct{}{},
+		lookupCount:  lookupCount,
+		closed:       make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}
+	if hub != nil {
+		// Subscribe before returning, so no change published after the store is created is missed.
+		go s.watchReceipts(hub, hub.Subscribe())
+	} else {
+		close(s.stopped)
+	}
+
+	return s, nil
+}
+
+// Read executes a parsed read statement, or returns its cached results.
+func (s *CachedGatewayStore) Read(
+	ctx context.Context, stmt parsing.ReadStmt, resolver sqlparser.ReadStatementResolver,
+) (*gateway.TableData, error) {
+	query, err := stmt.GetQuery(resolver)
+	if err != nil {
+		return nil, fmt.Errorf("get query: %s", err)
+	}
+	key, tables, err := s.cacheKey(ctx, stmt, query)
+	if err != nil {
+		return nil, fmt.Errorf("building cache key: %s", err)
+	}
+	if data, ok := s.get(ctx, key); ok {
+		return data, nil
+	}
+
+	data, err := s.execReadQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("parsing result to json: %w", err)
+	}
+	s.add(key, tables, data)
+
+	return data, nil
+}
+
+// ReadStream executes a parsed read statement and writes the rows to w as they are read, or writes
+// its cached results.
+func (s *CachedGatewayStore) ReadStream(
+	ctx context.Context, stmt parsing.ReadStmt, resolver sqlparser.ReadStatementResolver, w gateway.RowWriter,
+) error {
+	query, err := stmt.GetQuery(resolver)
+	if err != nil {
+		return fmt.Errorf("get query: %s", err)
+	}
+	key, tables, err := s.cacheKey(ctx, stmt, query)
+	if err != nil {
+		return fmt.Errorf("building cache key: %s", err)
+	}
+	if data, ok := s.get(ctx, key); ok {
+		if err := w.WriteColumns(data.Columns); err != nil {
+			return fmt.Errorf("writing columns: %w", err)
+		}
+		for _, row := range data.Rows {
+			if err := w.WriteRow(row); err != nil {
+				return fmt.Errorf("writing row: %w", err)
+			}
+		}
+		return nil
+	}
+
+	rw := &recordingRowWriter{
+		RowWriter: w,
+		maxRows:   s.maxEntryRows,
+		maxBytes:  s.maxBytes,
+		data:      &gateway.TableData{},
+	}
+	if err := s.execReadQueryStream(ctx, query, rw); err != nil {
+		return fmt.Errorf("streaming result: %w", err)
+	}
+	if !rw.overflowed {
+		s.add(key, tables, rw.data)
+	}
+
+	return nil
+}
+
+// Close stops watching for table changes.
+func (s *CachedGatewayStore) Close() {
+	close(s.closed)
+	<-s.stopped
+}
+
+// cacheKey returns the cache key of a query and the tables it reads. The key is empty if the query
+// shouldn't be cached.
+func (s *CachedGatewayStore) cacheKey(
+	ctx context.Context, stmt parsing.ReadStmt, query string,
+) (string, []cachedTable, error) {
+	validatedTables := stmt.GetTables()
+	if len(validatedTables) == 0 {
+		// Without tables there's nothing to invalidate the entry, and the query might not be deterministic.
+		return "", nil, nil
+	}
+
+	tables := make([]cachedTable, len(validatedTables))
+	chainIDs := map[tableland.ChainID]struct{}{}
+	for i, tbl := range validatedTables {
+		tables[i] = cachedTable{chainID: tableland.ChainID(tbl.ChainID()), tableID: tbl.TokenID()}
+		chainIDs[tableland.ChainID(tbl.ChainID())] = struct{}{}
+	}
+
+	heights := make([]string, 0, len(chainIDs))
+	for chainID := range chainIDs {
+		var height int64
+		err := s.db.DB.QueryRowContext(
+			ctx, "SELECT block_number FROM system_txn_processor WHERE chain_id=?1", chainID,
+		).Scan(&height)
+		if err != nil && err != sql.ErrNoRows {
+			return "", nil, fmt.Errorf("get last processed height: %s", err)
+		}
+		heights = append(heights, strconv.FormatInt(int64(chainID), 10)+":"+strconv.FormatInt(height, 10))
+	}
+	sort.Strings(heights)
+
+	return strings.Join(heights, ",") + "|" + query, tables, nil
+}
+
+func (s *CachedGatewayStore) get(ctx context.Context, key string) (*gateway.TableData, bool) {
+	if key == "" {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	elem, ok := s.entries[key]
+	if ok {
+		s.lru.MoveToFront(elem)
+	}
+	s.mu.Unlock()
+
+	attributes := append([]attribute.KeyValue{
+		{Key: "hit", Value: attribute.BoolValue(ok)},
+	}, metrics.BaseAttrs...)
+	s.lookupCount.Add(ctx, 1, attributes...)
+
+	if !ok {
+		return nil, false
+	}
+	return elem.Value.(*cacheEntry).data.Clone(), true
+}
+
+func (s *CachedGatewayStore) add(key string, tables []cachedTable, data *gateway.TableData) {
+	if key == "" || len(data.Rows) > s.maxEntryRows {
+		return
+	}
+	size := tableDataSize(data)
+	if size > s.maxBytes {
+		return
+	}
+	data = data.Clone()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		s.lru.MoveToFront(elem)
+		return
+	}
+	s.entries[key] = s.lru.PushFront(&cacheEntry{key: key, tables: tables, data: data, size: size})
+	s.bytes += size
+	for _, tbl := range tables {
+		if _, ok := s.tables[tbl]; !ok {
+			s.tables[tbl] = map[string]struct{}{}
+		}
+		s.tables[tbl][key] = struct{}{}
+	}
+
+	for s.lru.Len() > s.maxEntries || s.bytes > s.maxBytes {
+		s.remove(s.lru.Back().Value.(*cacheEntry).key)
+	}
+}
+
+// remove drops an entry. The caller must hold the lock.
+func (s *CachedGatewayStore) remove(key string) {
+	elem, ok := s.entries[key]
+	if !ok {
+		return
+	}
+	entry := s.lru.Remove(elem).(*cacheEntry)
+	delete(s.entries, key)
+	s.bytes -= entry.size
+	for _, tbl := range entry.tables {
+		delete(s.tables[tbl], key)
+		if len(s.tables[tbl]) == 0 {
+			delete(s.tables, tbl)
+		}
+	}
+}
+
+func (s *CachedGatewayStore) invalidate(receipt eventprocessor.Receipt) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range receipt.TableIDs {
+		tbl := cachedTable{chainID: receipt.ChainID, tableID: id.ToBigInt().Int64()}
+		for key := range s.tables[tbl] {
+			s.remove(key)
+		}
+	}
+}
+
+func (s *CachedGatewayStore) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lru.Init()
+	s.bytes = 0
+	s.entries = map[string]*list.Element{}
+	s.tables = map[cachedTable]map[string]struct{}{}
+}
+
+// watchReceipts drops the entries of the tables touched by published receipts until the store is closed.
+func (s *CachedGatewayStore) watchReceipts(hub *pubsub.Hub, sub *pubsub.Subscription) {
+	defer close(s.stopped)
+
+	for {
+		dropped := s.consumeReceipts(sub)
+		sub.Close()
+		if !dropped {
+			return
+		}
+		// The subscription couldn't keep up, so some changes might have been missed.
+		s.db.Log.Warn().Msg("read cache fell behind table changes, clearing it")
+		sub = hub.Subscribe()
+		s.clear()
+	}
+}
+
+// consumeReceipts returns true if the subscription was dropped, or false if the store was closed.
+func (s *CachedGatewayStore) consumeReceipts(sub *pubsub.Subscription) bool {
+	for {
+		select {
+		case <-s.closed:
+			return false
+		case receipt, ok := <-sub.Receipts():
+			if !ok {
+				return true
+			}
+			s.invalidate(receipt)
+		}
+	}
+}
+
+// recordingRowWriter records the rows written to a RowWriter, up to maxRows rows and about maxBytes bytes.
+type recordingRowWriter struct {
+	gateway.RowWriter
+
+	maxRows    int
+	maxBytes   int
+	size       int
+	data       *gateway.TableData
+	overflowed bool
+}
+
+func (w *recordingRowWriter) WriteColumns(columns []gateway.Column) error {
+	w.data.Columns = columns
+	w.data.Rows = [][]*gateway.ColumnValue{}
+	return w.RowWriter.WriteColumns(columns)
+}
+
+func (w *recordingRowWriter) WriteRow(row []*gateway.ColumnValue) error {
+	if !w.overflowed {
+		w.size += rowSize(row)
+		if len(w.data.Rows) < w.maxRows && w.size <= w.maxBytes {
+			w.data.Rows = append(w.data.Rows, row)
+		} else {
+			w.overflowed, w.data.Rows = true, nil
+		}
+	}
+	return w.RowWriter.WriteRow(row)
+}
+
+// tableDataSize estimates the memory taken by table data, in bytes.
+func tableDataSize(data *gateway.TableData) int {
+	size := 0
+	for _, col := range data.Columns {
+		size += len(col.Name) + len(col.Type)
+	}
+	for _, row := range data.Rows {
+		size += rowSize(row)
+	}
+	return size
+}
+
+// rowSize estimates the memory taken by a row, in bytes. Values other than strings and bytes are counted as
+// 8 bytes, and every value has a fixed overhead for its wrapper.
+func rowSize(row []*gateway.ColumnValue) int {
+	const valueOverhead = 48
+
+	size := 0
+	for _, cv := range row {
+		size += valueOverhead
+		if cv == nil {
+			continue
+		}
+		switch v := cv.Value().(type) {
+		case string:
+			size += len(v)
+		case []byte:
+			size += len(v)
+		case json.RawMessage:
+			size += len(v)
+		default:
+			size += 8
+		}
+	}
+	return size
+}
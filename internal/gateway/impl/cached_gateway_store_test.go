@@ -0,0 +1,155 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/formatter"
+	"github.com/textileio/go-tableland/internal/gateway"
+	tablelandimpl "github.com/textileio/go-tableland/internal/tableland/impl"
+	"github.com/textileio/go-tableland/pkg/database"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
+	executor "github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor/impl"
+	"github.com/textileio/go-tableland/pkg/parsing"
+	parserimpl "github.com/textileio/go-tableland/pkg/parsing/impl"
+	"github.com/textileio/go-tableland/pkg/pubsub"
+	"github.com/textileio/go-tableland/pkg/sharedmemory"
+	"github.com/textileio/go-tableland/pkg/tables"
+	"github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
+	"github.com/textileio/go-tableland/tests"
+)
+
+func TestCachedGatewayStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	ex, err := executor.NewExecutor(chainID, db, parser, 0, tablelandimpl.NewACL(db))
+	require.NoError(t, err)
+	executeBlock := func(height int64, events ...interface{}) {
+		bs, err := ex.NewBlockScope(ctx, height)
+		require.NoError(t, err)
+		res, err := bs.ExecuteTxnEvents(ctx, eventfeed.TxnEvents{TxnHash: common.HexToHash("0x1"), Events: events})
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+		require.NoError(t, bs.SetLastProcessedHeight(ctx, height))
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+	}
+	insert := func(statement string) *ethereum.ContractRunSQL {
+		return &ethereum.ContractRunSQL{
+			Caller:    common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+			IsOwner:   true,
+			TableId:   big.NewInt(1),
+			Statement: statement,
+			Policy: ethereum.ITablelandControllerPolicy{
+				AllowInsert: true,
+			},
+		}
+	}
+	executeBlock(1,
+		&ethereum.ContractCreateTable{
+			TableId:   big.NewInt(1),
+			Owner:     common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+			Statement: "create table foo_1337 (bar int)",
+		},
+		insert("insert into foo_1337_1 values (1)"),
+	)
+
+	hub := pubsub.NewHub(10)
+	store, err := NewCachedGatewayStore(NewGatewayStore(db), hub, 2, 2, 1<<20)
+	require.NoError(t, err)
+	defer store.Close()
+
+	resolver := parsing.NewReadStatementResolver(sharedmemory.NewSharedMemory())
+	svc, err := gateway.NewGateway(parser, store, resolver, "https://tableland.network", "", "")
+	require.NoError(t, err)
+
+	count := func(stmt string) int64 {
+		data, err := svc.RunReadQuery(ctx, stmt, nil)
+		require.NoError(t, err)
+		return data.Rows[0][0].Value().(int64)
+	}
+	stmt := "select count(*) from foo_1337_1"
+	require.Equal(t, int64(1), count(stmt))
+
+	// Modifying a returned result doesn't modify the cached one.
+	data, err := svc.RunReadQuery(ctx, stmt, nil)
+	require.NoError(t, err)
+	data.Rows[0][0] = gateway.OtherColValue(int64(10))
+	data.Columns[0].Name = "modified"
+	require.Equal(t, int64(1), count(stmt))
+	data, err = svc.RunReadQuery(ctx, stmt, nil)
+	require.NoError(t, err)
+	require.Equal(t, "count(*)", data.Columns[0].Name)
+
+	// Changes that bypass the event processor aren't seen, since the result is cached.
+	_, err = db.DB.ExecContext(ctx, "insert into foo_1337_1 values (2)")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count(stmt))
+
+	// Publishing a receipt of the table drops the cached result.
+	hub.Publish([]eventprocessor.Receipt{
+		{ChainID: chainID, BlockNumber: 1, TableIDs: []tables.TableID{tables.TableID(*big.NewInt(1))}},
+	})
+	require.Eventually(t, func() bool { return count(stmt) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// Processing a new block changes the cache key.
+	executeBlock(2, insert("insert into foo_1337_1 values (3)"))
+	require.Equal(t, int64(3), count(stmt))
+
+	// Streamed reads are served from the cache too.
+	var buf bytes.Buffer
+	w := formatter.NewStreamWriter(&buf, formatter.WithOutput(formatter.Table))
+	require.NoError(t, svc.StreamReadQuery(ctx, stmt, nil, w))
+	require.NoError(t, w.Close())
+	require.JSONEq(t, `{"columns":[{"name":"count(*)"}],"rows":[[3]]}`, buf.String())
+
+	// Results with too many rows aren't cached.
+	data, err = svc.RunReadQuery(ctx, "select bar from foo_1337_1", nil)
+	require.NoError(t, err)
+	require.Len(t, data.Rows, 3)
+	_, err = db.DB.ExecContext(ctx, "insert into foo_1337_1 values (4)")
+	require.NoError(t, err)
+	data, err = svc.RunReadQuery(ctx, "select bar from foo_1337_1", nil)
+	require.NoError(t, err)
+	require.Len(t, data.Rows, 4)
+
+	// The least recently used entry is evicted.
+	require.Equal(t, int64(4), count("select count(*) from foo_1337_1 where bar > 0"))
+	require.Equal(t, int64(3), count("select count(*) from foo_1337_1 where bar > 1"))
+	require.Equal(t, 2, store.lru.Len())
+	_, ok := store.entries[store.lru.Front().Value.(*cacheEntry).key]
+	require.True(t, ok)
+	require.Len(t, store.tables, 1)
+
+	// Results larger than the memory bound aren't cached, and the bound evicts entries.
+	store, err = NewCachedGatewayStore(NewGatewayStore(db), nil, 10, 10, 200)
+	require.NoError(t, err)
+	defer store.Close()
+	svc, err = gateway.NewGateway(parser, store, resolver, "https://tableland.network", "", "")
+	require.NoError(t, err)
+	_, err = svc.RunReadQuery(ctx, "select bar, 'a long text value to fill the cache' from foo_1337_1", nil)
+	require.NoError(t, err)
+	require.Zero(t, store.lru.Len())
+	for i := 0; i < 5; i++ {
+		_, err = svc.RunReadQuery(ctx, fmt.Sprintf("select bar from foo_1337_1 where bar = %d", i), nil)
+		require.NoError(t, err)
+	}
+	require.Less(t, store.lru.Len(), 5)
+	require.LessOrEqual(t, store.bytes, 200)
+}
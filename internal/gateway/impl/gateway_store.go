@@ -3,10 +3,16 @@ package impl
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mattn/go-sqlite3"
 	"github.com/tablelandnetwork/sqlparser"
 	"github.com/textileio/go-tableland/internal/gateway"
 	"github.com/textileio/go-tableland/internal/tableland"
@@ -19,13 +25,59 @@ import (
 // GatewayStore is the storage layer of the gateway.
 type GatewayStore struct {
 	db *database.SQLiteDB
+
+	readQueryTimeout time.Duration
+	readQueryBudget  int
+
+	// statsCache holds the last calculated stats of tables, which are valid while the last processed height
+	// of their chain doesn't change.
+	statsMu    sync.Mutex
+	statsCache map[statsCacheKey]cachedTableStats
+}
+
+// maxCachedTableStats is the maximum number of tables with cached stats.
+const maxCachedTableStats = 1000
+
+type statsCacheKey struct {
+	chainID tableland.ChainID
+	tableID string
+}
+
+type cachedTableStats struct {
+	height int64
+	stats  gateway.TableStats
+}
+
+// GatewayStoreOption modifies the configuration of a GatewayStore.
+type GatewayStoreOption func(*GatewayStore)
+
+// WithReadQueryTimeout limits the wall-clock time of a read query. Zero means no limit. Queries are interrupted
+// by the SQLite driver when their context is done, so the timeout also stops expensive queries that don't return
+// rows, such as aggregations over cartesian joins.
+func WithReadQueryTimeout(timeout time.Duration) GatewayStoreOption {
+	return func(s *GatewayStore) {
+		s.readQueryTimeout = timeout
+	}
+}
+
+// WithReadQueryBudget limits the number of SQLite virtual machine instructions executed by each statement of
+// a read query. Zero means no limit.
+func WithReadQueryBudget(instructions int) GatewayStoreOption {
+	return func(s *GatewayStore) {
+		s.readQueryBudget = instructions
+	}
 }
 
 // NewGatewayStore creates a new GatewayStore.
-func NewGatewayStore(db *database.SQLiteDB) *GatewayStore {
-	return &GatewayStore{
-		db: db,
+func NewGatewayStore(db *database.SQLiteDB, opts ...GatewayStoreOption) *GatewayStore {
+	s := &GatewayStore{
+		db:         db,
+		statsCache: map[statsCacheKey]cachedTableStats{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // Read executes a parsed read statement.
@@ -38,12 +90,222 @@ func (s *GatewayStore) Read(
 	}
 	ret, err := s.execReadQuery(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("parsing result to json: %s", err)
+		return nil, fmt.Errorf("parsing result to json: %w", err)
 	}
 
 	return ret, nil
 }
 
+// ReadStream executes a parsed read statement and writes the rows to w as they are read.
+func (s *GatewayStore) ReadStream(
+	ctx context.Context, stmt parsing.ReadStmt, resolver sqlparser.ReadStatementResolver, w gateway.RowWriter,
+) error {
+	query, err := stmt.GetQuery(resolver)
+	if err != nil {
+		return fmt.Errorf("get query: %s", err)
+	}
+	if err := s.execReadQueryStream(ctx, query, w); err != nil {
+		return fmt.Errorf("streaming result: %w", err)
+	}
+
+	return nil
+}
+
+// ReadBatch executes resolved read queries in the same read transaction, so all of them read the same snapshot
+// of the database, and writes their rows to w as they are read. Errors of a query, including errors of its
+// RowWriter, are passed to w, but reads interrupted by the timeout or the budget fail the whole batch.
+func (s *GatewayStore) ReadBatch(ctx context.Context, queries []string, w gateway.BatchWriter) error {
+	return s.withReadConn(ctx, func(ctx context.Context, conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		if err != nil {
+			return fmt.Errorf("opening read transaction: %s", err)
+		}
+		defer func() {
+			if err := tx.Rollback(); err != nil {
+				s.db.Log.Warn().Err(err).Msg("rollback read transaction")
+			}
+		}()
+
+		for i, query := range queries {
+			err := readBatchQuery(ctx, tx, query, w.Begin(i))
+			if err != nil && isInterrupted(ctx, err) {
+				return err
+			}
+			w.End(i, err)
+		}
+		return nil
+	})
+}
+
+// isInterrupted returns true if a read failed because the context is done or SQLite interrupted it.
+func isInterrupted(ctx context.Context, err error) bool {
+	var sqliteErr sqlite3.Error
+	return ctx.Err() != nil || (errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrInterrupt)
+}
+
+func readBatchQuery(ctx context.Context, tx *sql.Tx, query string, w gateway.RowWriter) (err error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := rows.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+	}()
+	return rowsToWriter(rows, w)
+}
+
+// ReadPage executes a parsed read statement and returns up to limit rows after the provided position, and the
+// position of the next page if there are more rows. If heights is nil, rows are read at the last processed block
+// heights of the chains of the queried tables, which are returned so the next pages can be read at the same
+// heights. Otherwise, the queried tables are read as they were at the provided heights, rebuilding the tables that
+// changed from the change log, so writes after the first page don't change the next pages.
+// gateway.ErrCursorExpired is returned if a queried table changed and the change log doesn't reach the heights
+// anymore.
+func (s *GatewayStore) ReadPage(
+	ctx context.Context,
+	stmt parsing.ReadStmt,
+	resolver sqlparser.ReadStatementResolver,
+	heights gateway.BlockHeights,
+	after gateway.PagePosition,
+	limit int,
+) (*gateway.TableData, *gateway.PagePosition, gateway.BlockHeights, error) {
+	var ret *gateway.TableData
+	var next *gateway.PagePosition
+	err := s.withReadConn(ctx, func(ctx context.Context, conn *sql.Conn) error {
+		var err error
+		ret, next, heights, err = s.readPage(ctx, conn, stmt, resolver, heights, after, limit)
+		return err
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return ret, next, heights, nil
+}
+
+func (s *GatewayStore) readPage(
+	ctx context.Context,
+	conn *sql.Conn,
+	stmt parsing.ReadStmt,
+	resolver sqlparser.ReadStatementResolver,
+	heights gateway.BlockHeights,
+	after gateway.PagePosition,
+	limit int,
+) (*gateway.TableData, *gateway.PagePosition, gateway.BlockHeights, error) {
+	// Everything is read in the same transaction, so block heights and rows come from the same snapshot.
+	// Shadow tables of pinned reads are created in it too, so rolling it back drops them. The transaction can't be
+	// read-only since it writes the temp schema of the connection, but it never writes the main database.
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("opening read transaction: %s", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			// The shadow tables might be left behind, so the connection can't be reused.
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+			s.db.Log.Warn().Err(err).Msg("rollback read transaction")
+		}
+	}()
+
+	tbls := stmt.GetTables()
+	if heights == nil {
+		heights = gateway.BlockHeights{}
+		for _, tbl := range tbls {
+			chainID := tableland.ChainID(tbl.ChainID())
+			if _, ok := heights[chainID]; ok {
+				continue
+			}
+			var height int64
+			err := tx.QueryRowContext(
+				ctx, "SELECT block_number FROM system_txn_processor WHERE chain_id=?1", chainID,
+			).Scan(&height)
+			if err != nil && err != sql.ErrNoRows {
+				return nil, nil, nil, fmt.Errorf("get last processed height: %s", err)
+			}
+			heights[chainID] = height
+		}
+	} else {
+		for _, tbl := range tbls {
+			if _, ok := heights[tableland.ChainID(tbl.ChainID())]; !ok {
+				return nil, nil, nil, gateway.ErrInvalidCursor
+			}
+		}
+		if err := s.pinTables(ctx, tx, tbls, heights); err != nil {
+			if err == gateway.ErrHistoryUnavailable {
+				return nil, nil, nil, gateway.ErrCursorExpired
+			}
+			return nil, nil, nil, err
+		}
+	}
+
+	// Statements are paginated by key if possible, so pages don't read the previous pages again. Otherwise, the
+	// rows of the previous pages are skipped.
+	pinned := &pinnedResolver{ReadStatementResolver: resolver, heights: heights}
+	var query string
+	var keyColumns int
+	keyed := false
+	if after.Offset == 0 {
+		query, keyColumns, keyed, err = stmt.GetPageQuery(pinned, after.Key, limit+1)
+		if after.Key != nil && (err != nil || !keyed) {
+			return nil, nil, nil, gateway.ErrInvalidCursor
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("get page query: %s", err)
+		}
+	}
+	if !keyed {
+		if query, err = stmt.GetQuery(pinned); err != nil {
+			return nil, nil, nil, fmt.Errorf("get query: %s", err)
+		}
+	}
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("executing query: %s", err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			s.db.Log.Warn().Err(err).Msg("closing rows")
+		}
+	}()
+
+	var next *gateway.PagePosition
+	var ret *gateway.TableData
+	if keyed {
+		var key []interface{}
+		ret, key, err = rowsToKeyedTableDataPage(rows, keyColumns, limit)
+		if key != nil {
+			next = &gateway.PagePosition{Key: key}
+		}
+	} else {
+		var more bool
+		ret, more, err = rowsToTableDataPage(rows, after.Offset, limit)
+		if more {
+			next = &gateway.PagePosition{Offset: after.Offset + int64(limit)}
+		}
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("parsing result to json: %w", err)
+	}
+
+	return ret, next, heights, nil
+}
+
+// pinnedResolver resolves block numbers to the heights a paginated read query is pinned to.
+type pinnedResolver struct {
+	sqlparser.ReadStatementResolver
+	heights gateway.BlockHeights
+}
+
+// GetBlockNumber returns the pinned block number of a chain, if any.
+func (r *pinnedResolver) GetBlockNumber(chainID int64) (int64, bool) {
+	if height, ok := r.heights[tableland.ChainID(chainID)]; ok {
+		return height, true
+	}
+	return r.ReadStatementResolver.GetBlockNumber(chainID)
+}
+
 // GetTable returns a table information.
 func (s *GatewayStore) GetTable(
 	ctx context.Context, chainID tableland.ChainID, tableID tables.TableID,
@@ -59,26 +321,239 @@ func (s *GatewayStore) GetTable(
 		return gateway.Table{}, fmt.Errorf("getting table: %s", err)
 	}
 
-	tableID, err = tables.NewTableIDFromInt64(table.ID)
+	return registryToTable(table)
+}
+
+// GetTableStats returns the statistics of a table, read in the same read transaction. The approximate size is
+// only available if SQLite was built with the dbstat virtual table, e.g. with -DSQLITE_ENABLE_DBSTAT_VTAB.
+// Stats are cached until the last processed height of the chain changes.
+func (s *GatewayStore) GetTableStats(
+	ctx context.Context, chainID tableland.ChainID, tableID tables.TableID,
+) (gateway.TableStats, error) {
+	table, err := s.GetTable(ctx, chainID, tableID)
 	if err != nil {
-		return gateway.Table{}, fmt.Errorf("table id from int64: %s", err)
+		return gateway.TableStats{}, err
 	}
+	name := table.Name()
 
-	return gateway.Table{
-		ID:         tableID,
-		ChainID:    tableland.ChainID(table.ChainID),
-		Controller: table.Controller,
-		Prefix:     table.Prefix,
-		Structure:  table.Structure,
-		CreatedAt:  time.Unix(table.CreatedAt, 0),
-	}, nil
+	key := statsCacheKey{chainID: chainID, tableID: tableID.String()}
+	stats := gateway.TableStats{CreatedAt: table.CreatedAt, Indexes: []gateway.TableIndex{}}
+	var height int64
+	cached := false
+	err = s.withReadConn(ctx, func(ctx context.Context, conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		if err != nil {
+			return fmt.Errorf("opening read transaction: %s", err)
+		}
+		defer func() {
+			if err := tx.Rollback(); err != nil {
+				s.db.Log.Warn().Err(err).Msg("rollback read transaction")
+			}
+		}()
+
+		err = tx.QueryRowContext(ctx,
+			"SELECT coalesce(max(block_number), 0) FROM system_txn_processor WHERE chain_id=?1", chainID,
+		).Scan(&height)
+		if err != nil {
+			return fmt.Errorf("get last processed height: %s", err)
+		}
+		if cachedStats, ok := s.cachedTableStats(key, height); ok {
+			stats, cached = cachedStats, true
+			return nil
+		}
+
+		if err := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT count(*) FROM %s", name)).Scan(&stats.RowCount); err != nil {
+			return fmt.Errorf("counting rows: %s", err)
+		}
+		err = tx.QueryRowContext(ctx, "SELECT count(*) FROM pragma_table_info(?1)", name).Scan(&stats.ColumnCount)
+		if err != nil {
+			return fmt.Errorf("counting columns: %s", err)
+		}
+		if stats.Indexes, err = tableIndexes(ctx, tx, name); err != nil {
+			return fmt.Errorf("listing indexes: %s", err)
+		}
+
+		var bytes int64
+		err = tx.QueryRowContext(ctx,
+			`SELECT coalesce(sum(pgsize), 0) FROM dbstat
+			WHERE name IN (SELECT name FROM sqlite_master WHERE tbl_name=?1)`,
+			name,
+		).Scan(&bytes)
+		if err == nil {
+			stats.Bytes = &bytes
+		} else if !strings.Contains(err.Error(), "no such table: dbstat") {
+			return fmt.Errorf("get table size: %s", err)
+		}
+
+		err = tx.QueryRowContext(ctx,
+			`SELECT r.block_number, r.txn_hash FROM system_txn_receipt_tables t
+			JOIN system_txn_receipts r
+			ON r.chain_id=t.chain_id AND r.block_number=t.block_number AND r.index_in_block=t.index_in_block
+			WHERE t.chain_id=?1 AND t.table_id=?2 AND r.error IS NULL
+			ORDER BY t.block_number DESC, t.index_in_block DESC LIMIT 1`,
+			chainID, tableID.ToBigInt().Int64(),
+		).Scan(&stats.LastModifiedBlock, &stats.LastModifiedTxnHash)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("get last modification: %s", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return gateway.TableStats{}, err
+	}
+	if !cached {
+		s.cacheTableStats(key, height, stats)
+	}
+
+	return stats, nil
+}
+
+// cachedTableStats returns a copy of the cached stats of a table if they were calculated at the provided height.
+func (s *GatewayStore) cachedTableStats(key statsCacheKey, height int64) (gateway.TableStats, bool) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	entry, ok := s.statsCache[key]
+	if !ok || entry.height != height {
+		return gateway.TableStats{}, false
+	}
+	return copyTableStats(entry.stats), true
+}
+
+func (s *GatewayStore) cacheTableStats(key statsCacheKey, height int64, stats gateway.TableStats) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	if _, ok := s.statsCache[key]; !ok && len(s.statsCache) >= maxCachedTableStats {
+		// Evict any entry, most of them are usually stale after a few blocks.
+		for k := range s.statsCache {
+			delete(s.statsCache, k)
+			break
+		}
+	}
+	s.statsCache[key] = cachedTableStats{height: height, stats: copyTableStats(stats)}
+}
+
+func copyTableStats(stats gateway.TableStats) gateway.TableStats {
+	indexes := make([]gateway.TableIndex, len(stats.Indexes))
+	for i, index := range stats.Indexes {
+		indexes[i] = index
+		indexes[i].Columns = append([]string{}, index.Columns...)
+	}
+	stats.Indexes = indexes
+	if stats.Bytes != nil {
+		bytes := *stats.Bytes
+		stats.Bytes = &bytes
+	}
+	return stats
+}
+
+// tableIndexes returns the indexes of a table with their columns, in creation order.
+func tableIndexes(ctx context.Context, tx *sql.Tx, table string) ([]gateway.TableIndex, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT il.name, il."unique", coalesce(ii.name, '') FROM pragma_index_list(?1) il
+		JOIN pragma_index_info(il.name) ii ORDER BY il.seq DESC, ii.seqno`,
+		table,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	indexes := []gateway.TableIndex{}
+	for rows.Next() {
+		var name, column string
+		var unique bool
+		if err := rows.Scan(&name, &unique, &column); err != nil {
+			return nil, err
+		}
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != name {
+			indexes = append(indexes, gateway.TableIndex{Name: name, Unique: unique, Columns: []string{}})
+		}
+		last := &indexes[len(indexes)-1]
+		last.Columns = append(last.Columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return indexes, nil
+}
+
+// GetStateHash returns the last state hash of a chain calculated at or before a block.
+func (s *GatewayStore) GetStateHash(
+	ctx context.Context, chainID tableland.ChainID, blockNumber int64,
+) (gateway.StateHash, bool, error) {
+	res, err := s.db.Queries.GetStateHash(ctx, db.GetStateHashParams{
+		ChainID:     int64(chainID),
+		BlockNumber: blockNumber,
+	})
+	if err == sql.ErrNoRows {
+		return gateway.StateHash{}, false, nil
+	}
+	if err != nil {
+		return gateway.StateHash{}, false, fmt.Errorf("get state hash: %s", err)
+	}
+
+	return gateway.StateHash{
+		ChainID:     tableland.ChainID(res.ChainID),
+		BlockNumber: res.BlockNumber,
+		Hash:        res.Hash,
+		CreatedAt:   time.Unix(res.CreatedAt, 0),
+	}, true, nil
+}
+
+// GetBlockHeights returns the last processed block heights of the provided chains. Chains without processed
+// blocks have height zero.
+func (s *GatewayStore) GetBlockHeights(
+	ctx context.Context, chainIDs []tableland.ChainID,
+) (gateway.BlockHeights, error) {
+	heights := gateway.BlockHeights{}
+	for _, chainID := range chainIDs {
+		if _, ok := heights[chainID]; ok {
+			continue
+		}
+		var height int64
+		err := s.db.DB.QueryRowContext(
+			ctx, "SELECT block_number FROM system_txn_processor WHERE chain_id=?1", chainID,
+		).Scan(&height)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, fmt.Errorf("get last processed height: %s", err)
+		}
+		heights[chainID] = height
+	}
+	return heights, nil
+}
+
+// ListTables returns up to limit tables owned by an address with an id greater than afterID.
+func (s *GatewayStore) ListTables(
+	ctx context.Context, chainID tableland.ChainID, owner common.Address, afterID int64, limit int,
+) ([]gateway.Table, error) {
+	rows, err := s.db.Queries.ListTablesByController(ctx, db.ListTablesByControllerParams{
+		ChainID:    int64(chainID),
+		Controller: owner.Hex(),
+		ID:         afterID,
+		Limit:      int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing tables: %s", err)
+	}
+
+	tbls := make([]gateway.Table, len(rows))
+	for i, row := range rows {
+		tbls[i], err = registryToTable(row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tbls, nil
 }
 
 // GetSchemaByTableName returns the table schema given its name.
 func (s *GatewayStore) GetSchemaByTableName(ctx context.Context, tblName string) (gateway.TableSchema, error) {
 	createStmt, err := s.db.Queries.GetSchemaByTableName(ctx, tblName)
 	if err != nil {
-		return gateway.TableSchema{}, fmt.Errorf("failed to get the table: %s", err)
+		return gateway.TableSchema{}, fmt.Errorf("failed to get the table: %w", err)
 	}
 
 	if strings.Contains(strings.ToLower(createStmt), "autoincrement") {
@@ -138,11 +613,216 @@ func (s *GatewayStore) GetReceipt(
 		return gateway.Receipt{}, false, fmt.Errorf("get receipt: %s", err)
 	}
 
+	receipt, err := receiptFromRow(res)
+	if err != nil {
+		return gateway.Receipt{}, false, err
+	}
+
+	return receipt, true, nil
+}
+
+// GetReceipts returns the receipts of the transactions that were found, in no particular order. It's a single
+// query using the (chain_id, txn_hash) index, filtered to the exact lookups afterwards.
+func (s *GatewayStore) GetReceipts(ctx context.Context, lookups []gateway.ReceiptLookup) ([]gateway.Receipt, error) {
+	if len(lookups) == 0 {
+		return nil, nil
+	}
+
+	wanted := make(map[gateway.ReceiptLookup]struct{}, len(lookups))
+	chainIDs := map[tableland.ChainID]struct{}{}
+	var hashArgs []any
+	for _, lookup := range lookups {
+		if _, ok := wanted[lookup]; ok {
+			continue
+		}
+		wanted[lookup] = struct{}{}
+		chainIDs[lookup.ChainID] = struct{}{}
+		hashArgs = append(hashArgs, lookup.TxnHash.Hex())
+	}
+	args := make([]any, 0, len(chainIDs)+len(hashArgs))
+	for chainID := range chainIDs {
+		args = append(args, int64(chainID))
+	}
+	args = append(args, hashArgs...)
+
+	query := fmt.Sprintf(
+		"SELECT chain_id, block_number, index_in_block, txn_hash, error, table_id, error_event_idx, table_ids "+
+			"FROM system_txn_receipts WHERE chain_id IN (%s) AND txn_hash IN (%s)",
+		placeholders(len(chainIDs)), placeholders(len(hashArgs)),
+	)
+	rows, err := s.db.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get receipts: %s", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var receipts []gateway.Receipt
+	for rows.Next() {
+		var row db.SystemTxnReceipt
+		if err := rows.Scan(
+			&row.ChainID,
+			&row.BlockNumber,
+			&row.IndexInBlock,
+			&row.TxnHash,
+			&row.Error,
+			&row.TableID,
+			&row.ErrorEventIdx,
+			&row.TableIds,
+		); err != nil {
+			return nil, fmt.Errorf("scanning receipt: %s", err)
+		}
+		lookup := gateway.ReceiptLookup{ChainID: tableland.ChainID(row.ChainID), TxnHash: common.HexToHash(row.TxnHash)}
+		if _, ok := wanted[lookup]; !ok {
+			continue
+		}
+		receipt, err := receiptFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating receipts: %s", err)
+	}
+
+	return receipts, nil
+}
+
+// placeholders returns n comma separated query parameter placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// ListReceiptsAfter returns up to limit receipts of a chain that were executed after the
+// provided position, ordered by block number and index in block.
+func (s *GatewayStore) ListReceiptsAfter(
+	ctx context.Context, chainID tableland.ChainID, blockNumber int64, indexInBlock int64, limit int,
+) ([]gateway.Receipt, error) {
+	rows, err := s.db.Queries.ListReceiptsAfter(ctx, db.ListReceiptsAfterParams{
+		ChainID:      int64(chainID),
+		BlockNumber:  blockNumber,
+		IndexInBlock: indexInBlock,
+		Limit:        int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list receipts: %s", err)
+	}
+
+	receipts := make([]gateway.Receipt, len(rows))
+	for i, row := range rows {
+		receipts[i], err = receiptFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return receipts, nil
+}
+
+// ListReceipts returns up to limit receipts of a chain that match the filter and were executed after the
+// provided position, ordered by block number and index in block.
+func (s *GatewayStore) ListReceipts(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	filter gateway.ReceiptFilter,
+	blockNumber int64,
+	indexInBlock int64,
+	limit int,
+) ([]gateway.Receipt, error) {
+	toBlock := int64(math.MaxInt64)
+	if filter.ToBlock > 0 {
+		toBlock = filter.ToBlock
+	}
+
+	var rows []db.SystemTxnReceipt
+	var err error
+	if filter.TableID != nil {
+		rows, err = s.db.Queries.ListTableReceiptsInRange(ctx, db.ListTableReceiptsInRangeParams{
+			ChainID:       int64(chainID),
+			TableID:       filter.TableID.ToBigInt().Int64(),
+			BlockNumber:   blockNumber,
+			IndexInBlock:  indexInBlock,
+			BlockNumber_2: toBlock,
+			Limit:         int64(limit),
+		})
+	} else {
+		rows, err = s.db.Queries.ListReceiptsInRange(ctx, db.ListReceiptsInRangeParams{
+			ChainID:       int64(chainID),
+			BlockNumber:   blockNumber,
+			IndexInBlock:  indexInBlock,
+			BlockNumber_2: toBlock,
+			Limit:         int64(limit),
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list receipts: %s", err)
+	}
+
+	receipts := make([]gateway.Receipt, len(rows))
+	for i, row := range rows {
+		receipts[i], err = receiptFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return receipts, nil
+}
+
+// ListTableEvents returns up to limit events of a table that were emitted after the provided position,
+// ordered by block number and index in block.
+func (s *GatewayStore) ListTableEvents(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	id tables.TableID,
+	blockNumber int64,
+	eventIndex int64,
+	limit int,
+) ([]gateway.TableEvent, error) {
+	rows, err := s.db.Queries.ListTableEvents(ctx, db.ListTableEventsParams{
+		ChainID:     int64(chainID),
+		TableID:     sql.NullInt64{Int64: id.ToBigInt().Int64(), Valid: true},
+		BlockNumber: blockNumber,
+		EventIndex:  uint(eventIndex),
+		Limit:       int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list table events: %s", err)
+	}
+
+	events := make([]gateway.TableEvent, len(rows))
+	for i, row := range rows {
+		events[i] = gateway.TableEvent{
+			BlockNumber: row.BlockNumber,
+			TxnHash:     row.TxHash,
+			TxnIndex:    row.TxIndex,
+			EventIndex:  row.EventIndex,
+			EventType:   row.EventType,
+			EventJSON:   []byte(row.EventJson),
+			Executed:    row.TxnHash.Valid,
+		}
+		if row.Timestamp.Valid {
+			timestamp := time.Unix(row.Timestamp.Int64, 0)
+			events[i].BlockTimestamp = &timestamp
+		}
+		if row.Error.Valid {
+			errorMsg := row.Error.String
+			events[i].Error = &errorMsg
+
+			errorEventIdx := int(row.ErrorEventIdx.Int64)
+			events[i].ErrorEventIdx = &errorEventIdx
+		}
+	}
+
+	return events, nil
+}
+
+func receiptFromRow(res db.SystemTxnReceipt) (gateway.Receipt, error) {
 	receipt := gateway.Receipt{
-		ChainID:      chainID,
+		ChainID:      tableland.ChainID(res.ChainID),
 		BlockNumber:  res.BlockNumber,
 		IndexInBlock: res.IndexInBlock,
-		TxnHash:      txnHash,
+		TxnHash:      res.TxnHash,
 	}
 
 	if res.Error.Valid {
@@ -155,7 +835,7 @@ func (s *GatewayStore) GetReceipt(
 	if res.TableID.Valid {
 		id, err := tables.NewTableIDFromInt64(res.TableID.Int64)
 		if err != nil {
-			return gateway.Receipt{}, false, fmt.Errorf("parsing id integer: %s", err)
+			return gateway.Receipt{}, fmt.Errorf("parsing id integer: %s", err)
 		}
 		receipt.TableID = &id // nolint
 	}
@@ -166,25 +846,113 @@ func (s *GatewayStore) GetReceipt(
 		for i, idStr := range tableIdsStr {
 			tableID, err := tables.NewTableID(idStr)
 			if err != nil {
-				return gateway.Receipt{}, false, fmt.Errorf("parsing id string: %s", err)
+				return gateway.Receipt{}, fmt.Errorf("parsing id string: %s", err)
 			}
 			tableIds[i] = tableID
 		}
 		receipt.TableIDs = tableIds
 	}
 
-	return receipt, true, nil
+	return receipt, nil
+}
+
+func registryToTable(table db.Registry) (gateway.Table, error) {
+	tableID, err := tables.NewTableIDFromInt64(table.ID)
+	if err != nil {
+		return gateway.Table{}, fmt.Errorf("table id from int64: %s", err)
+	}
+
+	return gateway.Table{
+		ID:         tableID,
+		ChainID:    tableland.ChainID(table.ChainID),
+		Controller: table.Controller,
+		Prefix:     table.Prefix,
+		Structure:  table.Structure,
+		CreatedAt:  time.Unix(table.CreatedAt, 0),
+	}, nil
 }
 
 func (s *GatewayStore) execReadQuery(ctx context.Context, q string) (*gateway.TableData, error) {
-	rows, err := s.db.DB.QueryContext(ctx, q)
+	var ret *gateway.TableData
+	err := s.withReadConn(ctx, func(ctx context.Context, conn *sql.Conn) error {
+		rows, err := conn.QueryContext(ctx, q)
+		if err != nil {
+			return fmt.Errorf("executing query: %s", err)
+		}
+		defer func() {
+			if err = rows.Close(); err != nil {
+				s.db.Log.Warn().Err(err).Msg("closing rows")
+			}
+		}()
+		ret, err = rowsToTableData(rows)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("executing query: %s", err)
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (s *GatewayStore) execReadQueryStream(ctx context.Context, q string, w gateway.RowWriter) error {
+	return s.withReadConn(ctx, func(ctx context.Context, conn *sql.Conn) error {
+		rows, err := conn.QueryContext(ctx, q)
+		if err != nil {
+			return fmt.Errorf("executing query: %s", err)
+		}
+		defer func() {
+			if err = rows.Close(); err != nil {
+				s.db.Log.Warn().Err(err).Msg("closing rows")
+			}
+		}()
+		return rowsToWriter(rows, w)
+	})
+}
+
+// withReadConn runs a read with a dedicated connection, enforcing the read query timeout and budget.
+// If the read is interrupted by any of them, gateway.ErrReadQueryTimeout or gateway.ErrReadQueryBudgetExceeded
+// is returned.
+func (s *GatewayStore) withReadConn(ctx context.Context, read func(context.Context, *sql.Conn) error) error {
+	readCtx := ctx
+	if s.readQueryTimeout > 0 {
+		var cancel context.CancelFunc
+		readCtx, cancel = context.WithTimeout(ctx, s.readQueryTimeout)
+		defer cancel()
+	}
+
+	conn, err := s.db.DB.Conn(readCtx)
+	if err != nil {
+		return fmt.Errorf("getting connection: %s", err)
 	}
 	defer func() {
-		if err = rows.Close(); err != nil {
-			s.db.Log.Warn().Err(err).Msg("closing rows")
+		if err := conn.Close(); err != nil {
+			s.db.Log.Warn().Err(err).Msg("closing connection")
 		}
 	}()
-	return rowsToTableData(rows)
+
+	var budget *progressBudget
+	if s.readQueryBudget > 0 {
+		budget, err = setProgressBudget(readCtx, conn, s.readQueryBudget)
+		if err != nil {
+			return fmt.Errorf("setting read query budget: %s", err)
+		}
+	}
+
+	err = read(readCtx, conn)
+	var exceeded bool
+	if budget != nil {
+		var errClear error
+		if exceeded, errClear = budget.clear(); errClear != nil {
+			s.db.Log.Error().Err(errClear).Msg("clearing read query budget")
+		}
+	}
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() == nil && readCtx.Err() == context.DeadlineExceeded {
+		return &gateway.ErrReadQueryTimeout{Timeout: s.readQueryTimeout}
+	}
+	if exceeded {
+		return &gateway.ErrReadQueryBudgetExceeded{MaxInstructions: s.readQueryBudget}
+	}
+	return err
 }
@@ -1,23 +1,34 @@
 package impl
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/formatter"
 	"github.com/textileio/go-tableland/internal/gateway"
 	"github.com/textileio/go-tableland/internal/router/middlewares"
 	"github.com/textileio/go-tableland/internal/tableland"
+	tablelandimpl "github.com/textileio/go-tableland/internal/tableland/impl"
 	"github.com/textileio/go-tableland/pkg/database"
+	"github.com/textileio/go-tableland/pkg/database/db"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
 	executor "github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor/impl"
 	"github.com/textileio/go-tableland/pkg/parsing"
 	parserimpl "github.com/textileio/go-tableland/pkg/parsing/impl"
+	"github.com/textileio/go-tableland/pkg/sharedmemory"
 	"github.com/textileio/go-tableland/pkg/tables"
 	"github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
 	"github.com/textileio/go-tableland/tests"
@@ -266,6 +277,1122 @@ func TestGetMetadata(t *testing.T) {
 	})
 }
 
+func TestListTables(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	otherOwner := common.HexToAddress("0x07dfFc57AA386D2b239CaBE8993358DF20BAFBE2")
+
+	// populate the registry with three tables of owner and one of otherOwner
+	ex, err := executor.NewExecutor(chainID, db, parser, 0, nil)
+	require.NoError(t, err)
+	bs, err := ex.NewBlockScope(context.Background(), 0)
+	require.NoError(t, err)
+	for i, tblOwner := range []common.Address{owner, otherOwner, owner, owner} {
+		res, err := bs.ExecuteTxnEvents(context.Background(), eventfeed.TxnEvents{
+			TxnHash: common.HexToHash(fmt.Sprintf("0x%d", i)),
+			Events: []interface{}{
+				&ethereum.ContractCreateTable{
+					TableId:   big.NewInt(int64(i + 1)),
+					Owner:     tblOwner,
+					Statement: "create table foo_1337 (bar int)",
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+	}
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	store := NewGatewayStore(db)
+
+	t.Run("store pagination", func(t *testing.T) {
+		t.Parallel()
+
+		tbls, err := store.ListTables(context.Background(), chainID, owner, -1, 2)
+		require.NoError(t, err)
+		require.Len(t, tbls, 2)
+		require.Equal(t, "1", tbls[0].ID.String())
+		require.Equal(t, "3", tbls[1].ID.String())
+		require.Equal(t, owner.Hex(), tbls[0].Controller)
+
+		tbls, err = store.ListTables(context.Background(), chainID, owner, 3, 2)
+		require.NoError(t, err)
+		require.Len(t, tbls, 1)
+		require.Equal(t, "4", tbls[0].ID.String())
+
+		tbls, err = store.ListTables(context.Background(), chainID, owner, 4, 2)
+		require.NoError(t, err)
+		require.Empty(t, tbls)
+	})
+
+	t.Run("gateway", func(t *testing.T) {
+		t.Parallel()
+
+		svc, err := gateway.NewGateway(parser, store, nil, "https://tableland.network", "", "")
+		require.NoError(t, err)
+
+		tbls, cursor, err := svc.ListTables(context.Background(), chainID, otherOwner, "")
+		require.NoError(t, err)
+		require.Empty(t, cursor)
+		require.Len(t, tbls, 1)
+		require.Equal(t, "foo_1337_2", tbls[0].Name())
+
+		tbls, cursor, err = svc.ListTables(context.Background(), chainID, owner, "")
+		require.NoError(t, err)
+		require.Empty(t, cursor)
+		require.Len(t, tbls, 3)
+
+		tbls, _, err = svc.ListTables(context.Background(), 1, owner, "")
+		require.NoError(t, err)
+		require.Empty(t, tbls)
+
+		_, _, err = svc.ListTables(context.Background(), chainID, owner, "not a cursor")
+		require.ErrorIs(t, err, gateway.ErrInvalidCursor)
+	})
+}
+
+func TestGetReceipts(t *testing.T) {
+	t.Parallel()
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+	db, err := database.Open(tests.Sqlite3URI(t))
+	require.NoError(t, err)
+	ex, err := executor.NewExecutor(chainID, db, parser, 0, nil)
+	require.NoError(t, err)
+
+	hash1, hash2, hash3 := common.HexToHash("0x1"), common.HexToHash("0x2"), common.HexToHash("0x3")
+	bs, err := ex.NewBlockScope(context.Background(), 0)
+	require.NoError(t, err)
+	require.NoError(t, bs.SaveTxnReceipts(context.Background(), []eventprocessor.Receipt{
+		{ChainID: chainID, BlockNumber: 1, IndexInBlock: 0, TxnHash: hash1.Hex()},
+		{ChainID: chainID, BlockNumber: 1, IndexInBlock: 1, TxnHash: hash2.Hex()},
+		{ChainID: 1, BlockNumber: 1, IndexInBlock: 0, TxnHash: hash3.Hex()},
+	}))
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	store := NewGatewayStore(db)
+	receipts, err := store.GetReceipts(context.Background(), []gateway.ReceiptLookup{
+		{ChainID: chainID, TxnHash: hash1},
+		{ChainID: chainID, TxnHash: hash1},
+		{ChainID: chainID, TxnHash: common.HexToHash("0x4")},
+		// Each hash is only looked up in its own chain.
+		{ChainID: chainID, TxnHash: hash3},
+		{ChainID: 1, TxnHash: hash2},
+	})
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	require.Equal(t, hash1.Hex(), receipts[0].TxnHash)
+
+	receipts, err = store.GetReceipts(context.Background(), nil)
+	require.NoError(t, err)
+	require.Empty(t, receipts)
+}
+
+func TestListReceipts(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	ex, err := executor.NewExecutor(chainID, db, parser, 0, nil)
+	require.NoError(t, err)
+
+	tableIDs := func(ids ...int64) tables.TableIDs {
+		tableIDs := make(tables.TableIDs, len(ids))
+		for i, id := range ids {
+			tableIDs[i] = tables.TableID(*big.NewInt(id))
+		}
+		return tableIDs
+	}
+	errMsg := "table not found"
+	errorEventIdx := 0
+	receipts := []eventprocessor.Receipt{
+		{ChainID: chainID, BlockNumber: 1, IndexInBlock: 0, TxnHash: "0x10", TableIDs: tableIDs(1)},
+		{ChainID: chainID, BlockNumber: 1, IndexInBlock: 1, TxnHash: "0x11", TableIDs: tableIDs(2, 12)},
+		{ChainID: chainID, BlockNumber: 2, IndexInBlock: 0, TxnHash: "0x20", Error: &errMsg, ErrorEventIdx: &errorEventIdx,
+			TableID: &tableIDs(2)[0]},
+		{ChainID: chainID, BlockNumber: 3, IndexInBlock: 0, TxnHash: "0x30", TableIDs: tableIDs(1, 2)},
+	}
+	// Block 10 has more receipts than fit in a page.
+	for i := 0; i <= gateway.ListReceiptsPageSize; i++ {
+		receipts = append(receipts, eventprocessor.Receipt{
+			ChainID:      chainID,
+			BlockNumber:  10,
+			IndexInBlock: int64(i),
+			TxnHash:      fmt.Sprintf("0x10%d", i),
+			TableIDs:     tableIDs(3),
+		})
+	}
+	bs, err := ex.NewBlockScope(context.Background(), 0)
+	require.NoError(t, err)
+	require.NoError(t, bs.SaveTxnReceipts(context.Background(), receipts))
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	store := NewGatewayStore(db)
+
+	txnHashes := func(receipts []gateway.Receipt) []string {
+		hashes := make([]string, len(receipts))
+		for i, receipt := range receipts {
+			hashes[i] = receipt.TxnHash
+		}
+		return hashes
+	}
+
+	t.Run("store filters", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		id := func(id int64) *tables.TableID {
+			tableID := tables.TableID(*big.NewInt(id))
+			return &tableID
+		}
+
+		receipts, err := store.ListReceipts(ctx, chainID, gateway.ReceiptFilter{ToBlock: 3}, -1, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, []string{"0x10", "0x11", "0x20", "0x30"}, txnHashes(receipts))
+		require.Nil(t, receipts[2].TableIDs)
+		require.Equal(t, errMsg, *receipts[2].Error)
+
+		receipts, err = store.ListReceipts(ctx, chainID, gateway.ReceiptFilter{ToBlock: 3}, 1, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, []string{"0x11", "0x20", "0x30"}, txnHashes(receipts))
+
+		receipts, err = store.ListReceipts(ctx, chainID, gateway.ReceiptFilter{ToBlock: 3}, -1, 0, 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{"0x10", "0x11"}, txnHashes(receipts))
+
+		receipts, err = store.ListReceipts(ctx, chainID, gateway.ReceiptFilter{TableID: id(2)}, -1, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, []string{"0x11", "0x20", "0x30"}, txnHashes(receipts))
+
+		receipts, err = store.ListReceipts(ctx, chainID, gateway.ReceiptFilter{TableID: id(1)}, -1, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, []string{"0x10", "0x30"}, txnHashes(receipts))
+
+		receipts, err = store.ListReceipts(ctx, chainID, gateway.ReceiptFilter{TableID: id(4)}, -1, 0, 10)
+		require.NoError(t, err)
+		require.Empty(t, receipts)
+
+		receipts, err = store.ListReceipts(ctx, 1, gateway.ReceiptFilter{}, -1, 0, 10)
+		require.NoError(t, err)
+		require.Empty(t, receipts)
+	})
+
+	t.Run("gateway", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		svc, err := gateway.NewGateway(parser, store, nil, "https://tableland.network", "", "")
+		require.NoError(t, err)
+
+		receipts, cursor, err := svc.ListReceipts(ctx, chainID, gateway.ReceiptFilter{FromBlock: 2, ToBlock: 3}, "")
+		require.NoError(t, err)
+		require.Empty(t, cursor)
+		require.Equal(t, []string{"0x20", "0x30"}, txnHashes(receipts))
+
+		receipts, cursor, err = svc.ListReceipts(ctx, chainID, gateway.ReceiptFilter{FromBlock: 3}, "")
+		require.NoError(t, err)
+		require.NotEmpty(t, cursor)
+		require.Len(t, receipts, gateway.ListReceiptsPageSize)
+		require.Equal(t, "0x30", receipts[0].TxnHash)
+
+		receipts, cursor, err = svc.ListReceipts(ctx, chainID, gateway.ReceiptFilter{FromBlock: 3}, cursor)
+		require.NoError(t, err)
+		require.Empty(t, cursor)
+		require.Len(t, receipts, 2)
+		require.Equal(t, int64(10), receipts[1].BlockNumber)
+		require.Equal(t, int64(gateway.ListReceiptsPageSize), receipts[1].IndexInBlock)
+
+		_, _, err = svc.ListReceipts(ctx, chainID, gateway.ReceiptFilter{}, "not a cursor")
+		require.ErrorIs(t, err, gateway.ErrInvalidCursor)
+	})
+}
+
+func TestGetTableEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	sqlDB, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	insertEvent := func(tableID int64, blockNumber int64, txnHash string, index uint, eventType string) {
+		require.NoError(t, sqlDB.Queries.InsertEVMEvent(ctx, db.InsertEVMEventParams{
+			ChainID:     int64(chainID),
+			EventJson:   fmt.Sprintf(`{"TableId":%d,"Statement":"stmt %d"}`, tableID, index),
+			EventType:   eventType,
+			Address:     "0x0",
+			Topics:      "[]",
+			Data:        []byte{},
+			BlockNumber: blockNumber,
+			TxHash:      txnHash,
+			BlockHash:   "0x0",
+			EventIndex:  index,
+			TableID:     sql.NullInt64{Int64: tableID, Valid: true},
+		}))
+	}
+	insertEvent(1, 1, "0x1", 0, "ContractCreateTable")
+	insertEvent(2, 1, "0x1", 1, "ContractCreateTable")
+	insertEvent(1, 2, "0x2", 0, "ContractRunSQL")
+	insertEvent(1, 3, "0x3", 0, "ContractRunSQL")
+	insertEvent(1, 3, "0x3", 1, "ContractSetController")
+	// Block 4 has more events of table 2 than fit in a page.
+	for i := 0; i <= gateway.ListTableEventsPageSize; i++ {
+		insertEvent(2, 4, fmt.Sprintf("0x4%d", i), uint(i), "ContractRunSQL")
+	}
+	require.NoError(t, sqlDB.Queries.InsertBlockExtraInfo(ctx, db.InsertBlockExtraInfoParams{
+		ChainID:     int64(chainID),
+		BlockNumber: 1,
+		Timestamp:   1700000000,
+	}))
+
+	ex, err := executor.NewExecutor(chainID, sqlDB, parser, 0, nil)
+	require.NoError(t, err)
+	bs, err := ex.NewBlockScope(ctx, 0)
+	require.NoError(t, err)
+	errMsg := "column not found"
+	errorEventIdx := 1
+	require.NoError(t, bs.SaveTxnReceipts(ctx, []eventprocessor.Receipt{
+		{ChainID: chainID, BlockNumber: 1, TxnHash: "0x1"},
+		{ChainID: chainID, BlockNumber: 3, TxnHash: "0x3", Error: &errMsg, ErrorEventIdx: &errorEventIdx},
+	}))
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	svc, err := gateway.NewGateway(parser, NewGatewayStore(sqlDB), nil, "https://tableland.network", "", "")
+	require.NoError(t, err)
+
+	events, cursor, err := svc.GetTableEvents(ctx, chainID, tables.TableID(*big.NewInt(1)), "")
+	require.NoError(t, err)
+	require.Empty(t, cursor)
+	require.Len(t, events, 4)
+
+	require.Equal(t, "ContractCreateTable", events[0].EventType)
+	require.JSONEq(t, `{"TableId":1,"Statement":"stmt 0"}`, string(events[0].EventJSON))
+	require.Equal(t, time.Unix(1700000000, 0), *events[0].BlockTimestamp)
+	require.True(t, events[0].Executed)
+	require.Nil(t, events[0].Error)
+
+	require.Equal(t, int64(2), events[1].BlockNumber)
+	require.Nil(t, events[1].BlockTimestamp)
+	require.False(t, events[1].Executed)
+
+	for _, event := range events[2:] {
+		require.Equal(t, "0x3", event.TxnHash)
+		require.True(t, event.Executed)
+		require.Equal(t, errMsg, *event.Error)
+		require.Equal(t, errorEventIdx, *event.ErrorEventIdx)
+	}
+	require.Equal(t, "ContractSetController", events[3].EventType)
+
+	events, cursor, err = svc.GetTableEvents(ctx, chainID, tables.TableID(*big.NewInt(2)), "")
+	require.NoError(t, err)
+	require.NotEmpty(t, cursor)
+	require.Len(t, events, gateway.ListTableEventsPageSize)
+	require.Equal(t, "0x1", events[0].TxnHash)
+
+	events, cursor, err = svc.GetTableEvents(ctx, chainID, tables.TableID(*big.NewInt(2)), cursor)
+	require.NoError(t, err)
+	require.Empty(t, cursor)
+	require.Len(t, events, 2)
+	require.Equal(t, uint(gateway.ListTableEventsPageSize), events[1].EventIndex)
+
+	events, _, err = svc.GetTableEvents(ctx, chainID, tables.TableID(*big.NewInt(3)), "")
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	_, _, err = svc.GetTableEvents(ctx, chainID, tables.TableID(*big.NewInt(1)), "not a cursor")
+	require.ErrorIs(t, err, gateway.ErrInvalidCursor)
+}
+
+func TestRunReadQueryPage(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	ex, err := executor.NewExecutor(
+		chainID, db, parser, 0, tablelandimpl.NewACL(db), executor.WithChangeLogRetention(10),
+	)
+	require.NoError(t, err)
+
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	executeBlock := func(height int64, events ...interface{}) {
+		bs, err := ex.NewBlockScope(context.Background(), height)
+		require.NoError(t, err)
+		txnHash := common.HexToHash(fmt.Sprintf("0x%d", height))
+		res, err := bs.ExecuteTxnEvents(context.Background(), eventfeed.TxnEvents{
+			TxnHash: txnHash,
+			Events:  events,
+		})
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+		require.NoError(t, bs.SaveTxnReceipts(context.Background(), []eventprocessor.Receipt{
+			{ChainID: chainID, BlockNumber: height, TxnHash: txnHash.Hex(), TableIDs: res.TableIDs},
+		}))
+		require.NoError(t, bs.SetLastProcessedHeight(context.Background(), height))
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+	}
+	insert := func(statement string) *ethereum.ContractRunSQL {
+		return &ethereum.ContractRunSQL{
+			Caller:    owner,
+			IsOwner:   true,
+			TableId:   big.NewInt(1),
+			Statement: statement,
+			Policy: ethereum.ITablelandControllerPolicy{
+				AllowInsert: true,
+			},
+		}
+	}
+
+	executeBlock(1,
+		&ethereum.ContractCreateTable{
+			TableId:   big.NewInt(1),
+			Owner:     owner,
+			Statement: "create table foo_1337 (bar int)",
+		},
+		insert("insert into foo_1337_1 values (1), (2), (3), (4), (5)"),
+	)
+
+	resolver := parsing.NewReadStatementResolver(sharedmemory.NewSharedMemory())
+	svc, err := gateway.NewGateway(parser, NewGatewayStore(db), resolver, "https://tableland.network", "", "")
+	require.NoError(t, err)
+
+	stmt := "select bar from foo_1337_1 order by bar"
+
+	data, cursor, err := svc.RunReadQueryPage(context.Background(), stmt, nil, "", 2)
+	require.NoError(t, err)
+	require.Len(t, data.Rows, 2)
+	require.Equal(t, int64(1), data.Rows[0][0].Value())
+	require.NotEmpty(t, cursor)
+
+	// The cursor can't be used with a different statement.
+	_, _, err = svc.RunReadQueryPage(context.Background(), "select * from foo_1337_1", nil, cursor, 0)
+	require.ErrorIs(t, err, gateway.ErrInvalidCursor)
+
+	data, cursor, err = svc.RunReadQueryPage(context.Background(), stmt, nil, cursor, 0)
+	require.NoError(t, err)
+	require.Len(t, data.Rows, 2)
+	require.Equal(t, int64(3), data.Rows[0][0].Value())
+	require.NotEmpty(t, cursor)
+
+	// Changing the table after the first page was read doesn't change the next pages.
+	executeBlock(2, insert("insert into foo_1337_1 values (0)"))
+	data, next, err := svc.RunReadQueryPage(context.Background(), stmt, nil, cursor, 0)
+	require.NoError(t, err)
+	require.Len(t, data.Rows, 1)
+	require.Equal(t, int64(5), data.Rows[0][0].Value())
+	require.Empty(t, next)
+
+	// The cursor expires once the change log doesn't reach the height of the first page.
+	executeBlock(20, insert("insert into foo_1337_1 values (6)"))
+	_, _, err = svc.RunReadQueryPage(context.Background(), stmt, nil, cursor, 0)
+	require.ErrorIs(t, err, gateway.ErrCursorExpired)
+
+	// A new query is pinned to the new height and reads the last page without a next cursor.
+	data, cursor, err = svc.RunReadQueryPage(context.Background(), stmt, nil, "", 4)
+	require.NoError(t, err)
+	require.Len(t, data.Rows, 4)
+	require.Equal(t, int64(0), data.Rows[0][0].Value())
+	data, cursor, err = svc.RunReadQueryPage(context.Background(), stmt, nil, cursor, 0)
+	require.NoError(t, err)
+	require.Len(t, data.Rows, 3)
+	require.Equal(t, int64(4), data.Rows[0][0].Value())
+	require.Empty(t, cursor)
+
+	_, _, err = svc.RunReadQueryPage(context.Background(), stmt, nil, "not a cursor", 0)
+	require.ErrorIs(t, err, gateway.ErrInvalidCursor)
+}
+
+func TestRunReadQueryPageOrders(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	ex, err := executor.NewExecutor(chainID, db, parser, 0, tablelandimpl.NewACL(db))
+	require.NoError(t, err)
+	bs, err := ex.NewBlockScope(context.Background(), 1)
+	require.NoError(t, err)
+	res, err := bs.ExecuteTxnEvents(context.Background(), eventfeed.TxnEvents{
+		TxnHash: common.HexToHash("0x1"),
+		Events: []interface{}{
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(1),
+				Owner:     common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+				Statement: "create table foo_1337 (a int, b text, c int)",
+			},
+			&ethereum.ContractRunSQL{
+				Caller:  common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+				IsOwner: true,
+				TableId: big.NewInt(1),
+				Statement: `insert into foo_1337_1 values
+					(1, 'x', 15), (2, null, null), (null, 'it''s', 1), (2, 'x', 25), (3, '{"k":1}', null),
+					(null, null, 15), (1, '[1]', -1), (4, 'y', 1), (2, 'x', 9223372036854775807)`,
+				Policy: ethereum.ITablelandControllerPolicy{
+					AllowInsert: true,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	resolver := parsing.NewReadStatementResolver(sharedmemory.NewSharedMemory())
+	svc, err := gateway.NewGateway(parser, NewGatewayStore(db), resolver, "https://tableland.network", "", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		stmt  string
+		keyed bool
+	}{
+		{stmt: "select * from foo_1337_1", keyed: true},
+		{stmt: "select a, b from foo_1337_1 order by a desc", keyed: true},
+		{stmt: "select a, b, c from foo_1337_1 order by b, c desc", keyed: true},
+		{stmt: "select a, b from foo_1337_1 order by a desc nulls first, b nulls last", keyed: true},
+		{stmt: "select a as x, b from foo_1337_1 where b is not null order by x", keyed: true},
+		{stmt: "select b from foo_1337_1 f where f.a > 1 order by 1 desc", keyed: true},
+		{stmt: "select c, a * 3 from foo_1337_1 order by a * 3 desc, c", keyed: true},
+		{stmt: "select a, count(*) from foo_1337_1 group by a", keyed: false},
+		{stmt: "select distinct b from foo_1337_1 order by b", keyed: false},
+		{stmt: "select f.a, g.b from foo_1337_1 f join foo_1337_1 g on f.a = g.a", keyed: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.stmt, func(t *testing.T) {
+			t.Parallel()
+
+			all, err := svc.RunReadQuery(context.Background(), test.stmt, nil)
+			require.NoError(t, err)
+
+			var rows []string
+			var cursor string
+			for {
+				data, next, err := svc.RunReadQueryPage(context.Background(), test.stmt, nil, cursor, 2)
+				require.NoError(t, err)
+				require.Equal(t, all.Columns, data.Columns)
+				for _, row := range data.Rows {
+					b, err := json.Marshal(row)
+					require.NoError(t, err)
+					rows = append(rows, string(b))
+				}
+				if next == "" {
+					break
+				}
+				c, err := base64.RawURLEncoding.DecodeString(next)
+				require.NoError(t, err)
+				require.Equal(t, test.keyed, strings.Contains(string(c), `"k":`))
+				cursor = next
+			}
+
+			var expected []string
+			for _, row := range all.Rows {
+				b, err := json.Marshal(row)
+				require.NoError(t, err)
+				expected = append(expected, string(b))
+			}
+			require.ElementsMatch(t, expected, rows)
+		})
+	}
+}
+
+func TestGetReadQueryHeights(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	ex, err := executor.NewExecutor(chainID, db, parser, 0, tablelandimpl.NewACL(db))
+	require.NoError(t, err)
+	bs, err := ex.NewBlockScope(context.Background(), 5)
+	require.NoError(t, err)
+	require.NoError(t, bs.SetLastProcessedHeight(context.Background(), 5))
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	resolver := parsing.NewReadStatementResolver(sharedmemory.NewSharedMemory())
+	svc, err := gateway.NewGateway(parser, NewGatewayStore(db), resolver, "https://tableland.network", "", "")
+	require.NoError(t, err)
+
+	heights, err := svc.GetReadQueryHeights(context.Background(), "select * from foo_1337_1 join bar_1_2")
+	require.NoError(t, err)
+	require.Equal(t, gateway.BlockHeights{1337: 5, 1: 0}, heights)
+
+	_, err = svc.GetReadQueryHeights(context.Background(), "insert into foo_1337_1 values (1)")
+	require.Error(t, err)
+}
+
+func TestGetTableStats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	ex, err := executor.NewExecutor(chainID, db, parser, 0, tablelandimpl.NewACL(db))
+	require.NoError(t, err)
+	bs, err := ex.NewBlockScope(ctx, 1)
+	require.NoError(t, err)
+	res, err := bs.ExecuteTxnEvents(ctx, eventfeed.TxnEvents{
+		TxnHash: common.HexToHash("0x1"),
+		Events: []interface{}{
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(1),
+				Owner:     common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+				Statement: "create table foo_1337 (id int primary key, name text, unique(name, id))",
+			},
+			&ethereum.ContractRunSQL{
+				Caller:    common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+				IsOwner:   true,
+				TableId:   big.NewInt(1),
+				Statement: "insert into foo_1337_1 values (1, 'a'), (2, 'b'), (3, 'c')",
+				Policy: ethereum.ITablelandControllerPolicy{
+					AllowInsert: true,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	tableID, _ := tables.NewTableID("1")
+	errMsg := "column not found"
+	errorEventIdx := 0
+	require.NoError(t, bs.SaveTxnReceipts(ctx, []eventprocessor.Receipt{
+		{ChainID: chainID, BlockNumber: 1, TxnHash: "0x1", TableIDs: tables.TableIDs{tableID}},
+		// Failed transactions don't modify the table.
+		{
+			ChainID:       chainID,
+			BlockNumber:   2,
+			TxnHash:       "0x2",
+			TableIDs:      tables.TableIDs{tableID},
+			Error:         &errMsg,
+			ErrorEventIdx: &errorEventIdx,
+		},
+	}))
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	resolver := parsing.NewReadStatementResolver(sharedmemory.NewSharedMemory())
+	svc, err := gateway.NewGateway(
+		parser, NewGatewayStore(db), resolver, "https://tableland.network", "", "", gateway.WithMaxTableRowCount(100),
+	)
+	require.NoError(t, err)
+
+	stats, err := svc.GetTableStats(ctx, chainID, tableID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), stats.RowCount)
+	require.Equal(t, 100, stats.MaxRowCount)
+	require.Equal(t, 2, stats.ColumnCount)
+	require.Len(t, stats.Indexes, 2)
+	for _, index := range stats.Indexes {
+		require.True(t, index.Unique)
+	}
+	require.Equal(t, []string{"name", "id"}, stats.Indexes[1].Columns)
+	require.Equal(t, int64(1), stats.LastModifiedBlock)
+	require.Equal(t, "0x1", stats.LastModifiedTxnHash)
+	require.False(t, stats.CreatedAt.IsZero())
+	if stats.Bytes != nil {
+		require.Greater(t, *stats.Bytes, int64(0))
+	}
+
+	notFoundID, _ := tables.NewTableID("2")
+	_, err = svc.GetTableStats(ctx, chainID, notFoundID)
+	require.ErrorIs(t, err, gateway.ErrTableNotFound)
+
+	// Stats are cached until the last processed height changes, and images use their row count.
+	_, err = db.DB.ExecContext(ctx, "insert into foo_1337_1 values (4, 'd')")
+	require.NoError(t, err)
+	stats, err = svc.GetTableStats(ctx, chainID, tableID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), stats.RowCount)
+	img, err := svc.GetTableImage(ctx, chainID, tableID)
+	require.NoError(t, err)
+	require.Contains(t, string(img), ">3 rows</text>")
+
+	bs, err = ex.NewBlockScope(ctx, 3)
+	require.NoError(t, err)
+	require.NoError(t, bs.SetLastProcessedHeight(ctx, 3))
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+	stats, err = svc.GetTableStats(ctx, chainID, tableID)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), stats.RowCount)
+	img, err = svc.GetTableImage(ctx, chainID, tableID)
+	require.NoError(t, err)
+	require.Contains(t, string(img), ">4 rows</text>")
+}
+
+func TestStreamReadQuery(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	ex, err := executor.NewExecutor(chainID, db, parser, 0, tablelandimpl.NewACL(db))
+	require.NoError(t, err)
+	bs, err := ex.NewBlockScope(context.Background(), 1)
+	require.NoError(t, err)
+	res, err := bs.ExecuteTxnEvents(context.Background(), eventfeed.TxnEvents{
+		TxnHash: common.HexToHash("0x1"),
+		Events: []interface{}{
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(1),
+				Owner:     common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+				Statement: "create table foo_1337 (bar int)",
+			},
+			&ethereum.ContractRunSQL{
+				Caller:    common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+				IsOwner:   true,
+				TableId:   big.NewInt(1),
+				Statement: "insert into foo_1337_1 values (1), (2), (3)",
+				Policy: ethereum.ITablelandControllerPolicy{
+					AllowInsert: true,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	resolver := parsing.NewReadStatementResolver(sharedmemory.NewSharedMemory())
+	svc, err := gateway.NewGateway(parser, NewGatewayStore(db), resolver, "https://tableland.network", "", "")
+	require.NoError(t, err)
+
+	stmt := "select bar from foo_1337_1 order by bar"
+
+	buf := bytes.NewBuffer([]byte{})
+	w := formatter.NewStreamWriter(buf, formatter.WithOutput(formatter.NDJSON))
+	require.NoError(t, svc.StreamReadQuery(context.Background(), stmt, nil, w))
+	require.NoError(t, w.Close())
+	require.Equal(t, "{\"bar\":1}\n{\"bar\":2}\n{\"bar\":3}\n", buf.String())
+
+	// Errors of the writer stop the query and are returned.
+	w = formatter.NewStreamWriter(bytes.NewBuffer([]byte{}), formatter.WithMaxRowCount(2))
+	err = svc.StreamReadQuery(context.Background(), stmt, nil, w)
+	var errRowCount *formatter.ErrMaxRowCountExceeded
+	require.ErrorAs(t, err, &errRowCount)
+	require.Equal(t, 2, errRowCount.MaxAllowed)
+}
+
+func TestStreamReadQueryAt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	ex, err := executor.NewExecutor(
+		chainID, db, parser, 0, tablelandimpl.NewACL(db), executor.WithChangeLogRetention(4),
+	)
+	require.NoError(t, err)
+	executeBlock := func(height int64, events ...interface{}) {
+		bs, err := ex.NewBlockScope(ctx, height)
+		require.NoError(t, err)
+		txnHash := common.HexToHash(fmt.Sprintf("0x%d", height))
+		res, err := bs.ExecuteTxnEvents(ctx, eventfeed.TxnEvents{TxnHash: txnHash, Events: events})
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+		require.NoError(t, bs.SaveTxnReceipts(ctx, []eventprocessor.Receipt{
+			{ChainID: chainID, BlockNumber: height, TxnHash: txnHash.Hex(), TableIDs: res.TableIDs},
+		}))
+		require.NoError(t, bs.SetLastProcessedHeight(ctx, height))
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+	}
+	runSQL := func(statement string) *ethereum.ContractRunSQL {
+		tableID := int64(1)
+		if strings.Contains(statement, "qux_1337_2") {
+			tableID = 2
+		}
+		return &ethereum.ContractRunSQL{
+			Caller:    common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+			IsOwner:   true,
+			TableId:   big.NewInt(tableID),
+			Statement: statement,
+			Policy: ethereum.ITablelandControllerPolicy{
+				AllowInsert: true,
+				AllowUpdate: true,
+				AllowDelete: true,
+			},
+		}
+	}
+	executeBlock(1,
+		&ethereum.ContractCreateTable{
+			TableId:   big.NewInt(1),
+			Owner:     common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+			Statement: "create table foo_1337 (id integer primary key, bar text)",
+		},
+		runSQL("insert into foo_1337_1 values (1, 'a'), (2, 'b')"),
+	)
+	executeBlock(2, runSQL("update foo_1337_1 set bar='c' where id=1"))
+	executeBlock(3,
+		runSQL("delete from foo_1337_1 where id=2"),
+		runSQL("insert into foo_1337_1 values (3, 'd')"),
+		&ethereum.ContractCreateTable{
+			TableId:   big.NewInt(2),
+			Owner:     common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+			Statement: "create table qux_1337 (id integer)",
+		},
+	)
+	executeBlock(4, runSQL("update foo_1337_1 set id=4 where id=3"))
+	executeBlock(5,
+		runSQL("alter table foo_1337_1 add column baz integer"),
+		runSQL("alter table foo_1337_1 rename column bar to qux"),
+		runSQL("update foo_1337_1 set qux='e', baz=7 where id=1"),
+	)
+	executeBlock(6, runSQL("insert into qux_1337_2 values (1)"))
+	executeBlock(7, runSQL("insert into qux_1337_2 values (2)"))
+
+	resolver := parsing.NewReadStatementResolver(sharedmemory.NewSharedMemory())
+	svc, err := gateway.NewGateway(parser, NewGatewayStore(db), resolver, "https://tableland.network", "", "")
+	require.NoError(t, err)
+
+	readTableAt := func(table string, block int64) (string, error) {
+		buf := bytes.NewBuffer([]byte{})
+		w := formatter.NewStreamWriter(buf, formatter.WithOutput(formatter.NDJSON))
+		if err := svc.StreamReadQueryAt(ctx, "select * from "+table+" order by id", nil, block, w); err != nil {
+			return "", err
+		}
+		require.NoError(t, w.Close())
+		return buf.String(), nil
+	}
+	readAt := func(block int64) (string, error) {
+		return readTableAt("foo_1337_1", block)
+	}
+
+	// Blocks before the ALTER statements read the columns the table had then.
+	for block, exp := range map[int64]string{
+		3: "{\"bar\":\"c\",\"id\":1}\n{\"bar\":\"d\",\"id\":3}\n",
+		4: "{\"bar\":\"c\",\"id\":1}\n{\"bar\":\"d\",\"id\":4}\n",
+		5: "{\"baz\":7,\"id\":1,\"qux\":\"e\"}\n{\"baz\":null,\"id\":4,\"qux\":\"d\"}\n",
+	} {
+		res, err := readAt(block)
+		require.NoError(t, err)
+		require.Equal(t, exp, res, "block %d", block)
+	}
+	res, err := readTableAt("qux_1337_2", 6)
+	require.NoError(t, err)
+	require.Equal(t, "{\"id\":1}\n", res)
+	res, err = readTableAt("qux_1337_2", 3)
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	// Block 2 is out of the retention, and block 8 wasn't processed yet.
+	_, err = readAt(2)
+	require.ErrorIs(t, err, gateway.ErrHistoryUnavailable)
+	_, err = readAt(8)
+	require.ErrorIs(t, err, gateway.ErrHistoryUnavailable)
+
+	// The table didn't exist before block 3, even if the change log doesn't reach it.
+	_, err = readTableAt("qux_1337_2", 2)
+	require.ErrorIs(t, err, gateway.ErrTableNotFound)
+	_, err = readTableAt("qux_1337_2", 1)
+	require.ErrorIs(t, err, gateway.ErrTableNotFound)
+
+	// Shadow tables don't outlive the read.
+	data, err := svc.RunReadQuery(ctx, "select count(*) from foo_1337_1", nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), data.Rows[0][0].Value())
+	var shadows int
+	require.NoError(t, db.DB.QueryRowContext(ctx, "select count(*) from sqlite_temp_master").Scan(&shadows))
+	require.Zero(t, shadows)
+}
+
+func TestReadQueryLimits(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	values := make([]string, 100)
+	for i := range values {
+		values[i] = fmt.Sprintf("(%d)", i)
+	}
+	ex, err := executor.NewExecutor(chainID, db, parser, 0, tablelandimpl.NewACL(db))
+	require.NoError(t, err)
+	bs, err := ex.NewBlockScope(context.Background(), 1)
+	require.NoError(t, err)
+	res, err := bs.ExecuteTxnEvents(context.Background(), eventfeed.TxnEvents{
+		TxnHash: common.HexToHash("0x1"),
+		Events: []interface{}{
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(1),
+				Owner:     common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+				Statement: "create table foo_1337 (bar int)",
+			},
+			&ethereum.ContractRunSQL{
+				Caller:    common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+				IsOwner:   true,
+				TableId:   big.NewInt(1),
+				Statement: "insert into foo_1337_1 values " + strings.Join(values, ","),
+				Policy: ethereum.ITablelandControllerPolicy{
+					AllowInsert: true,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	newGateway := func(opts ...GatewayStoreOption) gateway.Gateway {
+		resolver := parsing.NewReadStatementResolver(sharedmemory.NewSharedMemory())
+		svc, err := gateway.NewGateway(parser, NewGatewayStore(db, opts...), resolver, "https://tableland.network", "", "")
+		require.NoError(t, err)
+		return svc
+	}
+
+	// A cartesian join of 100^4 rows.
+	expensive := "select count(*) from foo_1337_1 a, foo_1337_1 b, foo_1337_1 c, foo_1337_1 d"
+	cheap := "select count(*) from foo_1337_1"
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newGateway(WithReadQueryTimeout(50 * time.Millisecond))
+		_, err := svc.RunReadQuery(context.Background(), expensive, nil)
+		var errTimeout *gateway.ErrReadQueryTimeout
+		require.ErrorAs(t, err, &errTimeout)
+		require.Equal(t, 50*time.Millisecond, errTimeout.Timeout)
+
+		data, err := svc.RunReadQuery(context.Background(), cheap, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(100), data.Rows[0][0].Value())
+
+		// Interrupted queries fail the whole batch, and so do streamed and paged reads.
+		batch := []gateway.ReadQuery{{Statement: cheap}, {Statement: expensive}}
+		err = svc.RunReadQueryBatch(context.Background(), batch, newBatchRecorder(0))
+		require.ErrorAs(t, err, &errTimeout)
+		err = svc.StreamReadQuery(context.Background(), expensive, nil, formatter.NewStreamWriter(io.Discard))
+		require.ErrorAs(t, err, &errTimeout)
+		_, _, err = svc.RunReadQueryPage(context.Background(), expensive, nil, "", 10)
+		require.ErrorAs(t, err, &errTimeout)
+	})
+
+	t.Run("budget", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newGateway(WithReadQueryBudget(100000))
+		err := svc.StreamReadQuery(context.Background(), expensive, nil, formatter.NewStreamWriter(io.Discard))
+		var errBudget *gateway.ErrReadQueryBudgetExceeded
+		require.ErrorAs(t, err, &errBudget)
+		require.Equal(t, 100000, errBudget.MaxInstructions)
+
+		_, _, err = svc.RunReadQueryPage(context.Background(), expensive, nil, "", 10)
+		require.ErrorAs(t, err, &errBudget)
+
+		// Interrupted queries fail the whole batch.
+		batch := []gateway.ReadQuery{{Statement: cheap}, {Statement: expensive}}
+		err = svc.RunReadQueryBatch(context.Background(), batch, newBatchRecorder(0))
+		require.ErrorAs(t, err, &errBudget)
+
+		data, err := svc.RunReadQuery(context.Background(), cheap, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(100), data.Rows[0][0].Value())
+
+		// The budget is removed once the reads are done, so it doesn't limit the next users of the connections.
+		data, err = newGateway().RunReadQuery(
+			context.Background(), "select count(*) from foo_1337_1 a, foo_1337_1 b, foo_1337_1 c", nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(1000000), data.Rows[0][0].Value())
+	})
+}
+
+func TestRunReadQueryBatch(t *testing.T) {
+	t.Parallel()
+
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	ex, err := executor.NewExecutor(chainID, db, parser, 0, tablelandimpl.NewACL(db))
+	require.NoError(t, err)
+	bs, err := ex.NewBlockScope(context.Background(), 1)
+	require.NoError(t, err)
+	res, err := bs.ExecuteTxnEvents(context.Background(), eventfeed.TxnEvents{
+		TxnHash: common.HexToHash("0x1"),
+		Events: []interface{}{
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(1),
+				Owner:     common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+				Statement: "create table foo_1337 (bar int)",
+			},
+			&ethereum.ContractRunSQL{
+				Caller:    common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+				IsOwner:   true,
+				TableId:   big.NewInt(1),
+				Statement: "insert into foo_1337_1 values (1), (2), (3)",
+				Policy: ethereum.ITablelandControllerPolicy{
+					AllowInsert: true,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	resolver := parsing.NewReadStatementResolver(sharedmemory.NewSharedMemory())
+	svc, err := gateway.NewGateway(parser, NewGatewayStore(db), resolver, "https://tableland.network", "", "")
+	require.NoError(t, err)
+
+	w := newBatchRecorder(0)
+	err = svc.RunReadQueryBatch(context.Background(), []gateway.ReadQuery{
+		{Statement: "select bar from foo_1337_1 order by bar"},
+		{Statement: "select count(*) from foo_1337_1 where bar > ?", Params: []any{int64(1)}},
+		{Statement: "insert into foo_1337_1 values (4)"},
+		{Statement: "select * from foo_1337_2"},
+		{Statement: "select bar from foo_1337_1 where bar = ?"},
+	}, w)
+	require.NoError(t, err)
+	require.Len(t, w.errs, 5)
+
+	require.NoError(t, w.errs[0])
+	require.Len(t, w.data[0].Rows, 3)
+	require.Equal(t, int64(1), w.data[0].Rows[0][0].Value())
+	require.Equal(t, int64(3), w.data[0].Rows[2][0].Value())
+
+	require.NoError(t, w.errs[1])
+	require.Equal(t, int64(2), w.data[1].Rows[0][0].Value())
+
+	// Failing queries don't fail the others, and invalid queries never begin.
+	require.Error(t, w.errs[2])
+	require.NotContains(t, w.data, 2)
+	require.Error(t, w.errs[3])
+	require.Error(t, w.errs[4])
+
+	// Errors of the RowWriter stop reading the query.
+	w = newBatchRecorder(2)
+	err = svc.RunReadQueryBatch(context.Background(), []gateway.ReadQuery{
+		{Statement: "select bar from foo_1337_1 order by bar"},
+		{Statement: "select count(*) from foo_1337_1"},
+	}, w)
+	require.NoError(t, err)
+	require.ErrorIs(t, w.errs[0], errTooManyRows)
+	require.Len(t, w.data[0].Rows, 2)
+	require.NoError(t, w.errs[1])
+	require.Equal(t, int64(3), w.data[1].Rows[0][0].Value())
+
+	tooMany := make([]gateway.ReadQuery, gateway.MaxReadQueryBatchSize+1)
+	for i := range tooMany {
+		tooMany[i].Statement = "select bar from foo_1337_1"
+	}
+	err = svc.RunReadQueryBatch(context.Background(), tooMany, newBatchRecorder(0))
+	require.Error(t, err)
+}
+
+var errTooManyRows = errors.New("too many rows")
+
+// batchRecorder records the results of the queries of a batch, failing the queries with more than maxRows rows.
+type batchRecorder struct {
+	maxRows int
+	data    map[int]*gateway.TableData
+	errs    map[int]error
+}
+
+func newBatchRecorder(maxRows int) *batchRecorder {
+	return &batchRecorder{
+		maxRows: maxRows,
+		data:    map[int]*gateway.TableData{},
+		errs:    map[int]error{},
+	}
+}
+
+func (r *batchRecorder) Begin(i int) gateway.RowWriter {
+	r.data[i] = &gateway.TableData{}
+	return &tableDataWriter{data: r.data[i], maxRows: r.maxRows}
+}
+
+func (r *batchRecorder) End(i int, err error) {
+	r.errs[i] = err
+}
+
+type tableDataWriter struct {
+	data    *gateway.TableData
+	maxRows int
+}
+
+func (w *tableDataWriter) WriteColumns(columns []gateway.Column) error {
+	w.data.Columns = columns
+	return nil
+}
+
+func (w *tableDataWriter) WriteRow(row []*gateway.ColumnValue) error {
+	if w.maxRows > 0 && len(w.data.Rows) >= w.maxRows {
+		return errTooManyRows
+	}
+	w.data.Rows = append(w.data.Rows, row)
+	return nil
+}
+
 func TestQueryConstraints(t *testing.T) {
 	t.Parallel()
 
@@ -294,7 +1421,7 @@ func TestQueryConstraints(t *testing.T) {
 		require.NoError(t, err)
 
 		_, err = gateway.RunReadQuery(
-			context.Background(), "SELECT * FROM foo_1337_1 WHERE bar = 'hello2'", []string{},
+			context.Background(), "SELECT * FROM foo_1337_1 WHERE bar = 'hello2'", []any{},
 		) // length of 45 bytes
 		require.Error(t, err)
 		require.ErrorContains(t, err, "read query size is too long")
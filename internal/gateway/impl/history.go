@@ -0,0 +1,296 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/tablelandnetwork/sqlparser"
+	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/parsing"
+)
+
+// ReadStreamAt executes a parsed read statement against the state the queried tables had at the end of the
+// provided block, and writes the rows to w as they are read. Past states are rebuilt from the row changes
+// recorded by the executor, in temporary tables that shadow the queried tables in the read connection.
+// gateway.ErrHistoryUnavailable is returned if the block wasn't processed yet, or a queried table changed after
+// it and the change log doesn't reach it. gateway.ErrTableNotFound is returned if a queried table was created
+// after the block.
+func (s *GatewayStore) ReadStreamAt(
+	ctx context.Context,
+	stmt parsing.ReadStmt,
+	resolver sqlparser.ReadStatementResolver,
+	block int64,
+	w gateway.RowWriter,
+) error {
+	tbls := stmt.GetTables()
+	if len(tbls) == 0 {
+		return s.ReadStream(ctx, stmt, resolver, w)
+	}
+	chainID := tableland.ChainID(tbls[0].ChainID())
+	for _, tbl := range tbls[1:] {
+		if tableland.ChainID(tbl.ChainID()) != chainID {
+			return fmt.Errorf("reading at a block is only supported for tables of the same chain")
+		}
+	}
+
+	return s.withReadConn(ctx, func(ctx context.Context, conn *sql.Conn) error {
+		return s.readStreamAt(ctx, conn, stmt, resolver, chainID, block, w)
+	})
+}
+
+func (s *GatewayStore) readStreamAt(
+	ctx context.Context,
+	conn *sql.Conn,
+	stmt parsing.ReadStmt,
+	resolver sqlparser.ReadStatementResolver,
+	chainID tableland.ChainID,
+	block int64,
+	w gateway.RowWriter,
+) (err error) {
+	// Shadow tables are created in the read transaction, so rolling it back drops them. The transaction can't be
+	// read-only since it writes the temp schema of the connection, but it never writes the main database.
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("opening read transaction: %s", err)
+	}
+	defer func() {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			// The shadow tables might be left behind, so the connection can't be reused.
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+			err = fmt.Errorf("rollback read transaction: %s", rbErr)
+		}
+	}()
+
+	var height int64
+	if err := tx.QueryRowContext(
+		ctx, "SELECT block_number FROM system_txn_processor WHERE chain_id=?1", chainID,
+	).Scan(&height); err != nil {
+		if err == sql.ErrNoRows {
+			return gateway.ErrHistoryUnavailable
+		}
+		return fmt.Errorf("get last processed height: %s", err)
+	}
+	if block > height {
+		return gateway.ErrHistoryUnavailable
+	}
+	heights := gateway.BlockHeights{chainID: block}
+	if err := s.pinTables(ctx, tx, stmt.GetTables(), heights); err != nil {
+		return err
+	}
+
+	query, err := stmt.GetQuery(&pinnedResolver{ReadStatementResolver: resolver, heights: heights})
+	if err != nil {
+		return fmt.Errorf("get query: %s", err)
+	}
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("executing query: %s", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			s.db.Log.Warn().Err(err).Msg("closing rows")
+		}
+	}()
+	return rowsToWriter(rows, w)
+}
+
+// pinTables makes the queried tables read as they were at the end of the provided heights of their chains. Tables
+// that changed after the height of their chain are shadowed by their rebuilt past state, and
+// gateway.ErrHistoryUnavailable is returned if the change log doesn't reach the height. Changes are looked up in
+// the indexes of the row changes and the receipt tables, so unchanged tables cost a lookup.
+// gateway.ErrTableNotFound is returned if a table was created after the height of its chain.
+func (s *GatewayStore) pinTables(
+	ctx context.Context, tx *sql.Tx, tbls []*sqlparser.ValidatedTable, heights gateway.BlockHeights,
+) error {
+	type chainState struct {
+		height     int64
+		sinceBlock int64
+		hasLog     bool
+	}
+	chains := map[tableland.ChainID]chainState{}
+	for _, tbl := range tbls {
+		chainID := tableland.ChainID(tbl.ChainID())
+		block := heights[chainID]
+
+		state, ok := chains[chainID]
+		if !ok {
+			err := tx.QueryRowContext(
+				ctx, "SELECT block_number FROM system_txn_processor WHERE chain_id=?1", chainID,
+			).Scan(&state.height)
+			if err != nil && err != sql.ErrNoRows {
+				return fmt.Errorf("get last processed height: %s", err)
+			}
+			err = tx.QueryRowContext(
+				ctx, "SELECT since_block FROM system_row_changes_retention WHERE chain_id=?1", chainID,
+			).Scan(&state.sinceBlock)
+			if err != nil && err != sql.ErrNoRows {
+				return fmt.Errorf("get change log retention: %s", err)
+			}
+			state.hasLog = err == nil
+			chains[chainID] = state
+		}
+		if block > state.height {
+			return gateway.ErrHistoryUnavailable
+		}
+		if block == state.height {
+			continue
+		}
+
+		// Table creations are kept in the change log after the changes are pruned.
+		var created bool
+		if err := tx.QueryRowContext(ctx,
+			`SELECT EXISTS(
+				SELECT 1 FROM system_row_changes
+				WHERE chain_id=?1 AND table_id=?2 AND block_number>?3 AND schema=''
+			)`,
+			chainID, tbl.TokenID(), block,
+		).Scan(&created); err != nil {
+			return fmt.Errorf("checking table creation: %s", err)
+		}
+		if created {
+			return gateway.ErrTableNotFound
+		}
+
+		if !state.hasLog || block < state.sinceBlock {
+			// Receipts are only indexed for the tables they touch, so a table without receipts after the
+			// height is unchanged even if its changes aren't in the log.
+			var changed bool
+			if err := tx.QueryRowContext(ctx,
+				`SELECT EXISTS(
+					SELECT 1 FROM system_txn_receipt_tables WHERE chain_id=?1 AND table_id=?2 AND block_number>?3
+				)`,
+				chainID, tbl.TokenID(), block,
+			).Scan(&changed); err != nil {
+				return fmt.Errorf("checking table receipts: %s", err)
+			}
+			if changed {
+				return gateway.ErrHistoryUnavailable
+			}
+			continue
+		}
+
+		var changed bool
+		if err := tx.QueryRowContext(ctx,
+			`SELECT EXISTS(
+				SELECT 1 FROM system_row_changes WHERE chain_id=?1 AND table_id=?2 AND block_number>?3
+			)`,
+			chainID, tbl.TokenID(), block,
+		).Scan(&changed); err != nil {
+			return fmt.Errorf("checking row changes: %s", err)
+		}
+		if !changed {
+			continue
+		}
+		if err := s.shadowTableAt(ctx, tx, chainID, tbl.TokenID(), tbl.Name(), block); err != nil {
+			return fmt.Errorf("rebuilding table %s: %s", tbl.Name(), err)
+		}
+	}
+	return nil
+}
+
+// shadowTableAt creates a temporary table with the name of a table, so it takes precedence in queries, holding
+// the rows the table had at the end of the provided block. The current rows are copied and the recorded row
+// changes after the block are undone, from the latest to the oldest. Undoing a schema change recreates the
+// shadow table with the columns the table had before it, and the rows recorded with it are inserted back next.
+func (s *GatewayStore) shadowTableAt(
+	ctx context.Context, tx *sql.Tx, chainID tableland.ChainID, tableID int64, name string, block int64,
+) error {
+	rows, err := tx.QueryContext(ctx, "SELECT name, type FROM pragma_table_info(?1, 'main')", name)
+	if err != nil {
+		return fmt.Errorf("getting table columns: %s", err)
+	}
+	var columns, definitions []string
+	for rows.Next() {
+		var column, typ string
+		if err := rows.Scan(&column, &typ); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("scan column: %s", err)
+		}
+		columns = append(columns, parsing.QuoteIdentifier(column))
+		definitions = append(definitions, strings.TrimSpace(parsing.QuoteIdentifier(column)+" "+typ))
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("closing rows: %s", err)
+	}
+
+	table := parsing.QuoteIdentifier(name)
+	columnList := strings.Join(columns, ",")
+	if _, err := tx.ExecContext(ctx,
+		fmt.Sprintf("CREATE TEMP TABLE %s (%s)", table, strings.Join(definitions, ",")),
+	); err != nil {
+		return fmt.Errorf("creating shadow table: %s", err)
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+		"INSERT INTO temp.%s (rowid,%s) SELECT rowid,%s FROM main.%s", table, columnList, columnList, table),
+	); err != nil {
+		return fmt.Errorf("copying rows: %s", err)
+	}
+
+	changes, err := tx.QueryContext(ctx,
+		`SELECT columns, before_row_id, before, after_row_id, schema
+			FROM system_row_changes
+			WHERE chain_id=?1 AND table_id=?2 AND block_number>?3
+			ORDER BY id DESC`,
+		chainID, tableID, block)
+	if err != nil {
+		return fmt.Errorf("getting row changes: %s", err)
+	}
+	type rowChange struct {
+		columns     string
+		beforeRowID sql.NullInt64
+		before      sql.NullString
+		afterRowID  sql.NullInt64
+		schema      sql.NullString
+	}
+	var undo []rowChange
+	for changes.Next() {
+		var c rowChange
+		if err := changes.Scan(&c.columns, &c.beforeRowID, &c.before, &c.afterRowID, &c.schema); err != nil {
+			_ = changes.Close()
+			return fmt.Errorf("scan row change: %s", err)
+		}
+		undo = append(undo, c)
+	}
+	if err := changes.Close(); err != nil {
+		return fmt.Errorf("closing rows: %s", err)
+	}
+
+	for _, c := range undo {
+		if c.schema.Valid {
+			if c.schema.String == "" {
+				return fmt.Errorf("table didn't exist at block %d", block)
+			}
+			if _, err := tx.ExecContext(ctx, "DROP TABLE temp."+table); err != nil {
+				return fmt.Errorf("undoing schema change: %s", err)
+			}
+			if _, err := tx.ExecContext(ctx,
+				fmt.Sprintf("CREATE TEMP TABLE %s (%s)", table, c.schema.String),
+			); err != nil {
+				return fmt.Errorf("undoing schema change: %s", err)
+			}
+			continue
+		}
+		if c.afterRowID.Valid {
+			if _, err := tx.ExecContext(ctx,
+				fmt.Sprintf("DELETE FROM temp.%s WHERE rowid=?1", table), c.afterRowID.Int64,
+			); err != nil {
+				return fmt.Errorf("undoing row change: %s", err)
+			}
+		}
+		if c.beforeRowID.Valid {
+			// The image was built by the executor with quote(), so it's a list of SQL literals.
+			if _, err := tx.ExecContext(ctx,
+				fmt.Sprintf("INSERT INTO temp.%s (rowid,%s) VALUES (?1,%s)", table, c.columns, c.before.String),
+				c.beforeRowID.Int64,
+			); err != nil {
+				return fmt.Errorf("undoing row change: %s", err)
+			}
+		}
+	}
+
+	return nil
+}
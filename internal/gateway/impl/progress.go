@@ -0,0 +1,110 @@
+package impl
+
+/*
+#include <stdlib.h>
+
+typedef struct sqlite3 sqlite3;
+typedef struct sqlite3_context sqlite3_context;
+typedef struct sqlite3_value sqlite3_value;
+typedef struct sqlite3_api_routines sqlite3_api_routines;
+
+#define SQLITE_OK 0
+#define SQLITE_NOMEM 7
+#define SQLITE_UTF8 1
+#define SQLITE_DIRECTONLY 0x000080000
+
+int sqlite3_auto_extension(void(*)(void));
+void sqlite3_progress_handler(sqlite3*, int, int(*)(void*), void*);
+int sqlite3_create_function_v2(sqlite3*, const char*, int, int, void*,
+	void(*)(sqlite3_context*, int, sqlite3_value**), void(*)(sqlite3_context*, int, sqlite3_value**),
+	void(*)(sqlite3_context*), void(*)(void*));
+void *sqlite3_user_data(sqlite3_context*);
+sqlite3 *sqlite3_context_db_handle(sqlite3_context*);
+long long sqlite3_value_int64(sqlite3_value*);
+void sqlite3_result_int(sqlite3_context*, int);
+
+// interruptProgress is called by SQLite once the budget of a statement is spent.
+// It records that the budget was exceeded and interrupts the statement.
+static int interruptProgress(void *exceeded) {
+	*(int*)exceeded = 1;
+	return 1;
+}
+
+// readBudget implements tableland_read_budget(instructions). A positive number of instructions installs a
+// progress handler that interrupts every statement of the connection after them, and zero removes it.
+// It returns whether a statement was interrupted since the handler was installed.
+static void readBudget(sqlite3_context *ctx, int argc, sqlite3_value **argv) {
+	int *exceeded = sqlite3_user_data(ctx);
+	sqlite3 *db = sqlite3_context_db_handle(ctx);
+	long long instructions = sqlite3_value_int64(argv[0]);
+
+	sqlite3_result_int(ctx, *exceeded);
+	if (instructions <= 0) {
+		sqlite3_progress_handler(db, 0, 0, 0);
+		return;
+	}
+	*exceeded = 0;
+	sqlite3_progress_handler(db, instructions > 0x7fffffff ? 0x7fffffff : (int)instructions,
+		interruptProgress, exceeded);
+}
+
+// registerReadBudget is an auto extension that defines tableland_read_budget in every new connection.
+// The function can't be used from triggers or views, and read queries can't call it since it isn't
+// an allowed function.
+static int registerReadBudget(sqlite3 *db, char **errmsg, const sqlite3_api_routines *api) {
+	int *exceeded = calloc(1, sizeof(int));
+	if (!exceeded) {
+		return SQLITE_NOMEM;
+	}
+	return sqlite3_create_function_v2(db, "tableland_read_budget", 1, SQLITE_UTF8 | SQLITE_DIRECTONLY,
+		exceeded, readBudget, 0, 0, free);
+}
+
+static int autoRegisterReadBudget() {
+	return sqlite3_auto_extension((void(*)(void))registerReadBudget);
+}
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// The read budget is installed in the connections with SQLite's auto extension mechanism, since the driver
+// doesn't expose the progress handler. Only connections opened after the registration have it, so it's
+// registered before any database is opened.
+func init() {
+	if rc := C.autoRegisterReadBudget(); rc != C.SQLITE_OK {
+		panic(fmt.Sprintf("registering read budget sqlite extension: error code %d", rc))
+	}
+}
+
+// progressBudget interrupts the statements of a connection once they execute a number of
+// SQLite virtual machine instructions, using SQLite's progress handler.
+type progressBudget struct {
+	conn *sql.Conn
+}
+
+// setProgressBudget installs a progress handler that interrupts every statement executed in conn after
+// the provided number of instructions. The budget must be cleared before the connection is released.
+func setProgressBudget(ctx context.Context, conn *sql.Conn, instructions int) (*progressBudget, error) {
+	if _, err := conn.ExecContext(ctx, "SELECT tableland_read_budget(?1)", instructions); err != nil {
+		return nil, fmt.Errorf("installing progress handler: %s", err)
+	}
+	return &progressBudget{conn: conn}, nil
+}
+
+// clear removes the progress handler from the connection, and returns true if a statement was interrupted
+// because it exceeded the budget. If the handler can't be removed, the connection is discarded.
+func (b *progressBudget) clear() (bool, error) {
+	var exceeded bool
+	row := b.conn.QueryRowContext(context.Background(), "SELECT tableland_read_budget(0)")
+	if err := row.Scan(&exceeded); err != nil {
+		_ = b.conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		return false, fmt.Errorf("removing progress handler: %s", err)
+	}
+	return exceeded, nil
+}
@@ -2,7 +2,9 @@ package impl
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/textileio/go-tableland/internal/gateway"
 )
@@ -12,7 +14,7 @@ func rowsToTableData(rows *sql.Rows) (*gateway.TableData, error) {
 	if err != nil {
 		return nil, fmt.Errorf("get columns from rows: %s", err)
 	}
-	rowsData, err := getRowsData(rows, len(columns))
+	rowsData, err := getRowsData(rows, len(columns), -1)
 	if err != nil {
 		return nil, err
 	}
@@ -23,34 +25,136 @@ func rowsToTableData(rows *sql.Rows) (*gateway.TableData, error) {
 	}, nil
 }
 
+// rowsToTableDataPage is like rowsToTableData, but skips the first offset rows
+// and returns up to limit rows, and whether there are more rows.
+func rowsToTableDataPage(rows *sql.Rows, offset int64, limit int) (*gateway.TableData, bool, error) {
+	columns, err := getColumnsData(rows)
+	if err != nil {
+		return nil, false, fmt.Errorf("get columns from rows: %s", err)
+	}
+	for i := int64(0); i < offset; i++ {
+		if !rows.Next() {
+			break
+		}
+	}
+	rowsData, err := getRowsData(rows, len(columns), limit)
+	if err != nil {
+		return nil, false, err
+	}
+	more := rows.Next()
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("iterating rows: %s", err)
+	}
+
+	return &gateway.TableData{
+		Columns: columns,
+		Rows:    rowsData,
+	}, more, nil
+}
+
+// rowsToKeyedTableDataPage is like rowsToTableData, but returns up to limit rows without their last keyColumns
+// columns, which hold the key of each row. If there are more rows, the key of the last returned row is returned
+// too, so the next page can start after it.
+func rowsToKeyedTableDataPage(rows *sql.Rows, keyColumns int, limit int) (*gateway.TableData, []interface{}, error) {
+	columns, err := getColumnsData(rows)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get columns from rows: %s", err)
+	}
+	rowsData, err := getRowsData(rows, len(columns), limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	more := rows.Next()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("iterating rows: %s", err)
+	}
+
+	n := len(columns) - keyColumns
+	var key []interface{}
+	if more && len(rowsData) > 0 {
+		for _, v := range rowsData[len(rowsData)-1][n:] {
+			value := v.Value()
+			// Strings holding JSON are scanned as raw JSON.
+			if raw, ok := value.(json.RawMessage); ok {
+				value = string(raw)
+			}
+			key = append(key, value)
+		}
+	}
+	for i := range rowsData {
+		rowsData[i] = rowsData[i][:n]
+	}
+
+	return &gateway.TableData{
+		Columns: columns[:n],
+		Rows:    rowsData,
+	}, key, nil
+}
+
+// rowsToWriter writes the columns and then every row to w, one row at a time.
+func rowsToWriter(rows *sql.Rows, w gateway.RowWriter) error {
+	columns, err := getColumnsData(rows)
+	if err != nil {
+		return fmt.Errorf("get columns from rows: %s", err)
+	}
+	if err := w.WriteColumns(columns); err != nil {
+		return fmt.Errorf("writing columns: %w", err)
+	}
+	for rows.Next() {
+		vals, err := scanRow(rows, len(columns))
+		if err != nil {
+			return err
+		}
+		if err := w.WriteRow(vals); err != nil {
+			return fmt.Errorf("writing row: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating rows: %w", err)
+	}
+	return nil
+}
+
 func getColumnsData(rows *sql.Rows) ([]gateway.Column, error) {
-	cols, err := rows.Columns()
+	cols, err := rows.ColumnTypes()
 	if err != nil {
 		return nil, fmt.Errorf("get columns from sql.Rows: %s", err)
 	}
 	columns := make([]gateway.Column, len(cols))
 	for i := range cols {
-		columns[i] = gateway.Column{Name: cols[i]}
+		columns[i] = gateway.Column{Name: cols[i].Name(), Type: strings.ToUpper(cols[i].DatabaseTypeName())}
 	}
 	return columns, nil
 }
 
-func getRowsData(rows *sql.Rows, numColumns int) ([][]*gateway.ColumnValue, error) {
+// getRowsData reads up to limit rows. A negative limit reads all rows.
+func getRowsData(rows *sql.Rows, numColumns int, limit int) ([][]*gateway.ColumnValue, error) {
 	rowsData := make([][]*gateway.ColumnValue, 0)
-	for rows.Next() {
-		vals := make([]*gateway.ColumnValue, numColumns)
-		for i := range vals {
-			val := &gateway.ColumnValue{}
-			vals[i] = val
-		}
-		scanArgs := make([]interface{}, len(vals))
-		for i := range vals {
-			scanArgs[i] = vals[i]
-		}
-		if err := rows.Scan(scanArgs...); err != nil {
-			return nil, fmt.Errorf("scan row column: %s", err)
+	for (limit < 0 || len(rowsData) < limit) && rows.Next() {
+		vals, err := scanRow(rows, numColumns)
+		if err != nil {
+			return nil, err
 		}
 		rowsData = append(rowsData, vals)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating rows: %s", err)
+	}
 	return rowsData, nil
 }
+
+func scanRow(rows *sql.Rows, numColumns int) ([]*gateway.ColumnValue, error) {
+	vals := make([]*gateway.ColumnValue, numColumns)
+	for i := range vals {
+		val := &gateway.ColumnValue{}
+		vals[i] = val
+	}
+	scanArgs := make([]interface{}, len(vals))
+	for i := range vals {
+		scanArgs[i] = vals[i]
+	}
+	if err := rows.Scan(scanArgs...); err != nil {
+		return nil, fmt.Errorf("scan row column: %s", err)
+	}
+	return vals, nil
+}
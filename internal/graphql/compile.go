@@ -0,0 +1,553 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/pkg/parsing"
+)
+
+// table is a table exposed as a query field, named after the table.
+type table struct {
+	name    string
+	schema  gateway.TableSchema
+	columns map[string]gateway.ColumnSchema
+}
+
+// nestedLists are the response keys of the lists of nested objects of the objects selected by a field, with the
+// nested lists of their own objects.
+type nestedLists map[string]nestedLists
+
+// compiler compiles a root field of a query to a read query that returns a row for each selected object, with
+// the object as JSON. Values of the query are sent as parameters of the read query.
+type compiler struct {
+	ctx       context.Context
+	gateway   gateway.Gateway
+	variables map[string]Value
+	tables    map[string]*table
+
+	sql     strings.Builder
+	params  []any
+	aliases int
+}
+
+// compileRootField compiles a root field, and returns the nested lists of the selected objects. maxRows limits
+// the rows returned by the query and by each nested list, and zero means no limit.
+func (c *compiler) compileRootField(field *Field, maxRows int64) (string, []any, nestedLists, error) {
+	tbl, err := c.table(field.Name)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if tbl == nil {
+		return "", nil, nil, &ErrInvalidQuery{Msg: fmt.Sprintf("unknown field %s", field.Name)}
+	}
+	if field.Argument("on") != nil {
+		return "", nil, nil, &ErrInvalidQuery{
+			Msg: fmt.Sprintf("field %s can't be joined at the root", field.ResponseKey()),
+		}
+	}
+
+	c.sql.Reset()
+	c.params = nil
+	nested, err := c.writeRows(field, tbl, "", nil, maxRows)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return c.sql.String(), c.params, nested, nil
+}
+
+// table returns the schema of a table, or nil if the name isn't the name of an existing table.
+func (c *compiler) table(name string) (*table, error) {
+	if tbl, ok := c.tables[name]; ok {
+		return tbl, nil
+	}
+
+	schema, err := c.gateway.GetTableSchema(c.ctx, name)
+	if err == gateway.ErrTableNotFound {
+		c.tables[name] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get table schema: %s", err)
+	}
+
+	tbl := &table{name: name, schema: schema, columns: make(map[string]gateway.ColumnSchema, len(schema.Columns))}
+	for _, column := range schema.Columns {
+		tbl.columns[column.Name] = column
+	}
+	c.tables[name] = tbl
+
+	return tbl, nil
+}
+
+// writeRows writes a query that returns the objects selected by the field, from the rows of tbl that match the
+// arguments of the field, and returns their nested lists. If parentAlias is empty, the query returns a row for
+// each object. Otherwise, it returns the JSON array of the objects of the rows joined to the row of the parent
+// query by the provided columns.
+func (c *compiler) writeRows(
+	field *Field, tbl *table, parentAlias string, on Object, maxRows int64,
+) (nestedLists, error) {
+	if len(field.SelectionSet) == 0 {
+		return nil, &ErrInvalidQuery{
+			Msg: fmt.Sprintf("field %s must have a selection of subfields", field.ResponseKey()),
+		}
+	}
+
+	rowsAlias := c.alias("s")
+	tableAlias := c.alias("t")
+
+	// The objects are built in the outer query, from the columns of the inner query.
+	type selected struct {
+		key    string
+		field  *Field
+		column string
+		nested *table
+	}
+	var fields []selected
+	keys := map[string]struct{}{}
+	for _, f := range field.SelectionSet {
+		if _, ok := keys[f.ResponseKey()]; ok {
+			return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("field %s is selected more than once", f.ResponseKey())}
+		}
+		keys[f.ResponseKey()] = struct{}{}
+
+		if f.Name == "__typename" {
+			fields = append(fields, selected{key: f.ResponseKey(), field: f})
+			continue
+		}
+		if _, ok := tbl.columns[f.Name]; ok {
+			if len(f.Arguments) > 0 || len(f.SelectionSet) > 0 {
+				return nil, &ErrInvalidQuery{
+					Msg: fmt.Sprintf("column %s can't have arguments or subfields", f.ResponseKey()),
+				}
+			}
+			fields = append(fields, selected{key: f.ResponseKey(), field: f, column: c.alias("c")})
+			continue
+		}
+		nested, err := c.table(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		if nested == nil {
+			return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("unknown field %s of %s", f.Name, tbl.name)}
+		}
+		fields = append(fields, selected{key: f.ResponseKey(), field: f, column: c.alias("c"), nested: nested})
+	}
+
+	if parentAlias == "" {
+		c.sql.WriteString("SELECT json_object(")
+	} else {
+		c.sql.WriteString("SELECT json_group_array(json_object(")
+	}
+	for i, f := range fields {
+		if i > 0 {
+			c.sql.WriteString(", ")
+		}
+		switch {
+		case f.column == "":
+			fmt.Fprintf(&c.sql, "'%s', '%s'", f.key, tbl.name)
+		case f.nested != nil:
+			fmt.Fprintf(&c.sql, "'%s', json(%s.%s)", f.key, rowsAlias, f.column)
+		default:
+			fmt.Fprintf(&c.sql, "'%s', %s.%s", f.key, rowsAlias, f.column)
+		}
+	}
+	if parentAlias == "" {
+		c.sql.WriteString(") FROM (SELECT ")
+	} else {
+		c.sql.WriteString(")) FROM (SELECT ")
+	}
+
+	lists := nestedLists{}
+	var columns int
+	for _, f := range fields {
+		if f.column == "" {
+			continue
+		}
+		if columns > 0 {
+			c.sql.WriteString(", ")
+		}
+		columns++
+
+		if f.nested == nil {
+			column := tableAlias + "." + parsing.QuoteIdentifier(f.field.Name)
+			// JSON can't hold blobs, so they are returned as hex strings.
+			if strings.EqualFold(tbl.columns[f.field.Name].Type, "blob") {
+				column = "hex(" + column + ")"
+			}
+			fmt.Fprintf(&c.sql, "%s AS %s", column, f.column)
+			continue
+		}
+
+		joinOn, err := c.joinColumns(f.field, f.nested, tbl)
+		if err != nil {
+			return nil, err
+		}
+		c.sql.WriteString("(")
+		nested, err := c.writeRows(f.field, f.nested, tableAlias, joinOn, maxRows)
+		if err != nil {
+			return nil, err
+		}
+		lists[f.key] = nested
+		fmt.Fprintf(&c.sql, ") AS %s", f.column)
+	}
+	if columns == 0 {
+		c.sql.WriteString("1")
+	}
+	fmt.Fprintf(&c.sql, " FROM %s AS %s", tbl.name, tableAlias)
+
+	var conditions int
+	writeCondition := func() {
+		if conditions == 0 {
+			c.sql.WriteString(" WHERE ")
+		} else {
+			c.sql.WriteString(" AND ")
+		}
+		conditions++
+	}
+	for _, join := range on {
+		writeCondition()
+		fmt.Fprintf(&c.sql, "%s.%s = %s.%s", tableAlias, parsing.QuoteIdentifier(join.Name),
+			parentAlias, parsing.QuoteIdentifier(scalarValue(join.Value).(string)))
+	}
+	if where := field.Argument("where"); where != nil {
+		writeCondition()
+		if err := c.writeFilter(where, tbl, tableAlias); err != nil {
+			return nil, err
+		}
+	}
+
+	if orderBy := field.Argument("order_by"); orderBy != nil {
+		if err := c.writeOrderBy(orderBy, tbl, tableAlias); err != nil {
+			return nil, err
+		}
+	}
+
+	limit, err := c.intArgument(field, "limit")
+	if err != nil {
+		return nil, err
+	}
+	offset, err := c.intArgument(field, "offset")
+	if err != nil {
+		return nil, err
+	}
+	// One more row than allowed is read, so exceeding the limit can be detected.
+	if maxRows > 0 && (limit < 0 || limit > maxRows) {
+		limit = maxRows + 1
+	}
+	if limit >= 0 || offset >= 0 {
+		if limit < 0 {
+			limit = math.MaxInt64
+		}
+		fmt.Fprintf(&c.sql, " LIMIT %d", limit)
+	}
+	if offset >= 0 {
+		fmt.Fprintf(&c.sql, " OFFSET %d", offset)
+	}
+	fmt.Fprintf(&c.sql, ") AS %s", rowsAlias)
+
+	for _, arg := range field.Arguments {
+		switch arg.Name {
+		case "where", "order_by", "limit", "offset", "on":
+		default:
+			return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("unknown argument %s of %s", arg.Name, field.ResponseKey())}
+		}
+	}
+
+	return lists, nil
+}
+
+// joinColumns returns the on argument of a nested table, that maps columns of the nested table to columns
+// of the parent table.
+func (c *compiler) joinColumns(field *Field, nested *table, parent *table) (Object, error) {
+	arg := field.Argument("on")
+	if arg == nil {
+		return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("field %s must have an on argument", field.ResponseKey())}
+	}
+	value, err := c.resolve(arg)
+	if err != nil {
+		return nil, err
+	}
+	on, ok := value.(Object)
+	if !ok || len(on) == 0 {
+		return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("on argument of %s must be a non-empty object", field.ResponseKey())}
+	}
+	for _, join := range on {
+		if _, ok := nested.columns[join.Name]; !ok {
+			return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("unknown column %s of %s", join.Name, nested.name)}
+		}
+		parentColumn, ok := scalarValue(join.Value).(string)
+		if _, exists := parent.columns[parentColumn]; !ok || !exists {
+			return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("on argument of %s must map to columns of %s",
+				field.ResponseKey(), parent.name)}
+		}
+	}
+
+	return on, nil
+}
+
+// writeFilter writes the condition of a where argument. Conditions on columns are objects of operators, or a
+// value to compare with, and they can be combined with _and, _or and _not.
+func (c *compiler) writeFilter(v Value, tbl *table, alias string) error {
+	value, err := c.resolve(v)
+	if err != nil {
+		return err
+	}
+	filter, ok := value.(Object)
+	if !ok {
+		return &ErrInvalidQuery{Msg: "filters must be objects"}
+	}
+	if len(filter) == 0 {
+		c.sql.WriteString("1")
+		return nil
+	}
+
+	c.sql.WriteString("(")
+	for i, f := range filter {
+		if i > 0 {
+			c.sql.WriteString(" AND ")
+		}
+		switch f.Name {
+		case "_and", "_or":
+			filters, ok := f.Value.(List)
+			if !ok {
+				return &ErrInvalidQuery{Msg: fmt.Sprintf("%s must be a list of filters", f.Name)}
+			}
+			if len(filters) == 0 {
+				if f.Name == "_and" {
+					c.sql.WriteString("1")
+				} else {
+					c.sql.WriteString("0")
+				}
+				continue
+			}
+			operator := " AND "
+			if f.Name == "_or" {
+				operator = " OR "
+			}
+			c.sql.WriteString("(")
+			for j, filter := range filters {
+				if j > 0 {
+					c.sql.WriteString(operator)
+				}
+				if err := c.writeFilter(filter, tbl, alias); err != nil {
+					return err
+				}
+			}
+			c.sql.WriteString(")")
+		case "_not":
+			// The read query parser doesn't support NOT, but comparing with false keeps its semantics for nulls.
+			c.sql.WriteString("(")
+			if err := c.writeFilter(f.Value, tbl, alias); err != nil {
+				return err
+			}
+			c.sql.WriteString(" = 0)")
+		default:
+			if _, ok := tbl.columns[f.Name]; !ok {
+				return &ErrInvalidQuery{Msg: fmt.Sprintf("unknown column %s of %s", f.Name, tbl.name)}
+			}
+			if err := c.writeColumnFilter(alias+"."+parsing.QuoteIdentifier(f.Name), f.Value); err != nil {
+				return err
+			}
+		}
+	}
+	c.sql.WriteString(")")
+
+	return nil
+}
+
+var comparisonOperators = map[string]string{
+	"eq":   "=",
+	"neq":  "!=",
+	"gt":   ">",
+	"gte":  ">=",
+	"lt":   "<",
+	"lte":  "<=",
+	"like": "LIKE",
+}
+
+func (c *compiler) writeColumnFilter(column string, v Value) error {
+	operators, ok := v.(Object)
+	if !ok {
+		operators = Object{{Name: "eq", Value: v}}
+	}
+	if len(operators) == 0 {
+		return &ErrInvalidQuery{Msg: "column filters can't be empty"}
+	}
+
+	c.sql.WriteString("(")
+	for i, op := range operators {
+		if i > 0 {
+			c.sql.WriteString(" AND ")
+		}
+		if sqlOp, ok := comparisonOperators[op.Name]; ok {
+			fmt.Fprintf(&c.sql, "%s %s ", column, sqlOp)
+			if err := c.writeValue(op.Value); err != nil {
+				return err
+			}
+			continue
+		}
+		switch op.Name {
+		case "in":
+			values, ok := op.Value.(List)
+			if !ok || len(values) == 0 {
+				return &ErrInvalidQuery{Msg: "in must be a non-empty list"}
+			}
+			fmt.Fprintf(&c.sql, "%s IN (", column)
+			for j, value := range values {
+				if j > 0 {
+					c.sql.WriteString(", ")
+				}
+				if err := c.writeValue(value); err != nil {
+					return err
+				}
+			}
+			c.sql.WriteString(")")
+		case "is_null":
+			isNull, ok := scalarValue(op.Value).(bool)
+			if !ok {
+				return &ErrInvalidQuery{Msg: "is_null must be a boolean"}
+			}
+			if isNull {
+				fmt.Fprintf(&c.sql, "%s IS NULL", column)
+			} else {
+				fmt.Fprintf(&c.sql, "%s IS NOT NULL", column)
+			}
+		default:
+			return &ErrInvalidQuery{Msg: fmt.Sprintf("unknown filter operator %s", op.Name)}
+		}
+	}
+	c.sql.WriteString(")")
+
+	return nil
+}
+
+// writeValue writes a value of a filter as a parameter.
+func (c *compiler) writeValue(v Value) error {
+	scalar, ok := v.(Scalar)
+	if !ok {
+		return &ErrInvalidQuery{Msg: "filter values must be scalars"}
+	}
+	if value, ok := scalar.Value.(float64); ok && (math.IsInf(value, 0) || math.IsNaN(value)) {
+		return &ErrInvalidQuery{Msg: "filter values must be finite numbers"}
+	}
+	c.params = append(c.params, scalar.Value)
+	c.sql.WriteString("?")
+
+	return nil
+}
+
+// writeOrderBy writes the order of an order_by argument, that is an object or a list of objects mapping
+// columns to asc or desc.
+func (c *compiler) writeOrderBy(v Value, tbl *table, alias string) error {
+	value, err := c.resolve(v)
+	if err != nil {
+		return err
+	}
+	orders, ok := value.(List)
+	if !ok {
+		orders = List{value}
+	}
+
+	var terms []string
+	for _, order := range orders {
+		columns, ok := order.(Object)
+		if !ok {
+			return &ErrInvalidQuery{Msg: "order_by must be an object or a list of objects"}
+		}
+		for _, column := range columns {
+			if _, ok := tbl.columns[column.Name]; !ok {
+				return &ErrInvalidQuery{Msg: fmt.Sprintf("unknown column %s of %s", column.Name, tbl.name)}
+			}
+			var direction string
+			switch dir := column.Value.(type) {
+			case Enum:
+				direction = string(dir)
+			case Scalar:
+				direction, _ = dir.Value.(string)
+			}
+			if direction != "asc" && direction != "desc" {
+				return &ErrInvalidQuery{Msg: fmt.Sprintf("order of %s must be asc or desc", column.Name)}
+			}
+			terms = append(terms, alias+"."+parsing.QuoteIdentifier(column.Name)+" "+strings.ToUpper(direction))
+		}
+	}
+	if len(terms) > 0 {
+		c.sql.WriteString(" ORDER BY " + strings.Join(terms, ", "))
+	}
+
+	return nil
+}
+
+// intArgument returns the value of a non-negative int argument, or -1 if it wasn't provided.
+func (c *compiler) intArgument(field *Field, name string) (int64, error) {
+	arg := field.Argument(name)
+	if arg == nil {
+		return -1, nil
+	}
+	value, err := c.resolve(arg)
+	if err != nil {
+		return 0, err
+	}
+	if value == (Scalar{}) {
+		return -1, nil
+	}
+	switch n := scalarValue(value).(type) {
+	case int64:
+		if n >= 0 {
+			return n, nil
+		}
+	}
+	return 0, &ErrInvalidQuery{Msg: fmt.Sprintf("%s of %s must be a non-negative int", name, field.ResponseKey())}
+}
+
+// resolve replaces the variables of a value with their values.
+func (c *compiler) resolve(v Value) (Value, error) {
+	switch v := v.(type) {
+	case Variable:
+		value, ok := c.variables[string(v)]
+		if !ok {
+			return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("variable $%s isn't defined", v)}
+		}
+		return value, nil
+	case List:
+		list := make(List, len(v))
+		for i, elem := range v {
+			value, err := c.resolve(elem)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+	case Object:
+		object := make(Object, len(v))
+		for i, field := range v {
+			value, err := c.resolve(field.Value)
+			if err != nil {
+				return nil, err
+			}
+			object[i] = &ObjectField{Name: field.Name, Value: value}
+		}
+		return object, nil
+	}
+	return v, nil
+}
+
+// scalarValue returns the value of a scalar, or nil if v isn't a scalar.
+func scalarValue(v Value) interface{} {
+	if scalar, ok := v.(Scalar); ok {
+		return scalar.Value
+	}
+	return nil
+}
+
+func (c *compiler) alias(prefix string) string {
+	c.aliases++
+	return prefix + strconv.Itoa(c.aliases)
+}
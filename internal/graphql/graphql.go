@@ -0,0 +1,351 @@
+// Package graphql serves GraphQL queries over the tables of the validator. The schema isn't static: every table
+// is a query field named after the table, with a field for each of its columns, so queries are resolved against
+// the schemas of the tables when they are executed. Each root field is compiled to a read query, that runs through
+// the gateway like any other read query.
+//
+// Root fields accept the following arguments:
+//   - where: an object filtering rows by columns, like {id: {gt: 10, lte: 20}, name: {like: "a%"}}. Column filters
+//     are objects of the eq, neq, gt, gte, lt, lte, like, in and is_null operators, or a value to compare with.
+//     Filters can be combined with _and, _or and _not.
+//   - order_by: an object, or a list of objects, mapping columns to asc or desc.
+//   - limit and offset.
+//
+// Fields of a table can also be other tables, to look up related rows. Nested tables accept the same arguments,
+// and require an on argument mapping their columns to columns of the parent table, like {owner_id: "id"}.
+//
+// The __schema and __type introspection fields are supported. Since there is a type for every table, __schema only
+// describes the Query type and the scalar types, and the types of the tables are looked up by name with __type.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/textileio/go-tableland/internal/gateway"
+)
+
+// ErrInvalidQuery indicates that a query can't be executed, because it isn't valid for the schema.
+type ErrInvalidQuery struct {
+	Msg string
+}
+
+func (e *ErrInvalidQuery) Error() string {
+	return e.Msg
+}
+
+// Request is a GraphQL request.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is a GraphQL response.
+type Response struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []Error         `json:"errors,omitempty"`
+}
+
+// Error is an error of a GraphQL response.
+type Error struct {
+	Message string   `json:"message"`
+	Path    []string `json:"path,omitempty"`
+}
+
+// Executor executes GraphQL queries.
+type Executor struct {
+	gateway     gateway.Gateway
+	maxRowCount int
+}
+
+// Option modifies the configuration of an Executor.
+type Option func(*Executor)
+
+// WithMaxRowCount limits the number of rows returned by each root field, and by each list of nested objects.
+// Zero means no limit.
+func WithMaxRowCount(count int) Option {
+	return func(e *Executor) {
+		e.maxRowCount = count
+	}
+}
+
+// NewExecutor creates a new Executor.
+func NewExecutor(gateway gateway.Gateway, opts ...Option) *Executor {
+	e := &Executor{
+		gateway: gateway,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Execute executes a GraphQL request. *ErrSyntax or *ErrInvalidQuery are returned if the request can't be
+// executed. Root fields are executed independently, so a field that fails is null in the response data,
+// and its error is included in the response errors.
+func (e *Executor) Execute(ctx context.Context, req Request) (*Response, error) {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return nil, err
+	}
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return nil, err
+	}
+	variables, err := operationVariables(op, req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &compiler{
+		ctx:       ctx,
+		gateway:   e.gateway,
+		variables: variables,
+		tables:    map[string]*table{},
+	}
+
+	// The whole operation is compiled before executing any field, so invalid queries aren't partially executed.
+	type rootField struct {
+		key    string
+		query  string
+		params []any
+		nested nestedLists
+		// data is the value of fields that aren't compiled to a query.
+		data json.RawMessage
+	}
+	fields := make([]rootField, len(op.SelectionSet))
+	keys := map[string]struct{}{}
+	for i, field := range op.SelectionSet {
+		if _, ok := keys[field.ResponseKey()]; ok {
+			return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("field %s is selected more than once", field.ResponseKey())}
+		}
+		keys[field.ResponseKey()] = struct{}{}
+
+		fields[i].key = field.ResponseKey()
+		switch field.Name {
+		case "__typename":
+			fields[i].data = json.RawMessage(`"Query"`)
+		case "__schema", "__type":
+			if fields[i].data, err = c.introspect(field); err != nil {
+				return nil, err
+			}
+		default:
+			fields[i].query, fields[i].params, fields[i].nested, err = c.compileRootField(field, int64(e.maxRowCount))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	res := &Response{}
+	var data bytes.Buffer
+	data.WriteString("{")
+	for i, field := range fields {
+		if i > 0 {
+			data.WriteString(",")
+		}
+		data.WriteString(strconv.Quote(field.key) + ":")
+		if field.query == "" {
+			data.Write(field.data)
+			continue
+		}
+
+		rows, err := e.runQuery(ctx, field.query, field.params, field.nested)
+		if err != nil {
+			data.WriteString("null")
+			res.Errors = append(res.Errors, Error{Message: err.Error(), Path: []string{field.key}})
+			continue
+		}
+		data.Write(rows)
+	}
+	data.WriteString("}")
+	res.Data = data.Bytes()
+
+	return res, nil
+}
+
+// runQuery runs the query of a root field, and returns the JSON array of the selected objects.
+func (e *Executor) runQuery(
+	ctx context.Context, query string, params []any, nested nestedLists,
+) (json.RawMessage, error) {
+	w := &objectsWriter{maxRows: e.maxRowCount, nested: nested}
+	if err := e.gateway.StreamReadQuery(ctx, query, params, w); err != nil {
+		if w.err != nil {
+			return nil, w.err
+		}
+		return nil, err
+	}
+	if w.rows == 0 {
+		return json.RawMessage("[]"), nil
+	}
+	w.buf.WriteString("]")
+	return w.buf.Bytes(), nil
+}
+
+// objectsWriter builds the JSON array of the objects selected by a root field as the rows of its query are read,
+// one object per row. Reading stops as soon as the field, or a list of nested objects, has more rows than allowed.
+type objectsWriter struct {
+	maxRows int
+	nested  nestedLists
+
+	buf  bytes.Buffer
+	rows int
+	err  error
+}
+
+func (w *objectsWriter) WriteColumns(columns []gateway.Column) error {
+	if len(columns) != 1 {
+		w.err = fmt.Errorf("unexpected shape of the query result")
+		return w.err
+	}
+	return nil
+}
+
+func (w *objectsWriter) WriteRow(row []*gateway.ColumnValue) error {
+	object, ok := row[0].Value().(json.RawMessage)
+	if !ok {
+		w.err = fmt.Errorf("unexpected type of the query result")
+		return w.err
+	}
+	w.rows++
+	if w.maxRows > 0 {
+		if w.rows > w.maxRows {
+			w.err = fmt.Errorf("the query returned more than %d rows", w.maxRows)
+			return w.err
+		}
+		if err := checkNestedLists(object, w.nested, w.maxRows); err != nil {
+			w.err = err
+			return w.err
+		}
+	}
+
+	if w.rows == 1 {
+		w.buf.WriteString("[")
+	} else {
+		w.buf.WriteString(",")
+	}
+	w.buf.Write(object)
+	return nil
+}
+
+// checkNestedLists fails if a list of nested objects of an object has more than maxRows objects. Nested lists are
+// read up to one more object than allowed, so checking them is bounded.
+func checkNestedLists(object json.RawMessage, nested nestedLists, maxRows int) error {
+	if len(nested) == 0 {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(object, &fields); err != nil {
+		return fmt.Errorf("decoding object: %s", err)
+	}
+	for key, lists := range nested {
+		var objects []json.RawMessage
+		if err := json.Unmarshal(fields[key], &objects); err != nil {
+			return fmt.Errorf("decoding field %s: %s", key, err)
+		}
+		if len(objects) > maxRows {
+			return fmt.Errorf("field %s returned more than %d rows", key, maxRows)
+		}
+		for _, object := range objects {
+			if err := checkNestedLists(object, lists, maxRows); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, &ErrInvalidQuery{Msg: "the operation name is required when the document has many operations"}
+		}
+		return doc.Operations[0], nil
+	}
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("unknown operation %s", name)}
+}
+
+// operationVariables returns the values of the variables of an operation, from the values of the request
+// and the defaults of the variable definitions.
+func operationVariables(op *Operation, values map[string]interface{}) (map[string]Value, error) {
+	variables := make(map[string]Value, len(op.Variables))
+	for _, def := range op.Variables {
+		if v, ok := values[def.Name]; ok {
+			value, err := toValue(v)
+			if err != nil {
+				return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("variable $%s: %s", def.Name, err)}
+			}
+			variables[def.Name] = value
+			continue
+		}
+		if def.DefaultValue != nil {
+			variables[def.Name] = def.DefaultValue
+			continue
+		}
+		if strings.HasSuffix(def.Type, "!") {
+			return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("variable $%s is required", def.Name)}
+		}
+		variables[def.Name] = Scalar{}
+	}
+	return variables, nil
+}
+
+// toValue converts a variable value decoded from JSON to a Value. Numbers are ints if they can be decoded
+// as json.Number.
+func toValue(v interface{}) (Value, error) {
+	switch v := v.(type) {
+	case nil, bool, string:
+		return Scalar{Value: v}, nil
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return Scalar{Value: n}, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", v)
+		}
+		return Scalar{Value: f}, nil
+	case float64:
+		if n := int64(v); float64(n) == v {
+			return Scalar{Value: n}, nil
+		}
+		return Scalar{Value: v}, nil
+	case []interface{}:
+		list := make(List, len(v))
+		for i, elem := range v {
+			value, err := toValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+	case map[string]interface{}:
+		// JSON objects aren't ordered, so fields are sorted to make the compiled queries deterministic.
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		object := make(Object, len(names))
+		for i, name := range names {
+			value, err := toValue(v[name])
+			if err != nil {
+				return nil, err
+			}
+			object[i] = &ObjectField{Name: name, Value: value}
+		}
+		return object, nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", v)
+}
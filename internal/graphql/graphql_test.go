@@ -0,0 +1,280 @@
+package graphql
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/gateway"
+	gatewayimpl "github.com/textileio/go-tableland/internal/gateway/impl"
+	tablelandimpl "github.com/textileio/go-tableland/internal/tableland/impl"
+	"github.com/textileio/go-tableland/pkg/database"
+	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
+	executor "github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor/impl"
+	"github.com/textileio/go-tableland/pkg/parsing"
+	parserimpl "github.com/textileio/go-tableland/pkg/parsing/impl"
+	"github.com/textileio/go-tableland/pkg/sharedmemory"
+	"github.com/textileio/go-tableland/pkg/tables/impl/ethereum"
+	"github.com/textileio/go-tableland/tests"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Parse(`
+		# Comments and commas are ignored.
+		query Users($min: Int = 1, $names: [String!]) {
+			people: users_1337_1(where: {id: {gte: $min}, name: {in: $names}}, limit: 10) {
+				id, name
+			}
+		}`)
+	require.NoError(t, err)
+	require.Len(t, doc.Operations, 1)
+
+	op := doc.Operations[0]
+	require.Equal(t, "Users", op.Name)
+	require.Equal(t, []*VariableDefinition{
+		{Name: "min", Type: "Int", DefaultValue: Scalar{Value: int64(1)}},
+		{Name: "names", Type: "[String!]"},
+	}, op.Variables)
+	require.Len(t, op.SelectionSet, 1)
+
+	field := op.SelectionSet[0]
+	require.Equal(t, "people", field.ResponseKey())
+	require.Equal(t, "users_1337_1", field.Name)
+	require.Equal(t, Object{
+		{Name: "id", Value: Object{{Name: "gte", Value: Variable("min")}}},
+		{Name: "name", Value: Object{{Name: "in", Value: Variable("names")}}},
+	}, field.Argument("where"))
+	require.Equal(t, Scalar{Value: int64(10)}, field.Argument("limit"))
+	require.Len(t, field.SelectionSet, 2)
+
+	for _, query := range []string{
+		"",
+		"{ users_1337_1 { id }",
+		"mutation { users_1337_1 { id } }",
+		"{ ...Users }",
+		`{ users_1337_1(where: {name: "unterminated}) { id } }`,
+	} {
+		_, err := Parse(query)
+		require.Error(t, err, query)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gw := setup(t)
+	ex := NewExecutor(gw, WithMaxRowCount(3))
+
+	execute := func(query string, variables map[string]interface{}) *Response {
+		res, err := ex.Execute(ctx, Request{Query: query, Variables: variables})
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("filter and order", func(t *testing.T) {
+		t.Parallel()
+
+		res := execute(`{
+			users_1337_1(where: {_or: [{id: 1}, {name: {like: "c%"}}]}, order_by: {id: desc}) {
+				__typename
+				id
+				name
+			}
+		}`, nil)
+		require.Empty(t, res.Errors)
+		require.JSONEq(t, `{"users_1337_1": [
+			{"__typename": "users_1337_1", "id": 3, "name": "carol"},
+			{"__typename": "users_1337_1", "id": 1, "name": "alice"}
+		]}`, string(res.Data))
+	})
+
+	t.Run("pagination and variables", func(t *testing.T) {
+		t.Parallel()
+
+		res := execute(`query Page($limit: Int!, $offset: Int = 1) {
+			page: users_1337_1(order_by: [{id: asc}], limit: $limit, offset: $offset) { id }
+		}`, map[string]interface{}{"limit": float64(1)})
+		require.Empty(t, res.Errors)
+		require.JSONEq(t, `{"page": [{"id": 2}]}`, string(res.Data))
+	})
+
+	t.Run("nested lookups", func(t *testing.T) {
+		t.Parallel()
+
+		res := execute(`{
+			users_1337_1(where: {id: {in: [1, 2]}}, order_by: {id: asc}) {
+				name
+				posts: posts_1337_2(on: {user_id: "id"}, where: {_not: {title: "hidden"}}, order_by: {id: asc}) {
+					title
+				}
+			}
+		}`, nil)
+		require.Empty(t, res.Errors)
+		require.JSONEq(t, `{"users_1337_1": [
+			{"name": "alice", "posts": [{"title": "first"}, {"title": "second"}]},
+			{"name": "bob", "posts": []}
+		]}`, string(res.Data))
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		t.Parallel()
+
+		res := execute(`{ __typename posts_1337_2 { id } users_1337_1(limit: 1) { id } }`, nil)
+		require.JSONEq(t, `{"__typename": "Query", "posts_1337_2": null, "users_1337_1": [{"id": 1}]}`, string(res.Data))
+		require.Len(t, res.Errors, 1)
+		require.Equal(t, []string{"posts_1337_2"}, res.Errors[0].Path)
+
+		// Lists of nested objects are limited too.
+		res, err := NewExecutor(gw, WithMaxRowCount(2)).Execute(ctx, Request{Query: `{
+			users_1337_1(where: {id: 1}) { posts: posts_1337_2(on: {user_id: "id"}) { id } }
+			other: users_1337_1(where: {id: 3}) { posts: posts_1337_2(on: {user_id: "id"}) { id } }
+		}`})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"users_1337_1": null, "other": [{"posts": [{"id": 4}]}]}`, string(res.Data))
+		require.Len(t, res.Errors, 1)
+		require.Equal(t, []string{"users_1337_1"}, res.Errors[0].Path)
+		require.Equal(t, "field posts returned more than 2 rows", res.Errors[0].Message)
+	})
+
+	t.Run("introspection", func(t *testing.T) {
+		t.Parallel()
+
+		res := execute(`{
+			__schema { queryType { name } types { name kind } }
+			__type(name: "users_1337_1") { __typename name kind fields { name type { name kind ofType { name } } } }
+			unknown: __type(name: "users_1337_404") { name }
+		}`, nil)
+		require.Empty(t, res.Errors)
+		require.JSONEq(t, `{
+			"__schema": {
+				"queryType": {"name": "Query"},
+				"types": [
+					{"name": "Query", "kind": "OBJECT"},
+					{"name": "Int", "kind": "SCALAR"},
+					{"name": "Float", "kind": "SCALAR"},
+					{"name": "String", "kind": "SCALAR"},
+					{"name": "Boolean", "kind": "SCALAR"}
+				]
+			},
+			"__type": {
+				"__typename": "__Type",
+				"name": "users_1337_1",
+				"kind": "OBJECT",
+				"fields": [
+					{"name": "id", "type": {"name": "Int", "kind": "SCALAR", "ofType": null}},
+					{"name": "name", "type": {"name": "String", "kind": "SCALAR", "ofType": null}}
+				]
+			},
+			"unknown": null
+		}`, string(res.Data))
+
+		for _, query := range []string{
+			`{ __schema { unknown } }`,
+			`{ __schema { queryType } }`,
+			`{ __type { name } }`,
+			`{ __type(name: "Query") { name { kind } } }`,
+		} {
+			_, err := ex.Execute(ctx, Request{Query: query})
+			var errInvalid *ErrInvalidQuery
+			require.ErrorAs(t, err, &errInvalid, query)
+		}
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		t.Parallel()
+
+		for _, query := range []string{
+			`{ users_1337_1 { id unknown } }`,
+			`{ users_1337_1(where: {unknown: 1}) { id } }`,
+			`{ users_1337_1(order_by: {id: up}) { id } }`,
+			`{ users_1337_1(limit: -1) { id } }`,
+			`{ users_1337_1(first: 1) { id } }`,
+			`{ users_1337_1 { posts_1337_2 { id } } }`,
+			`{ users_1337_1 { posts_1337_2(on: {user_id: "unknown"}) { id } } }`,
+			`{ other_1337_1 { id } }`,
+			`{ users_1337_404 { id } }`,
+			`query($id: Int!) { users_1337_1(where: {id: $id}) { id } }`,
+		} {
+			_, err := ex.Execute(ctx, Request{Query: query})
+			var errInvalid *ErrInvalidQuery
+			require.ErrorAs(t, err, &errInvalid, query)
+		}
+	})
+
+	t.Run("validated read queries", func(t *testing.T) {
+		t.Parallel()
+
+		// Values are sent as parameters, so they can't change the query.
+		res := execute(`{ users_1337_1(where: {name: "x') OR 1=1 --"}) { id } }`, nil)
+		require.Empty(t, res.Errors)
+		require.JSONEq(t, `{"users_1337_1": []}`, string(res.Data))
+
+		// System tables aren't tables of the schema.
+		_, err := ex.Execute(ctx, Request{Query: `{ registry { id } }`})
+		require.Error(t, err)
+	})
+}
+
+func setup(t *testing.T) gateway.Gateway {
+	t.Helper()
+
+	ctx := context.Background()
+	dbURI := tests.Sqlite3URI(t)
+
+	parser, err := parserimpl.New([]string{"system_", "registry"})
+	require.NoError(t, err)
+	db, err := database.Open(dbURI)
+	require.NoError(t, err)
+
+	ex, err := executor.NewExecutor(1337, db, parser, 0, tablelandimpl.NewACL(db))
+	require.NoError(t, err)
+
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	runSQL := func(tableID int64, statement string) *ethereum.ContractRunSQL {
+		return &ethereum.ContractRunSQL{
+			Caller:    owner,
+			IsOwner:   true,
+			TableId:   big.NewInt(tableID),
+			Statement: statement,
+			Policy: ethereum.ITablelandControllerPolicy{
+				AllowInsert: true,
+			},
+		}
+	}
+	bs, err := ex.NewBlockScope(ctx, 1)
+	require.NoError(t, err)
+	res, err := bs.ExecuteTxnEvents(ctx, eventfeed.TxnEvents{
+		TxnHash: common.HexToHash("0x1"),
+		Events: []interface{}{
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(1),
+				Owner:     owner,
+				Statement: "create table users_1337 (id int primary key, name text)",
+			},
+			&ethereum.ContractCreateTable{
+				TableId:   big.NewInt(2),
+				Owner:     owner,
+				Statement: "create table posts_1337 (id int primary key, user_id int, title text)",
+			},
+			runSQL(1, "insert into users_1337_1 values (1, 'alice'), (2, 'bob'), (3, 'carol')"),
+			runSQL(2, "insert into posts_1337_2 values (1, 1, 'first'), (2, 1, 'hidden'), (3, 1, 'second')"),
+			runSQL(2, "insert into posts_1337_2 values (4, 3, 'third')"),
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, bs.SetLastProcessedHeight(ctx, 1))
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	resolver := parsing.NewReadStatementResolver(sharedmemory.NewSharedMemory())
+	gw, err := gateway.NewGateway(parser, gatewayimpl.NewGatewayStore(db), resolver, "https://tableland.network", "", "")
+	require.NoError(t, err)
+
+	return gw
+}
@@ -0,0 +1,197 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// introspectionObject is an object of the introspection schema, like __Schema or __Type, mapping its fields to
+// their values. Values are scalars, other objects, or lists of objects.
+type introspectionObject map[string]interface{}
+
+// scalarTypes are the types of the columns of the tables. Blobs are returned as hex strings, and columns of any
+// type are described as strings.
+var scalarTypes = []string{"Int", "Float", "String", "Boolean"}
+
+// introspect resolves the __schema and __type root fields. The Query type doesn't list its fields, since there is
+// one for every table, and the types of the tables are only resolved by name with __type.
+func (c *compiler) introspect(field *Field) (json.RawMessage, error) {
+	var value interface{}
+	switch field.Name {
+	case "__schema":
+		if len(field.Arguments) > 0 {
+			return nil, &ErrInvalidQuery{Msg: fmt.Sprintf("field %s doesn't have arguments", field.ResponseKey())}
+		}
+		types := []introspectionObject{queryType()}
+		for _, name := range scalarTypes {
+			types = append(types, scalarType(name))
+		}
+		value = introspectionObject{
+			"__typename":       "__Schema",
+			"description":      nil,
+			"queryType":        queryType(),
+			"mutationType":     nil,
+			"subscriptionType": nil,
+			"types":            types,
+			"directives":       []introspectionObject{},
+		}
+	case "__type":
+		name, err := c.typeName(field)
+		if err != nil {
+			return nil, err
+		}
+		if value, err = c.namedType(name); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeIntrospection(&buf, field, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// typeName returns the name argument of a __type field.
+func (c *compiler) typeName(field *Field) (string, error) {
+	for _, arg := range field.Arguments {
+		if arg.Name != "name" {
+			return "", &ErrInvalidQuery{Msg: fmt.Sprintf("unknown argument %s of %s", arg.Name, field.ResponseKey())}
+		}
+	}
+	arg := field.Argument("name")
+	if arg == nil {
+		return "", &ErrInvalidQuery{Msg: fmt.Sprintf("field %s must have a name argument", field.ResponseKey())}
+	}
+	value, err := c.resolve(arg)
+	if err != nil {
+		return "", err
+	}
+	name, ok := scalarValue(value).(string)
+	if !ok {
+		return "", &ErrInvalidQuery{Msg: fmt.Sprintf("name argument of %s must be a string", field.ResponseKey())}
+	}
+	return name, nil
+}
+
+// namedType returns the type with the provided name, or nil if there isn't such type.
+func (c *compiler) namedType(name string) (interface{}, error) {
+	if name == "Query" {
+		return queryType(), nil
+	}
+	for _, scalar := range scalarTypes {
+		if name == scalar {
+			return scalarType(name), nil
+		}
+	}
+
+	tbl, err := c.table(name)
+	if err != nil || tbl == nil {
+		return nil, err
+	}
+	fields := make([]introspectionObject, len(tbl.schema.Columns))
+	for i, column := range tbl.schema.Columns {
+		fields[i] = introspectionObject{
+			"__typename":        "__Field",
+			"name":              column.Name,
+			"description":       nil,
+			"args":              []introspectionObject{},
+			"type":              scalarType(columnType(column.Type)),
+			"isDeprecated":      false,
+			"deprecationReason": nil,
+		}
+	}
+	return objectType(tbl.name, fields), nil
+}
+
+func queryType() introspectionObject {
+	return objectType("Query", []introspectionObject{})
+}
+
+func objectType(name string, fields []introspectionObject) introspectionObject {
+	t := scalarType(name)
+	t["kind"] = "OBJECT"
+	t["fields"] = fields
+	t["interfaces"] = []introspectionObject{}
+	return t
+}
+
+func scalarType(name string) introspectionObject {
+	return introspectionObject{
+		"__typename":    "__Type",
+		"kind":          "SCALAR",
+		"name":          name,
+		"description":   nil,
+		"fields":        nil,
+		"interfaces":    nil,
+		"possibleTypes": nil,
+		"enumValues":    nil,
+		"inputFields":   nil,
+		"ofType":        nil,
+	}
+}
+
+// columnType returns the name of the scalar type of a column of the provided type.
+func columnType(typ string) string {
+	switch typ {
+	case "int", "integer":
+		return "Int"
+	case "real":
+		return "Float"
+	default:
+		return "String"
+	}
+}
+
+// writeIntrospection writes the JSON of the subfields of an introspection value selected by the field. Arguments
+// of the subfields, like includeDeprecated, are ignored.
+func writeIntrospection(buf *bytes.Buffer, field *Field, value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case introspectionObject:
+		if len(field.SelectionSet) == 0 {
+			return &ErrInvalidQuery{
+				Msg: fmt.Sprintf("field %s must have a selection of subfields", field.ResponseKey()),
+			}
+		}
+		buf.WriteString("{")
+		for i, f := range field.SelectionSet {
+			v, ok := value[f.Name]
+			if !ok {
+				return &ErrInvalidQuery{Msg: fmt.Sprintf("unknown field %s of %s", f.Name, value["__typename"])}
+			}
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString(strconv.Quote(f.ResponseKey()) + ":")
+			if err := writeIntrospection(buf, f, v); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("}")
+	case []introspectionObject:
+		buf.WriteString("[")
+		for i, object := range value {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			if err := writeIntrospection(buf, field, object); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("]")
+	default:
+		if len(field.SelectionSet) > 0 {
+			return &ErrInvalidQuery{Msg: fmt.Sprintf("field %s can't have subfields", field.ResponseKey())}
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("marshaling to json: %s", err)
+		}
+		buf.Write(b)
+	}
+	return nil
+}
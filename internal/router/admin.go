@@ -0,0 +1,42 @@
+package router
+
+import (
+	"fmt"
+
+	"github.com/gorilla/mux"
+	"github.com/textileio/go-tableland/internal/chains"
+	"github.com/textileio/go-tableland/internal/router/controllers"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
+	"github.com/textileio/go-tableland/internal/tableland"
+)
+
+// ConfiguredAdminRouter returns a Router serving the admin API. It's meant to be served apart from the public
+// router, and every request must have the API key as bearer token. The backuper is nil if backups are disabled.
+func ConfiguredAdminRouter(
+	chainStacks map[tableland.ChainID]chains.ChainStack,
+	backuper controllers.Backuper,
+	apiKey string,
+) (*Router, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("the admin api key is empty")
+	}
+
+	supportedChainIDs := make([]tableland.ChainID, 0, len(chainStacks))
+	for chainID := range chainStacks {
+		supportedChainIDs = append(supportedChainIDs, chainID)
+	}
+
+	router := newRouter()
+	router.use(middlewares.TraceID, middlewares.AdminAuth(apiKey))
+
+	ctrl := controllers.NewAdminController(chainStacks, backuper)
+	chainMiddlewares := []mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)}
+	router.get("/admin/chains", ctrl.ListChainStacks, middlewares.WithLogging)
+	router.get("/admin/chains/{chainId}", ctrl.GetChainStack, chainMiddlewares...)
+	router.post("/admin/chains/{chainId}/stop", ctrl.StopChainStack, chainMiddlewares...)
+	router.post("/admin/chains/{chainId}/start", ctrl.StartChainStack, chainMiddlewares...)
+	router.post("/admin/chains/{chainId}/statehash", ctrl.ForceStateHash, chainMiddlewares...)
+	router.post("/admin/backup", ctrl.Backup, middlewares.WithLogging)
+
+	return router, nil
+}
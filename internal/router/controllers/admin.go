@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+	"github.com/textileio/go-tableland/internal/chains"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/errors"
+)
+
+// Backuper executes database backups on demand.
+type Backuper interface {
+	BackupNow() error
+}
+
+// ChainStackStatus is the status of a chain stack returned by the admin API.
+type ChainStackStatus struct {
+	ChainID             int64 `json:"chain_id"`
+	Running             bool  `json:"running"`
+	LastProcessedHeight int64 `json:"last_processed_height"`
+	ExecutionRound      int64 `json:"execution_round"`
+	FeedHeight          int64 `json:"feed_height"`
+}
+
+// AdminController defines the HTTP handlers of the admin API, used by operators to inspect and control the
+// chain stacks without restarting the validator.
+type AdminController struct {
+	chainStacks map[tableland.ChainID]chains.ChainStack
+	backuper    Backuper
+}
+
+// NewAdminController creates a new AdminController. The backuper is nil if backups are disabled.
+func NewAdminController(chainStacks map[tableland.ChainID]chains.ChainStack, backuper Backuper) *AdminController {
+	return &AdminController{
+		chainStacks: chainStacks,
+		backuper:    backuper,
+	}
+}
+
+// ListChainStacks handles the GET /admin/chains call.
+func (c *AdminController) ListChainStacks(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-type", "application/json")
+
+	chainIDs := make([]tableland.ChainID, 0, len(c.chainStacks))
+	for chainID := range c.chainStacks {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+
+	statuses := make([]ChainStackStatus, len(chainIDs))
+	for i, chainID := range chainIDs {
+		statuses[i] = c.chainStackStatus(chainID)
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(statuses)
+}
+
+// GetChainStack handles the GET /admin/chains/{chainId} call.
+func (c *AdminController) GetChainStack(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-type", "application/json")
+
+	chainID := r.Context().Value(middlewares.ContextKeyChainID).(tableland.ChainID)
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(c.chainStackStatus(chainID))
+}
+
+// StopChainStack handles the POST /admin/chains/{chainId}/stop call. It stops processing events of the chain,
+// waiting for the block being executed.
+func (c *AdminController) StopChainStack(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	chainID := ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID)
+	c.chainStacks[chainID].EventProcessor.Stop()
+	log.Ctx(ctx).Info().Int64("chain_id", int64(chainID)).Msg("chain stack stopped by admin")
+
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(c.chainStackStatus(chainID))
+}
+
+// StartChainStack handles the POST /admin/chains/{chainId}/start call. It resumes processing events of the
+// chain from the last processed height.
+func (c *AdminController) StartChainStack(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	chainID := ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID)
+	ep := c.chainStacks[chainID].EventProcessor
+	if ep.IsRunning() {
+		rw.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Chain stack is already running"})
+		return
+	}
+	if err := ep.Start(); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).Error().Err(err).Int64("chain_id", int64(chainID)).Msg("failed to start chain stack")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to start chain stack"})
+		return
+	}
+	log.Ctx(ctx).Info().Int64("chain_id", int64(chainID)).Msg("chain stack started by admin")
+
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(c.chainStackStatus(chainID))
+}
+
+// ForceStateHash handles the POST /admin/chains/{chainId}/statehash call. The state hash is calculated with the
+// next executed block of the chain, so the call returns before it's available. The chain stack must be running.
+func (c *AdminController) ForceStateHash(rw http.ResponseWriter, r *http.Request) {
+	chainID := r.Context().Value(middlewares.ContextKeyChainID).(tableland.ChainID)
+	ep := c.chainStacks[chainID].EventProcessor
+	if !ep.IsRunning() {
+		rw.Header().Set("Content-type", "application/json")
+		rw.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Chain stack isn't running"})
+		return
+	}
+	ep.ForceStateHash()
+
+	rw.WriteHeader(http.StatusAccepted)
+}
+
+// Backup handles the POST /admin/backup call. It returns after the backup is done.
+func (c *AdminController) Backup(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	if c.backuper == nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Backups are disabled"})
+		return
+	}
+	if err := c.backuper.BackupNow(); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).Error().Err(err).Msg("failed to backup")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to backup"})
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+}
+
+func (c *AdminController) chainStackStatus(chainID tableland.ChainID) ChainStackStatus {
+	ep := c.chainStacks[chainID].EventProcessor
+	return ChainStackStatus{
+		ChainID:             int64(chainID),
+		Running:             ep.IsRunning(),
+		LastProcessedHeight: ep.GetLastExecutedBlockNumber(),
+		ExecutionRound:      ep.GetExecutionRound(),
+		FeedHeight:          ep.GetFeedHeight(),
+	}
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/chains"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
+	"github.com/textileio/go-tableland/internal/tableland"
+)
+
+func TestAdminChainStacks(t *testing.T) {
+	t.Parallel()
+
+	ep1 := &fakeEventProcessor{running: true, lastHeight: 1500, feedHeight: 1510}
+	ep2 := &fakeEventProcessor{running: true, lastHeight: 3600, executionRound: 4, feedHeight: 3601}
+	router := adminRouter(map[tableland.ChainID]chains.ChainStack{
+		10: {EventProcessor: ep2},
+		1:  {EventProcessor: ep1},
+	}, nil)
+
+	rr := adminRequest(t, router, "GET", "/admin/chains", "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `[
+		{"chain_id":1,"running":true,"last_processed_height":1500,"execution_round":0,"feed_height":1510},
+		{"chain_id":10,"running":true,"last_processed_height":3600,"execution_round":4,"feed_height":3601}
+	]`, rr.Body.String())
+
+	rr = adminRequest(t, router, "POST", "/admin/chains/10/stop", "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t,
+		`{"chain_id":10,"running":false,"last_processed_height":3600,"execution_round":0,"feed_height":3601}`,
+		rr.Body.String())
+	require.True(t, ep1.running)
+
+	rr = adminRequest(t, router, "POST", "/admin/chains/10/start", "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.True(t, ep2.running)
+
+	rr = adminRequest(t, router, "POST", "/admin/chains/10/start", "secret")
+	require.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = adminRequest(t, router, "POST", "/admin/chains/1/statehash", "secret")
+	require.Equal(t, http.StatusAccepted, rr.Code)
+	require.True(t, ep1.forcedStateHash)
+	require.False(t, ep2.forcedStateHash)
+
+	rr = adminRequest(t, router, "POST", "/admin/chains/10/stop", "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	rr = adminRequest(t, router, "POST", "/admin/chains/10/statehash", "secret")
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.False(t, ep2.forcedStateHash)
+
+	rr = adminRequest(t, router, "GET", "/admin/chains/5", "secret")
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestAdminBackup(t *testing.T) {
+	t.Parallel()
+
+	stacks := map[tableland.ChainID]chains.ChainStack{1: {EventProcessor: &fakeEventProcessor{}}}
+
+	backuper := &fakeBackuper{}
+	rr := adminRequest(t, adminRouter(stacks, backuper), "POST", "/admin/backup", "secret")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, 1, backuper.backups)
+
+	backuper.err = errors.New("disk full")
+	rr = adminRequest(t, adminRouter(stacks, backuper), "POST", "/admin/backup", "secret")
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = adminRequest(t, adminRouter(stacks, nil), "POST", "/admin/backup", "secret")
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestAdminAuth(t *testing.T) {
+	t.Parallel()
+
+	ep := &fakeEventProcessor{running: true}
+	router := adminRouter(map[tableland.ChainID]chains.ChainStack{1: {EventProcessor: ep}}, nil)
+
+	for _, apiKey := range []string{"", "wrong", "secretsecret"} {
+		rr := adminRequest(t, router, "POST", "/admin/chains/1/stop", apiKey)
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+	}
+	require.True(t, ep.running)
+}
+
+func adminRouter(stacks map[tableland.ChainID]chains.ChainStack, backuper Backuper) *mux.Router {
+	chainIDs := make([]tableland.ChainID, 0, len(stacks))
+	for chainID := range stacks {
+		chainIDs = append(chainIDs, chainID)
+	}
+
+	ctrl := NewAdminController(stacks, backuper)
+	router := mux.NewRouter()
+	router.Use(middlewares.AdminAuth("secret"))
+	router.HandleFunc("/admin/chains", ctrl.ListChainStacks).Methods(http.MethodGet)
+	router.HandleFunc("/admin/backup", ctrl.Backup).Methods(http.MethodPost)
+	chain := router.PathPrefix("/admin/chains/{chainId}").Subrouter()
+	chain.Use(middlewares.RESTChainID(chainIDs))
+	chain.HandleFunc("", ctrl.GetChainStack).Methods(http.MethodGet)
+	chain.HandleFunc("/stop", ctrl.StopChainStack).Methods(http.MethodPost)
+	chain.HandleFunc("/start", ctrl.StartChainStack).Methods(http.MethodPost)
+	chain.HandleFunc("/statehash", ctrl.ForceStateHash).Methods(http.MethodPost)
+	return router
+}
+
+func adminRequest(t *testing.T, router http.Handler, method, path, apiKey string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, path, nil)
+	require.NoError(t, err)
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+type fakeEventProcessor struct {
+	running         bool
+	lastHeight      int64
+	executionRound  int64
+	feedHeight      int64
+	forcedStateHash bool
+}
+
+func (ep *fakeEventProcessor) GetLastExecutedBlockNumber() int64 { return ep.lastHeight }
+func (ep *fakeEventProcessor) GetExecutionRound() int64          { return ep.executionRound }
+func (ep *fakeEventProcessor) GetFeedHeight() int64              { return ep.feedHeight }
+func (ep *fakeEventProcessor) IsRunning() bool                   { return ep.running }
+func (ep *fakeEventProcessor) ForceStateHash()                   { ep.forcedStateHash = true }
+
+func (ep *fakeEventProcessor) Start() error {
+	if ep.running {
+		return errors.New("already started")
+	}
+	ep.running = true
+	return nil
+}
+
+func (ep *fakeEventProcessor) Stop() {
+	ep.running = false
+	ep.executionRound = 0
+}
+
+type fakeBackuper struct {
+	backups int
+	err     error
+}
+
+func (b *fakeBackuper) BackupNow() error {
+	if b.err != nil {
+		return b.err
+	}
+	b.backups++
+	return nil
+}
@@ -12,12 +12,18 @@ package apiv1
 type Query struct {
 	// The SQL read query statement
 	Statement string `json:"statement,omitempty"`
-	// The values of query parameters
-	Params []interface{} `json:"params,omitempty"`
-	// The requested response format: * `objects` - Returns the query results as a JSON array of JSON objects. * `table` - Return the query results as a JSON object with columns and rows properties. 
+	// The values of query parameters, either an array of positional values, or an object of named values for :name or @name parameters. Blobs are objects with a base64 encoded blob property.
+	Params interface{} `json:"params,omitempty"`
+	// The requested response format: * `objects` - Returns the query results as a JSON array of JSON objects. * `table` - Return the query results as a JSON object with columns and rows properties. * `csv` - Returns the query results as CSV with a header row. * `ndjson` - Returns the query results as newline delimited JSON objects. * `arrow` - Returns the query results as an Apache Arrow IPC stream. 
 	Format string `json:"format,omitempty"`
 	// Whether to extract the JSON object from the single property of the surrounding JSON object.
 	Extract bool `json:"extract,omitempty"`
 	// Whether to unwrap the returned JSON objects from their surrounding array.
 	Unwrap bool `json:"unwrap,omitempty"`
+	// The cursor returned in the X-Next-Cursor header of the previous page. Setting it or page_size paginates the results.
+	Cursor string `json:"cursor,omitempty"`
+	// The maximum number of rows of a page. Setting it or cursor paginates the results.
+	PageSize int32 `json:"page_size,omitempty"`
+	// The block number to read the state of the tables at, instead of the latest state. Requires the validator to keep a change log of the chain.
+	AtBlock int64 `json:"at_block,omitempty"`
 }
@@ -0,0 +1,29 @@
+/*
+ * Tableland Validator - OpenAPI 3.0
+ *
+ * In Tableland, Validators are the execution unit/actors of the protocol. They have the following responsibilities: - Listen to onchain events to materialize Tableland-compliant SQL queries in a database engine (currently, SQLite by default). - Serve read-queries (e.g., SELECT * FROM foo_69_1) to the external world. - Serve state queries (e.g., list tables, get receipts, etc) to the external world.  In the 1.0.0 release of the Tableland Validator API, we've switched to a design first approach! You can now help us improve the API whether it's by making changes to the definition itself or to the code. That way, with time, we can improve the API in general, and expose some of the new features in OAS3.  The API includes the following endpoints: - `/health`: Returns OK if the validator considers itself healthy. - `/version`: Returns version information about the validator daemon. - `/query`: Returns the results of a SQL read query against the Tableland network. - `/receipt/{chainId}/{transactionHash}`: Returns the status of a given transaction receipt by hash. - `/tables/{chainId}/{tableId}`: Returns information about a single table, including schema information.
+ *
+ * API version: 1.1.0
+ * Contact: carson@textile.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package apiv1
+
+type TableStats struct {
+	// The number of rows of the table.
+	RowCount int64 `json:"row_count"`
+	// The maximum number of rows of tables, if limited.
+	MaxRowCount int64 `json:"max_row_count,omitempty"`
+	// The approximate size in bytes of the table and its indexes on disk, if available.
+	Bytes int64 `json:"bytes,omitempty"`
+	// The number of columns of the table.
+	ColumnCount int32 `json:"column_count"`
+	// The indexes of the table.
+	Indexes []TableIndex `json:"indexes"`
+
+	CreatedAt int64 `json:"created_at"`
+	// The block of the last transaction that modified the table.
+	LastModifiedBlock int64 `json:"last_modified_block,omitempty"`
+	// The hash of the last transaction that modified the table.
+	LastModifiedTransactionHash string `json:"last_modified_transaction_hash,omitempty"`
+}
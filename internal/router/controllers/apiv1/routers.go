@@ -76,6 +76,13 @@ var routes = Routes{
 		QueryByStatementPost,
 	},
 
+	Route{
+		"QueryByStatementBatch",
+		strings.ToUpper("Post"),
+		"/api/v1/query/batch",
+		QueryByStatementBatch,
+	},
+
 	Route{
 		"ReceiptByTransactionHash",
 		strings.ToUpper("Get"),
@@ -83,6 +90,41 @@ var routes = Routes{
 		ReceiptByTransactionHash,
 	},
 
+	Route{
+		"ListReceipts",
+		strings.ToUpper("Get"),
+		"/api/v1/receipts/{chainId}",
+		ListReceipts,
+	},
+
+	Route{
+		"ReceiptsByTransactionHashes",
+		strings.ToUpper("Post"),
+		"/api/v1/receipts",
+		ReceiptsByTransactionHashes,
+	},
+
+	Route{
+		"RelayWrite",
+		strings.ToUpper("Post"),
+		"/api/v1/relay/{chainId}",
+		RelayWrite,
+	},
+
+	Route{
+		"GetStateHash",
+		strings.ToUpper("Get"),
+		"/api/v1/statehash/{chainId}",
+		GetStateHash,
+	},
+
+	Route{
+		"SubscribeToTables",
+		strings.ToUpper("Get"),
+		"/api/v1/subscribe/{chainId}",
+		SubscribeToTables,
+	},
+
 	Route{
 		"GetTableById",
 		strings.ToUpper("Get"),
@@ -90,6 +132,41 @@ var routes = Routes{
 		GetTableById,
 	},
 
+	Route{
+		"GetTableAcl",
+		strings.ToUpper("Get"),
+		"/api/v1/tables/{chainId}/{tableId}/acl",
+		GetTableAcl,
+	},
+
+	Route{
+		"GetTableStats",
+		strings.ToUpper("Get"),
+		"/api/v1/tables/{chainId}/{tableId}/stats",
+		GetTableStats,
+	},
+
+	Route{
+		"GetTableImage",
+		strings.ToUpper("Get"),
+		"/api/v1/tables/{chainId}/{tableId}/image",
+		GetTableImage,
+	},
+
+	Route{
+		"GetTableEvents",
+		strings.ToUpper("Get"),
+		"/api/v1/tables/{chainId}/{tableId}/events",
+		GetTableEvents,
+	},
+
+	Route{
+		"ListTables",
+		strings.ToUpper("Get"),
+		"/api/v1/tables/{chainId}",
+		ListTables,
+	},
+
 	Route{
 		"Version",
 		strings.ToUpper("Get"),
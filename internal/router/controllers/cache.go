@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
+	"github.com/textileio/go-tableland/internal/tableland"
+)
+
+// cacheControl returns the Cache-Control header of responses with an ETag. Without a max age, caches must
+// revalidate the response on every request.
+func (c *Controller) cacheControl() string {
+	if c.cacheMaxAge <= 0 {
+		return "public, no-cache"
+	}
+	return fmt.Sprintf("public, max-age=%d", int64(c.cacheMaxAge.Seconds()))
+}
+
+// conditionalChainResponse is conditionalResponse for responses that only depend on the chain of the request.
+func (c *Controller) conditionalChainResponse(rw http.ResponseWriter, r *http.Request) (http.ResponseWriter, bool) {
+	ctx := r.Context()
+	chainID := ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID)
+	heights, err := c.gateway.GetBlockHeights(ctx, []tableland.ChainID{chainID})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("get block heights for etag")
+		return rw, false
+	}
+	return c.conditionalResponse(rw, r, heights)
+}
+
+// conditionalResponse handles conditional GET requests whose response only changes when the chains
+// processed new blocks. The ETag identifies the request and the block heights of the chains. If it matches
+// If-None-Match, a 304 is sent and true is returned. Otherwise, the returned writer must be used to send the
+// response, so the ETag and Cache-Control headers are added to it if it's successful. Without heights,
+// the response isn't cacheable.
+func (c *Controller) conditionalResponse(
+	rw http.ResponseWriter, r *http.Request, heights gateway.BlockHeights,
+) (http.ResponseWriter, bool) {
+	if r.Method != http.MethodGet || len(heights) == 0 {
+		return rw, false
+	}
+
+	etag := responseETag(r, heights)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		rw.Header().Set("ETag", etag)
+		rw.Header().Set("Cache-Control", c.cacheControl())
+		rw.WriteHeader(http.StatusNotModified)
+		return rw, true
+	}
+
+	return &cacheableResponseWriter{ResponseWriter: rw, etag: etag, cacheControl: c.cacheControl()}, false
+}
+
+// responseETag returns a strong ETag for the response of a request at the provided block heights.
+func responseETag(r *http.Request, heights gateway.BlockHeights) string {
+	chainHeights := make([]string, 0, len(heights))
+	for chainID, height := range heights {
+		chainHeights = append(chainHeights, strconv.FormatInt(int64(chainID), 10)+":"+strconv.FormatInt(height, 10))
+	}
+	sort.Strings(chainHeights)
+
+	h := sha256.New()
+	_, _ = h.Write([]byte(r.URL.Path))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(r.URL.RawQuery))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(strings.Join(chainHeights, ",")))
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header matches the ETag, using the weak comparison.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheableResponseWriter adds the cache validators to successful responses.
+type cacheableResponseWriter struct {
+	http.ResponseWriter
+	etag         string
+	cacheControl string
+	wroteHeader  bool
+}
+
+func (w *cacheableResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if status == http.StatusOK {
+			w.Header().Set("ETag", w.etag)
+			w.Header().Set("Cache-Control", w.cacheControl)
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *cacheableResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *cacheableResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/mocks"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+func TestConditionalQuery(t *testing.T) {
+	t.Parallel()
+
+	stmt := "select * from foo_1337_1"
+	g := mocks.NewGateway(t)
+	g.EXPECT().GetReadQueryHeights(mock.Anything, stmt).Return(gateway.BlockHeights{1337: 10}, nil).Twice()
+	g.EXPECT().GetReadQueryHeights(mock.Anything, stmt).Return(gateway.BlockHeights{1337: 11}, nil).Once()
+	g.EXPECT().GetReadQueryHeights(mock.Anything, "select 1").Return(gateway.BlockHeights{}, nil).Once()
+	expectStreamReadQuery(g, &gateway.TableData{
+		Columns: []gateway.Column{{Name: "id"}},
+		Rows:    [][]*gateway.ColumnValue{{gateway.OtherColValue(1)}},
+	})
+	ctrl := NewController(g)
+
+	get := func(stmt string, etag string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", "/api/v1/query?statement="+stmt, nil)
+		require.NoError(t, err)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rr := httptest.NewRecorder()
+		ctrl.GetTableQuery(rr, req)
+		return rr
+	}
+
+	rr := get("select%20*%20from%20foo_1337_1", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "public, no-cache", rr.Header().Get("Cache-Control"))
+	etag := rr.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// The tables didn't change, so the client's copy is still valid.
+	rr = get("select%20*%20from%20foo_1337_1", "W/"+etag)
+	require.Equal(t, http.StatusNotModified, rr.Code)
+	require.Equal(t, etag, rr.Header().Get("ETag"))
+	require.Empty(t, rr.Body.String())
+
+	// A new block was processed.
+	rr = get("select%20*%20from%20foo_1337_1", etag)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NotEmpty(t, rr.Header().Get("ETag"))
+	require.NotEqual(t, etag, rr.Header().Get("ETag"))
+
+	// Without block heights, the response isn't cacheable.
+	rr = get("select%201", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Empty(t, rr.Header().Get("ETag"))
+	require.Empty(t, rr.Header().Get("Cache-Control"))
+}
+
+func TestConditionalGetTable(t *testing.T) {
+	t.Parallel()
+
+	id, _ := tables.NewTableID("100")
+	g := mocks.NewGateway(t)
+	g.EXPECT().GetBlockHeights(mock.Anything, []tableland.ChainID{1337}).Return(gateway.BlockHeights{1337: 10}, nil)
+	g.EXPECT().GetTableMetadata(mock.Anything, tableland.ChainID(1337), id).
+		Return(gateway.TableMetadata{Name: "foo_1337_100"}, nil).Once()
+	g.EXPECT().GetTableMetadata(mock.Anything, tableland.ChainID(1337), mock.Anything).
+		Return(gateway.TableMetadata{}, gateway.ErrTableNotFound).Once()
+	ctrl := NewController(g, WithCacheMaxAge(5*time.Second))
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/tables/{chainId}/{tableId}", ctrl.GetTable)
+	get := func(path string, etag string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		require.NoError(t, err)
+		req = req.WithContext(context.WithValue(req.Context(), middlewares.ContextKeyChainID, tableland.ChainID(1337)))
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get("/api/v1/tables/1337/100", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "public, max-age=5", rr.Header().Get("Cache-Control"))
+	etag := rr.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	rr = get("/api/v1/tables/1337/100", `"other", `+etag)
+	require.Equal(t, http.StatusNotModified, rr.Code)
+
+	// Other tables have other ETags, and errors aren't cacheable.
+	rr = get("/api/v1/tables/1337/101", etag)
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	require.Empty(t, rr.Header().Get("ETag"))
+	require.Empty(t, rr.Header().Get("Cache-Control"))
+}
@@ -1,37 +1,124 @@
 package controllers
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
+	"math"
+	"math/big"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
 	"github.com/textileio/go-tableland/buildinfo"
 	"github.com/textileio/go-tableland/internal/formatter"
 	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/internal/graphql"
 	"github.com/textileio/go-tableland/internal/router/controllers/apiv1"
 	"github.com/textileio/go-tableland/internal/router/middlewares"
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/attestation"
 	"github.com/textileio/go-tableland/pkg/errors"
+	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/relay"
 	"github.com/textileio/go-tableland/pkg/tables"
 	"github.com/textileio/go-tableland/pkg/telemetry"
+	"github.com/textileio/go-tableland/pkg/wallet"
+)
+
+const (
+	// readResponseBufferSize is the size of the chunks in which read query results are sent.
+	readResponseBufferSize = 32 << 10
+
+	// maxReceiptLookups is the maximum number of transactions looked up in a single receipts request.
+	maxReceiptLookups = 100
+
+	// maxSignedReadAttempts is the number of times a signed read query is run when the tables change while
+	// reading them.
+	maxSignedReadAttempts = 3
 )
 
 // Controller defines the HTTP handlers for interacting with user tables.
 type Controller struct {
 	gateway gateway.Gateway
+	graphql *graphql.Executor
+
+	maxReadRowCount     int
+	maxReadResponseSize int
+	supportedChainIDs   []tableland.ChainID
+	cacheMaxAge         time.Duration
+	relay               relay.Relay
+	signer              *wallet.Wallet
+}
+
+// ControllerOption modifies the configuration of a Controller.
+type ControllerOption func(*Controller)
+
+// WithMaxReadRowCount limits the number of rows returned by a read query. Zero means no limit.
+func WithMaxReadRowCount(count int) ControllerOption {
+	return func(c *Controller) {
+		c.maxReadRowCount = count
+	}
+}
+
+// WithMaxReadResponseSize limits the size in bytes of the response of a read query. Zero means no limit.
+func WithMaxReadResponseSize(size int) ControllerOption {
+	return func(c *Controller) {
+		c.maxReadResponseSize = size
+	}
+}
+
+// WithSupportedChainIDs sets the chains that can be referenced in the body of a request. By default, any chain
+// is accepted.
+func WithSupportedChainIDs(chainIDs []tableland.ChainID) ControllerOption {
+	return func(c *Controller) {
+		c.supportedChainIDs = chainIDs
+	}
+}
+
+// WithCacheMaxAge sets how long caches can serve read responses without revalidating them. By default,
+// they must revalidate them on every request.
+func WithCacheMaxAge(maxAge time.Duration) ControllerOption {
+	return func(c *Controller) {
+		c.cacheMaxAge = maxAge
+	}
+}
+
+// WithRelay enables relaying writes signed by users. By default, relay requests are rejected.
+func WithRelay(r relay.Relay) ControllerOption {
+	return func(c *Controller) {
+		c.relay = r
+	}
+}
+
+// WithSignedReads signs the responses of read queries with the wallet, so consumers can prove what the
+// validator returned. The signature is sent in the response headers. By default, responses aren't signed.
+func WithSignedReads(w *wallet.Wallet) ControllerOption {
+	return func(c *Controller) {
+		c.signer = w
+	}
 }
 
 // NewController creates a new Controller.
-func NewController(gateway gateway.Gateway) *Controller {
-	return &Controller{
+func NewController(gateway gateway.Gateway, opts ...ControllerOption) *Controller {
+	c := &Controller{
 		gateway: gateway,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.graphql = graphql.NewExecutor(gateway, graphql.WithMaxRowCount(c.maxReadRowCount))
+	return c
 }
 
 // MetadataConfig defines columns should be mapped to erc721 metadata
@@ -101,27 +188,9 @@ func (c *Controller) GetReceiptByTransactionHash(rw http.ResponseWriter, r *http
 	ctx := r.Context()
 
 	paramTxnHash := mux.Vars(r)["transactionHash"]
-	if _, err := common.ParseHexOrString(paramTxnHash); err != nil {
-		rw.Header().Set("Content-Type", "application/json")
-		rw.WriteHeader(http.StatusBadRequest)
-		log.Ctx(ctx).Error().Err(err).Msg("invalid transaction hash")
-		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid transaction hash"})
-		return
-	}
-	txnHash := common.HexToHash(paramTxnHash)
-
-	receipt, exists, err := c.gateway.GetReceiptByTransactionHash(
-		ctx, ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID), txnHash,
-	)
-	if err != nil {
-		rw.Header().Set("Content-Type", "application/json")
-		rw.WriteHeader(http.StatusBadRequest)
-		log.Ctx(ctx).Error().Err(err).Msg("get receipt by transaction hash")
-		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Get receipt by transaction hash failed"})
-		return
-	}
-	if !exists {
-		rw.WriteHeader(http.StatusNotFound)
+	receipt, reqErr := c.receipt(ctx, ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID), paramTxnHash)
+	if reqErr != nil {
+		writeRequestError(rw, reqErr)
 		return
 	}
 
@@ -135,7 +204,9 @@ func (c *Controller) GetReceiptByTransactionHash(rw http.ResponseWriter, r *http
 	}
 	if receipt.Error != nil {
 		receiptResponse.Error_ = *receipt.Error
-		receiptResponse.ErrorEventIdx = int32(*receipt.ErrorEventIdx)
+		if receipt.ErrorEventIdx != nil {
+			receiptResponse.ErrorEventIdx = int32(*receipt.ErrorEventIdx)
+		}
 	}
 
 	ids := make([]string, len(receipt.TableIDs))
@@ -150,38 +221,271 @@ func (c *Controller) GetReceiptByTransactionHash(rw http.ResponseWriter, r *http
 	_ = json.NewEncoder(rw).Encode(receiptResponse)
 }
 
-// GetTable handles the GET /tables/{chainID}/{tableId} call.
-func (c *Controller) GetTable(rw http.ResponseWriter, r *http.Request) {
+// GetReceiptsByTransactionHashes handles the POST /receipts call. The body is a list of transactions, and the
+// response has the result of looking up the receipt of each one of them, in the same order.
+func (c *Controller) GetReceiptsByTransactionHashes(rw http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	vars := mux.Vars(r)
+	rw.Header().Set("Content-Type", "application/json")
 
-	id, err := tables.NewTableID(vars["tableId"])
+	var lookups []apiv1.TransactionLookup
+	if err := json.NewDecoder(r.Body).Decode(&lookups); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		msg := fmt.Sprintf("Error parsing the body request: %v", err)
+		log.Ctx(ctx).Error().Err(err).Msg(msg)
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
+		return
+	}
+	_ = r.Body.Close()
+	if len(lookups) > maxReceiptLookups {
+		rw.WriteHeader(http.StatusBadRequest)
+		msg := fmt.Sprintf("Too many transactions, the maximum is %d", maxReceiptLookups)
+		log.Ctx(ctx).Error().Int("count", len(lookups)).Msg(msg)
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
+		return
+	}
+
+	receiptLookups := make([]gateway.ReceiptLookup, len(lookups))
+	for i, lookup := range lookups {
+		if !c.isSupportedChainID(tableland.ChainID(lookup.ChainId)) {
+			rw.WriteHeader(http.StatusBadRequest)
+			msg := fmt.Sprintf("Unsupported chain id %d", lookup.ChainId)
+			log.Ctx(ctx).Error().Int32("chain_id", lookup.ChainId).Msg(msg)
+			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
+			return
+		}
+		if _, err := common.ParseHexOrString(lookup.TransactionHash); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			msg := fmt.Sprintf("Invalid transaction hash %s", lookup.TransactionHash)
+			log.Ctx(ctx).Error().Err(err).Msg(msg)
+			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
+			return
+		}
+		receiptLookups[i] = gateway.ReceiptLookup{
+			ChainID: tableland.ChainID(lookup.ChainId),
+			TxnHash: common.HexToHash(lookup.TransactionHash),
+		}
+	}
+
+	found, err := c.gateway.GetReceiptsByTransactionHashes(ctx, receiptLookups)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).Error().Err(err).Msg("get receipts by transaction hashes")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Get receipt by transaction hash failed"})
+		return
+	}
+	receipts := make(map[gateway.ReceiptLookup]gateway.Receipt, len(found))
+	for _, receipt := range found {
+		receipts[gateway.ReceiptLookup{ChainID: receipt.ChainID, TxnHash: common.HexToHash(receipt.TxnHash)}] = receipt
+	}
+
+	results := make([]apiv1.ReceiptLookupResult, len(lookups))
+	for i, lookup := range lookups {
+		receipt, exists := receipts[receiptLookups[i]]
+		results[i] = apiv1.ReceiptLookupResult{
+			ChainId:         lookup.ChainId,
+			TransactionHash: lookup.TransactionHash,
+			Found:           exists,
+		}
+		if exists {
+			apiReceipt := toAPIReceipt(receipt)
+			apiReceipt.TransactionHash = lookup.TransactionHash
+			results[i].Receipt = &apiReceipt
+		}
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(results)
+}
+
+// RelayWrite handles the POST /relay/{chainId} call.
+func (c *Controller) RelayWrite(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rw.Header().Set("Content-Type", "application/json")
+
+	if c.relay == nil {
+		rw.WriteHeader(http.StatusNotFound)
+		log.Ctx(ctx).Error().Msg("relay is not enabled")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Relay is not enabled"})
+		return
+	}
+
+	var body apiv1.SignedWrite
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		msg := fmt.Sprintf("Error parsing the body request: %v", err)
+		log.Ctx(ctx).Error().Err(err).Msg(msg)
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
+		return
+	}
+	_ = r.Body.Close()
+
+	sw, err := signedWriteFromBody(ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID), body)
 	if err != nil {
-		rw.Header().Set("Content-type", "application/json")
 		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).Error().Err(err).Msg("invalid signed write")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: err.Error()})
+		return
+	}
+
+	submission, err := c.relay.Submit(ctx, sw)
+	if err != nil {
+		status, msg := relayErrorStatus(err)
+		rw.WriteHeader(status)
+		log.Ctx(ctx).Error().Err(err).Str("table_id", body.TableId).Msg("relaying write")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(apiv1.RelayedWrite{
+		TransactionHash: submission.TxnHash.Hex(),
+		Signer:          submission.Signer.Hex(),
+	})
+}
+
+func signedWriteFromBody(chainID tableland.ChainID, body apiv1.SignedWrite) (relay.SignedWrite, error) {
+	tableID, err := tables.NewTableID(body.TableId)
+	if err != nil {
+		return relay.SignedWrite{}, fmt.Errorf("invalid table id %s", body.TableId)
+	}
+	nonce, ok := new(big.Int).SetString(body.Nonce, 10)
+	if !ok || nonce.Sign() < 0 {
+		return relay.SignedWrite{}, fmt.Errorf("invalid nonce %s", body.Nonce)
+	}
+	signature, err := hexutil.Decode(body.Signature)
+	if err != nil {
+		return relay.SignedWrite{}, fmt.Errorf("invalid signature encoding: %s", err)
+	}
+
+	return relay.SignedWrite{
+		Write: relay.Write{
+			ChainID:   chainID,
+			TableID:   tableID,
+			Statement: body.Statement,
+			Nonce:     nonce,
+			Deadline:  body.Deadline,
+		},
+		Signature: signature,
+	}, nil
+}
+
+// relayErrorStatus returns the HTTP status and the message for the client of a failed relay submission.
+func relayErrorStatus(err error) (int, string) {
+	switch {
+	case goerrors.Is(err, relay.ErrInvalidSignature):
+		return http.StatusUnauthorized, err.Error()
+	case goerrors.Is(err, relay.ErrNotAllowed):
+		return http.StatusForbidden, err.Error()
+	case goerrors.Is(err, relay.ErrNonceUsed):
+		return http.StatusConflict, err.Error()
+	case goerrors.Is(err, relay.ErrQuotaExceeded), goerrors.Is(err, relay.ErrBudgetExceeded):
+		return http.StatusTooManyRequests, err.Error()
+	case goerrors.Is(err, relay.ErrExpired),
+		goerrors.Is(err, relay.ErrInvalidStatement),
+		goerrors.Is(err, relay.ErrUnsupportedChain):
+		return http.StatusBadRequest, err.Error()
+	default:
+		return http.StatusInternalServerError, "Relaying write failed"
+	}
+}
+
+func (c *Controller) isSupportedChainID(chainID tableland.ChainID) bool {
+	if c.supportedChainIDs == nil {
+		return true
+	}
+	for _, supportedChainID := range c.supportedChainIDs {
+		if chainID == supportedChainID {
+			return true
+		}
+	}
+	return false
+}
+
+// requestError is a failed request, with the HTTP status and the message for the client. An empty message
+// means the response has no body.
+type requestError struct {
+	status  int
+	message string
+}
+
+func writeRequestError(rw http.ResponseWriter, reqErr *requestError) {
+	if reqErr.message == "" {
+		rw.WriteHeader(reqErr.status)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(reqErr.status)
+	_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: reqErr.message})
+}
+
+// receipt validates the transaction hash and looks up its receipt.
+func (c *Controller) receipt(
+	ctx context.Context, chainID tableland.ChainID, txnHash string,
+) (gateway.Receipt, *requestError) {
+	if _, err := common.ParseHexOrString(txnHash); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("invalid transaction hash")
+		return gateway.Receipt{}, &requestError{status: http.StatusBadRequest, message: "Invalid transaction hash"}
+	}
+
+	receipt, exists, err := c.gateway.GetReceiptByTransactionHash(ctx, chainID, common.HexToHash(txnHash))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("get receipt by transaction hash")
+		return gateway.Receipt{}, &requestError{
+			status:  http.StatusBadRequest,
+			message: "Get receipt by transaction hash failed",
+		}
+	}
+	if !exists {
+		return gateway.Receipt{}, &requestError{status: http.StatusNotFound}
+	}
+	return receipt, nil
+}
+
+// tableMetadata validates the table id and fetches the table metadata.
+func (c *Controller) tableMetadata(
+	ctx context.Context, chainID tableland.ChainID, tableID string,
+) (gateway.TableMetadata, *requestError) {
+	id, err := tables.NewTableID(tableID)
+	if err != nil {
 		log.Ctx(ctx).
 			Error().
 			Err(err).
 			Msg("invalid id format")
 
-		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid id format"})
-		return
+		return gateway.TableMetadata{}, &requestError{status: http.StatusBadRequest, message: "Invalid id format"}
 	}
-	metadata, err := c.gateway.GetTableMetadata(ctx, ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID), id)
+	metadata, err := c.gateway.GetTableMetadata(ctx, chainID, id)
 	if err == gateway.ErrTableNotFound {
-		rw.WriteHeader(http.StatusNotFound)
-		return
+		return gateway.TableMetadata{}, &requestError{status: http.StatusNotFound}
 	}
 	if err != nil {
-		rw.Header().Set("Content-type", "application/json")
-		rw.WriteHeader(http.StatusInternalServerError)
 		log.Ctx(ctx).
 			Error().
 			Err(err).
 			Str("id", id.String()).
 			Msg("failed to fetch metadata")
 
-		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to fetch metadata"})
+		return gateway.TableMetadata{}, &requestError{
+			status:  http.StatusInternalServerError,
+			message: "Failed to fetch metadata",
+		}
+	}
+	return metadata, nil
+}
+
+// GetTable handles the GET /tables/{chainID}/{tableId} call.
+func (c *Controller) GetTable(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	rw, notModified := c.conditionalChainResponse(rw, r)
+	if notModified {
+		return
+	}
+
+	metadata, reqErr := c.tableMetadata(ctx, ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID), vars["tableId"])
+	if reqErr != nil {
+		writeRequestError(rw, reqErr)
 		return
 	}
 
@@ -220,164 +524,1078 @@ func (c *Controller) GetTable(rw http.ResponseWriter, r *http.Request) {
 	_ = enc.Encode(metadataV1)
 }
 
-// HealthHandler serves health check requests.
-func HealthHandler(w http.ResponseWriter, _ *http.Request) {
-	w.WriteHeader(http.StatusOK)
-}
-
-// GetTableQuery handles the GET /query?statement=[statement] call.
-// Use format=objects|table query param to control output format.
-func (c *Controller) GetTableQuery(rw http.ResponseWriter, r *http.Request) {
-	rw.Header().Set("Content-Type", "application/json")
-
-	stm := r.URL.Query().Get("statement")
-	params := []string{}
-
-	if r.URL.Query().Has("params") {
-		params = r.URL.Query()["params"]
-	}
+// GetTableACL handles the GET /tables/{chainID}/{tableId}/acl call.
+func (c *Controller) GetTableACL(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
 
-	start := time.Now()
-	res, ok := c.runReadRequest(r.Context(), stm, params, rw)
-	if !ok {
+	rw, notModified := c.conditionalChainResponse(rw, r)
+	if notModified {
 		return
 	}
-	took := time.Since(start)
 
-	opts, err := formatterOptions(r)
+	rw.Header().Set("Content-type", "application/json")
+	id, err := tables.NewTableID(vars["tableId"])
 	if err != nil {
 		rw.WriteHeader(http.StatusBadRequest)
-		msg := fmt.Sprintf("Error parsing formatting params: %v", err)
-		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
-		log.Ctx(r.Context()).Error().Err(err).Msg(msg)
+		log.Ctx(ctx).
+			Error().
+			Err(err).
+			Msg("invalid id format")
+
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid id format"})
 		return
 	}
-	formatted, config, err := formatter.Format(res, opts...)
-	if err != nil {
-		rw.WriteHeader(http.StatusInternalServerError)
-		msg := fmt.Sprintf("Error formatting data: %v", err)
-		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
-		log.Ctx(r.Context()).Error().Err(err).Msg(msg)
+	acl, err := c.gateway.GetTableACL(ctx, ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID), id)
+	if err == gateway.ErrTableNotFound {
+		rw.WriteHeader(http.StatusNotFound)
 		return
 	}
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).
+			Error().
+			Err(err).
+			Str("id", id.String()).
+			Msg("failed to fetch acl")
 
-	if len(formatted) == 0 {
-		rw.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to fetch acl"})
 		return
 	}
 
-	collectReadQueryMetric(r.Context(), stm, config, took)
+	aclV1 := apiv1.TableAcl{
+		Controller: acl.Controller,
+		Rules:      make([]apiv1.AclRule, len(acl.Rules)),
+	}
+	for i, rule := range acl.Rules {
+		aclV1.Rules[i] = apiv1.AclRule{
+			Controller: rule.Controller.Hex(),
+			Privileges: make([]string, len(rule.Privileges)),
+			CreatedAt:  rule.CreatedAt.Unix(),
+		}
+		for j, privilege := range rule.Privileges {
+			aclV1.Rules[i].Privileges[j] = privilege.ToSQLString()
+		}
+		if rule.UpdatedAt != nil {
+			aclV1.Rules[i].UpdatedAt = rule.UpdatedAt.Unix()
+		}
+	}
 
 	rw.WriteHeader(http.StatusOK)
-	if config.Unwrap && len(res.Rows) > 1 {
-		rw.Header().Set("Content-Type", "application/jsonl+json")
-	}
-	_, _ = rw.Write(formatted)
+	_ = json.NewEncoder(rw).Encode(aclV1)
 }
 
-// PostTableQuery handles the POST /query call.
-func (c *Controller) PostTableQuery(rw http.ResponseWriter, r *http.Request) {
-	rw.Header().Set("Content-Type", "application/json")
+// GetTableStats handles the GET /tables/{chainID}/{tableId}/stats call.
+func (c *Controller) GetTableStats(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
 
-	// setting a default body because these options could be missing from JSON
-	body := &apiv1.Query{
-		Params:  []any{},
-		Format:  string(formatter.Objects),
-		Extract: false,
-		Unwrap:  false,
+	rw, notModified := c.conditionalChainResponse(rw, r)
+	if notModified {
+		return
 	}
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+
+	rw.Header().Set("Content-type", "application/json")
+	id, err := tables.NewTableID(vars["tableId"])
+	if err != nil {
 		rw.WriteHeader(http.StatusBadRequest)
-		msg := fmt.Sprintf("Error parsing the body request: %v", err)
-		log.Ctx(r.Context()).Error().Err(err).Msg(msg)
-		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
+		log.Ctx(ctx).
+			Error().
+			Err(err).
+			Msg("invalid id format")
+
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid id format"})
 		return
 	}
-	_ = r.Body.Close()
-
-	params := make([]string, len(body.Params))
-	for i, p := range body.Params {
-		switch v := p.(type) {
-		case float64:
-			params[i] = fmt.Sprint(v)
-		case string:
-			params[i] = fmt.Sprintf("\"%s\"", v)
-		case nil:
-			params[i] = "null"
-		case bool:
-			params[i] = "false"
-			if v {
-				params[i] = "true"
-			}
-		default:
-			rw.WriteHeader(http.StatusBadRequest)
-			msg := fmt.Sprintf("invalid type (%T) of parameter", v)
-			log.Ctx(r.Context()).Error().Msg(msg)
-			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
-			return
-		}
+	stats, err := c.gateway.GetTableStats(ctx, ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID), id)
+	if err == gateway.ErrTableNotFound {
+		rw.WriteHeader(http.StatusNotFound)
+		return
 	}
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).
+			Error().
+			Err(err).
+			Str("id", id.String()).
+			Msg("failed to fetch table stats")
 
-	start := time.Now()
-	res, ok := c.runReadRequest(r.Context(), body.Statement, params, rw)
-	if !ok {
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to fetch table stats"})
+		return
+	}
+
+	statsV1 := apiv1.TableStats{
+		RowCount:                    stats.RowCount,
+		MaxRowCount:                 int64(stats.MaxRowCount),
+		ColumnCount:                 int32(stats.ColumnCount),
+		Indexes:                     make([]apiv1.TableIndex, len(stats.Indexes)),
+		CreatedAt:                   stats.CreatedAt.Unix(),
+		LastModifiedBlock:           stats.LastModifiedBlock,
+		LastModifiedTransactionHash: stats.LastModifiedTxnHash,
+	}
+	if stats.Bytes != nil {
+		statsV1.Bytes = *stats.Bytes
+	}
+	for i, index := range stats.Indexes {
+		statsV1.Indexes[i] = apiv1.TableIndex{
+			Name:    index.Name,
+			Unique:  index.Unique,
+			Columns: index.Columns,
+		}
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(statsV1)
+}
+
+// GetTableImage handles the GET /tables/{chainId}/{tableId}/image call.
+func (c *Controller) GetTableImage(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	rw, notModified := c.conditionalChainResponse(rw, r)
+	if notModified {
+		return
+	}
+
+	id, err := tables.NewTableID(vars["tableId"])
+	if err != nil {
+		rw.Header().Set("Content-type", "application/json")
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).
+			Error().
+			Err(err).
+			Msg("invalid id format")
+
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid id format"})
+		return
+	}
+	image, err := c.gateway.GetTableImage(ctx, ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID), id)
+	if err == gateway.ErrTableNotFound {
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		rw.Header().Set("Content-type", "application/json")
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).
+			Error().
+			Err(err).
+			Str("id", id.String()).
+			Msg("failed to render table image")
+
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to render table image"})
+		return
+	}
+
+	rw.Header().Set("Content-type", "image/svg+xml")
+	rw.WriteHeader(http.StatusOK)
+	_, _ = rw.Write(image)
+}
+
+// GetTableEvents handles the GET /tables/{chainId}/{tableId}/events?cursor=[cursor] call.
+func (c *Controller) GetTableEvents(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	rw, notModified := c.conditionalChainResponse(rw, r)
+	if notModified {
+		return
+	}
+
+	rw.Header().Set("Content-type", "application/json")
+	id, err := tables.NewTableID(vars["tableId"])
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).
+			Error().
+			Err(err).
+			Msg("invalid id format")
+
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid id format"})
+		return
+	}
+
+	chainID := ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID)
+	events, nextCursor, err := c.gateway.GetTableEvents(ctx, chainID, id, r.URL.Query().Get("cursor"))
+	if err == gateway.ErrInvalidCursor {
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).Error().Err(err).Msg("invalid cursor")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid cursor"})
+		return
+	}
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).
+			Error().
+			Err(err).
+			Str("id", id.String()).
+			Msg("failed to fetch table events")
+
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to fetch table events"})
+		return
+	}
+
+	page := apiv1.TableEventsPage{
+		Events:     make([]apiv1.TableEvent, len(events)),
+		NextCursor: nextCursor,
+	}
+	for i, event := range events {
+		page.Events[i] = apiv1.TableEvent{
+			BlockNumber:      event.BlockNumber,
+			TransactionHash:  event.TxnHash,
+			TransactionIndex: int64(event.TxnIndex),
+			EventIndex:       int64(event.EventIndex),
+			EventType:        event.EventType,
+			Event:            event.EventJSON,
+			Status:           "pending",
+		}
+		if event.BlockTimestamp != nil {
+			page.Events[i].BlockTimestamp = event.BlockTimestamp.Unix()
+		}
+		if event.Executed {
+			page.Events[i].Status = "success"
+		}
+		if event.Error != nil {
+			page.Events[i].Status = "failed"
+			page.Events[i].Error_ = *event.Error
+			page.Events[i].ErrorEventIdx = int32(*event.ErrorEventIdx)
+		}
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(page)
+}
+
+// GetStateHash handles the GET /statehash/{chainId}?block=[block] call. It returns the last state hash calculated
+// at or before the block, or the last one if the block isn't provided.
+func (c *Controller) GetStateHash(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	blockNumber := int64(math.MaxInt64)
+	if v := r.URL.Query().Get("block"); v != "" {
+		var err error
+		blockNumber, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || blockNumber <= 0 {
+			rw.WriteHeader(http.StatusBadRequest)
+			log.Ctx(ctx).Error().Str("block", v).Msg("invalid block number")
+			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid block number"})
+			return
+		}
+	}
+
+	rw, notModified := c.conditionalChainResponse(rw, r)
+	if notModified {
+		return
+	}
+
+	stateHash, exists, err := c.gateway.GetStateHash(
+		ctx, ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID), blockNumber,
+	)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).Error().Err(err).Int64("block", blockNumber).Msg("failed to get state hash")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to get state hash"})
+		return
+	}
+	if !exists {
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(apiv1.StateHash{
+		ChainId:     int64(stateHash.ChainID),
+		BlockNumber: stateHash.BlockNumber,
+		Hash:        stateHash.Hash,
+		CreatedAt:   stateHash.CreatedAt.Unix(),
+	})
+}
+
+// ListReceipts handles the GET /receipts/{chainId}?from_block=[block]&to_block=[block]&table_id=[id]&cursor=[cursor]
+// call. All the query params are optional.
+func (c *Controller) ListReceipts(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	filter, err := receiptFilterFromQuery(r.URL.Query())
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).Error().Err(err).Msg("invalid receipts filter")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: fmt.Sprintf("Invalid filter: %s", err)})
+		return
+	}
+
+	chainID := ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID)
+	receipts, nextCursor, err := c.gateway.ListReceipts(ctx, chainID, filter, r.URL.Query().Get("cursor"))
+	if err == gateway.ErrInvalidCursor {
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).Error().Err(err).Msg("invalid cursor")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid cursor"})
+		return
+	}
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).
+			Error().
+			Err(err).
+			Msg("failed to list receipts")
+
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to list receipts"})
+		return
+	}
+
+	page := apiv1.ReceiptsPage{
+		Receipts:   make([]apiv1.TransactionReceipt, len(receipts)),
+		NextCursor: nextCursor,
+	}
+	for i, receipt := range receipts {
+		page.Receipts[i] = toAPIReceipt(receipt)
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(page)
+}
+
+func receiptFilterFromQuery(query url.Values) (gateway.ReceiptFilter, error) {
+	var filter gateway.ReceiptFilter
+	for _, param := range []struct {
+		name  string
+		block *int64
+	}{{"from_block", &filter.FromBlock}, {"to_block", &filter.ToBlock}} {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 {
+			return gateway.ReceiptFilter{}, fmt.Errorf("%s must be a non-negative integer", param.name)
+		}
+		*param.block = n
+	}
+	if filter.ToBlock > 0 && filter.ToBlock < filter.FromBlock {
+		return gateway.ReceiptFilter{}, fmt.Errorf("to_block is lower than from_block")
+	}
+
+	if value := query.Get("table_id"); value != "" {
+		id, err := tables.NewTableID(value)
+		if err != nil {
+			return gateway.ReceiptFilter{}, fmt.Errorf("table_id: %s", err)
+		}
+		filter.TableID = &id
+	}
+
+	return filter, nil
+}
+
+// ListTables handles the GET /tables/{chainId}?owner=[owner]&cursor=[cursor] call.
+func (c *Controller) ListTables(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rw, notModified := c.conditionalChainResponse(rw, r)
+	if notModified {
+		return
+	}
+
+	paramOwner := r.URL.Query().Get("owner")
+	if !common.IsHexAddress(paramOwner) {
+		rw.Header().Set("Content-type", "application/json")
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).Error().Str("owner", paramOwner).Msg("invalid owner address")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid owner address"})
+		return
+	}
+	owner := common.HexToAddress(paramOwner)
+
+	chainID := ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID)
+	tbls, nextCursor, err := c.gateway.ListTables(ctx, chainID, owner, r.URL.Query().Get("cursor"))
+	if err == gateway.ErrInvalidCursor {
+		rw.Header().Set("Content-type", "application/json")
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).Error().Err(err).Msg("invalid cursor")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid cursor"})
+		return
+	}
+	if err != nil {
+		rw.Header().Set("Content-type", "application/json")
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).
+			Error().
+			Err(err).
+			Str("owner", owner.Hex()).
+			Msg("failed to list tables")
+
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to list tables"})
+		return
+	}
+
+	page := apiv1.TablesPage{
+		Tables:     make([]apiv1.TableSummary, len(tbls)),
+		NextCursor: nextCursor,
+	}
+	for i, tbl := range tbls {
+		page.Tables[i] = apiv1.TableSummary{
+			Id:         tbl.ID.String(),
+			ChainId:    int32(tbl.ChainID),
+			Name:       tbl.Name(),
+			Controller: tbl.Controller,
+			Structure:  tbl.Structure,
+			CreatedAt:  tbl.CreatedAt.Unix(),
+		}
+	}
+
+	rw.Header().Set("Content-type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(page)
+}
+
+// HealthHandler serves health check requests.
+func HealthHandler(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetTableQuery handles the GET /query?statement=[statement] call.
+// Use format=objects|table query param to control output format.
+func (c *Controller) GetTableQuery(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	stm := r.URL.Query().Get("statement")
+	params := make([]any, len(r.URL.Query()["params"]))
+	for i, p := range r.URL.Query()["params"] {
+		param, err := parsing.ParseParam(p)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			msg := fmt.Sprintf("Invalid param %d: %s", i+1, err)
+			log.Ctx(r.Context()).Error().Err(err).Msg(msg)
+			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
+			return
+		}
+		params[i] = param
+	}
+
+	cursor := r.URL.Query().Get("cursor")
+	pageSize := 0
+	if v := r.URL.Query().Get("page_size"); v != "" {
+		var err error
+		pageSize, err = strconv.Atoi(v)
+		if err != nil || pageSize <= 0 || pageSize > gateway.MaxReadQueryPageSize {
+			rw.WriteHeader(http.StatusBadRequest)
+			log.Ctx(r.Context()).Error().Str("page_size", v).Msg("invalid page size")
+			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid page size"})
+			return
+		}
+	}
+
+	var atBlock int64
+	if v := r.URL.Query().Get("at_block"); v != "" {
+		var err error
+		atBlock, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || atBlock <= 0 {
+			rw.WriteHeader(http.StatusBadRequest)
+			log.Ctx(r.Context()).Error().Str("at_block", v).Msg("invalid block number")
+			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid block number"})
+			return
+		}
+	}
+
+	opts, err := formatterOptions(r)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		msg := fmt.Sprintf("Error parsing formatting params: %v", err)
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
+		log.Ctx(r.Context()).Error().Err(err).Msg(msg)
+		return
+	}
+
+	heights, err := c.gateway.GetReadQueryHeights(r.Context(), stm)
+	if err == nil {
+		var notModified bool
+		if rw, notModified = c.conditionalResponse(rw, r, heights); notModified {
+			return
+		}
+	}
+
+	c.runReadRequest(r.Context(), stm, params, cursor, pageSize, atBlock, opts, rw)
+}
+
+// PostTableQuery handles the POST /query call.
+func (c *Controller) PostTableQuery(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	// setting a default body because these options could be missing from JSON
+	body := &apiv1.Query{
+		Format:  string(formatter.Objects),
+		Extract: false,
+		Unwrap:  false,
+	}
+	dec := json.NewDecoder(r.Body)
+	// Numbers are decoded as json.Number, so integers are bound as integers.
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		msg := fmt.Sprintf("Error parsing the body request: %v", err)
+		log.Ctx(r.Context()).Error().Err(err).Msg(msg)
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
+		return
+	}
+	_ = r.Body.Close()
+
+	stm, params, err := bodyParams(body.Statement, body.Params)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(r.Context()).Error().Msg(err.Error())
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: err.Error()})
+		return
+	}
+
+	if body.PageSize < 0 || body.PageSize > gateway.MaxReadQueryPageSize {
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(r.Context()).Error().Int32("page_size", body.PageSize).Msg("invalid page size")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid page size"})
+		return
+	}
+
+	if body.AtBlock < 0 {
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(r.Context()).Error().Int64("at_block", body.AtBlock).Msg("invalid block number")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Invalid block number"})
 		return
 	}
-	took := time.Since(start)
 
 	var opts []formatter.FormatOption
 	output, ok := formatter.OutputFromString(body.Format)
 	if !ok {
+		rw.WriteHeader(http.StatusBadRequest)
 		log.Ctx(r.Context()).Error().Msg("bad output query parameter")
 		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "bad output query parameter"})
+		return
 	}
 	opts = append(opts, formatter.WithOutput(output))
 	opts = append(opts, formatter.WithExtract(body.Extract))
 	opts = append(opts, formatter.WithUnwrap(body.Unwrap))
 
-	formatted, config, err := formatter.Format(res, opts...)
-	if err != nil {
-		rw.WriteHeader(http.StatusInternalServerError)
-		msg := fmt.Sprintf("Error formatting data: %v", err)
+	c.runReadRequest(r.Context(), stm, params, body.Cursor, int(body.PageSize), body.AtBlock, opts, rw)
+}
+
+// bodyParams returns the statement and the values bound to its parameters from a query body. Params are
+// either an array of positional values, or an object of named values whose parameters are rewritten into
+// positional ones.
+func bodyParams(stm string, bodyParams any) (string, []any, error) {
+	switch bodyParams := bodyParams.(type) {
+	case nil:
+		return stm, []any{}, nil
+	case []any:
+		params := make([]any, len(bodyParams))
+		for i, p := range bodyParams {
+			param, err := queryParam(p)
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid param %d: %s", i+1, err)
+			}
+			params[i] = param
+		}
+		return stm, params, nil
+	case map[string]any:
+		named := make(map[string]any, len(bodyParams))
+		for name, p := range bodyParams {
+			param, err := queryParam(p)
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid param %s: %s", name, err)
+			}
+			named[name] = param
+		}
+		return parsing.BindNamedParams(stm, named)
+	default:
+		return "", nil, fmt.Errorf("params must be an array or an object")
+	}
+}
+
+// queryParam returns the value of a read query parameter, decoded from JSON, as it's bound to the statement.
+// Blobs are objects with a single blob property holding the base64 encoded bytes.
+func queryParam(p any) (any, error) {
+	switch v := p.(type) {
+	case json.Number, string, bool, nil:
+		return v, nil
+	case map[string]any:
+		if blob, ok := v["blob"].(string); ok && len(v) == 1 {
+			b, err := base64.StdEncoding.DecodeString(blob)
+			if err != nil {
+				return nil, fmt.Errorf("invalid blob: %s", err)
+			}
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid type (%T)", p)
+}
+
+// PostTableQueryBatch handles the POST /query/batch call. The queries run on the same state of the tables, and
+// the response has the formatted results or the error of each query, in the order of the queries.
+func (c *Controller) PostTableQueryBatch(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rw.Header().Set("Content-Type", "application/json")
+
+	body := &apiv1.QueryBatch{Format: string(formatter.Objects)}
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		msg := fmt.Sprintf("Error parsing the body request: %v", err)
+		log.Ctx(ctx).Error().Err(err).Msg(msg)
 		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
-		log.Ctx(r.Context()).Error().Err(err).Msg(msg)
 		return
 	}
+	_ = r.Body.Close()
 
-	if len(formatted) == 0 {
-		rw.WriteHeader(http.StatusNotFound)
+	if len(body.Queries) == 0 {
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).Error().Msg("empty query batch")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "No queries to run"})
+		return
+	}
+	if len(body.Queries) > gateway.MaxReadQueryBatchSize {
+		rw.WriteHeader(http.StatusBadRequest)
+		msg := fmt.Sprintf("Too many queries, the maximum is %d", gateway.MaxReadQueryBatchSize)
+		log.Ctx(ctx).Error().Int("count", len(body.Queries)).Msg(msg)
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
 		return
 	}
 
-	collectReadQueryMetric(r.Context(), body.Statement, config, took)
+	output, ok := formatter.OutputFromString(body.Format)
+	if !ok || (output != formatter.Objects && output != formatter.Table) {
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).Error().Str("format", body.Format).Msg("bad batch format")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Batch results must be objects or table"})
+		return
+	}
+
+	results := make([]apiv1.BatchQueryResult, len(body.Queries))
+	queries := make([]gateway.ReadQuery, 0, len(body.Queries))
+	indexes := make([]int, 0, len(body.Queries))
+	for i, q := range body.Queries {
+		stm, params, err := bodyParams(q.Statement, q.Params)
+		if err != nil {
+			results[i].Error_ = err.Error()
+			continue
+		}
+		queries = append(queries, gateway.ReadQuery{Statement: stm, Params: params})
+		indexes = append(indexes, i)
+	}
+
+	w := &batchResultsWriter{
+		results: results,
+		indexes: indexes,
+		opts: []formatter.FormatOption{
+			formatter.WithOutput(output),
+			formatter.WithExtract(body.Extract),
+			formatter.WithMaxRowCount(c.maxReadRowCount),
+		},
+		maxSize:   c.maxReadResponseSize,
+		remaining: c.maxReadResponseSize,
+	}
+	if err := c.gateway.RunReadQueryBatch(ctx, queries, w); err != nil {
+		rw.WriteHeader(readQueryErrorStatus(err))
+		log.Ctx(ctx).Error().Err(err).Msg("executing read query batch")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: err.Error()})
+		return
+	}
 
 	rw.WriteHeader(http.StatusOK)
-	if config.Unwrap && len(res.Rows) > 1 {
-		rw.Header().Set("Content-Type", "application/jsonl+json")
+	_ = json.NewEncoder(rw).Encode(results)
+}
+
+// batchResultsWriter formats the results of the queries of a batch as they are read. The maximum response size
+// applies to the results of all the queries, so every query can only take what the previous ones left, and it
+// stops being read as soon as it exceeds it.
+type batchResultsWriter struct {
+	results []apiv1.BatchQueryResult
+	// indexes are the indexes in results of the queries of the batch.
+	indexes []int
+	opts    []formatter.FormatOption
+
+	maxSize   int
+	remaining int
+
+	buf *bytes.Buffer
+	w   *formatter.StreamWriter
+}
+
+func (b *batchResultsWriter) Begin(i int) gateway.RowWriter {
+	b.buf = &bytes.Buffer{}
+	if b.maxSize > 0 && b.remaining <= 0 {
+		return failingRowWriter{err: &formatter.ErrMaxSizeExceeded{MaxAllowed: b.maxSize}}
 	}
-	_, _ = rw.Write(formatted)
+	b.w = formatter.NewStreamWriter(b.buf, append(b.opts, formatter.WithMaxSize(b.remaining))...)
+	return b.w
 }
 
+func (b *batchResultsWriter) End(i int, err error) {
+	result := &b.results[b.indexes[i]]
+	if err == nil {
+		err = b.w.Close()
+	}
+	var errRowCount *formatter.ErrMaxRowCountExceeded
+	switch {
+	case goerrors.As(err, new(*formatter.ErrMaxSizeExceeded)):
+		err = &formatter.ErrMaxSizeExceeded{MaxAllowed: b.maxSize}
+	case goerrors.As(err, &errRowCount):
+		err = errRowCount
+	}
+	if err != nil {
+		result.Error_ = err.Error()
+		return
+	}
+	result.Result = b.buf.Bytes()
+	if b.maxSize > 0 {
+		b.remaining -= b.buf.Len()
+	}
+}
+
+// failingRowWriter fails every write with an error.
+type failingRowWriter struct {
+	err error
+}
+
+func (w failingRowWriter) WriteColumns([]gateway.Column) error   { return w.err }
+func (w failingRowWriter) WriteRow([]*gateway.ColumnValue) error { return w.err }
+
+// GraphQL handles the GET and POST /graphql calls.
+func (c *Controller) GraphQL(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	var req graphql.Request
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			dec := json.NewDecoder(strings.NewReader(variables))
+			dec.UseNumber()
+			if err := dec.Decode(&req.Variables); err != nil {
+				rw.WriteHeader(http.StatusBadRequest)
+				msg := fmt.Sprintf("Error parsing the variables: %v", err)
+				log.Ctx(r.Context()).Error().Err(err).Msg(msg)
+				_ = json.NewEncoder(rw).Encode(graphql.Response{Errors: []graphql.Error{{Message: msg}}})
+				return
+			}
+		}
+	} else {
+		dec := json.NewDecoder(r.Body)
+		dec.UseNumber()
+		if err := dec.Decode(&req); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			msg := fmt.Sprintf("Error parsing the body request: %v", err)
+			log.Ctx(r.Context()).Error().Err(err).Msg(msg)
+			_ = json.NewEncoder(rw).Encode(graphql.Response{Errors: []graphql.Error{{Message: msg}}})
+			return
+		}
+		_ = r.Body.Close()
+	}
+
+	res, err := c.graphql.Execute(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var errSyntax *graphql.ErrSyntax
+		var errInvalid *graphql.ErrInvalidQuery
+		if goerrors.As(err, &errSyntax) || goerrors.As(err, &errInvalid) {
+			status = http.StatusBadRequest
+		}
+		rw.WriteHeader(status)
+		log.Ctx(r.Context()).Error().Str("graphql_request", req.Query).Err(err).Msg("executing graphql query")
+		_ = json.NewEncoder(rw).Encode(graphql.Response{Errors: []graphql.Error{{Message: err.Error()}}})
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(rw).Encode(res)
+}
+
+// runReadRequest runs a read query and streams the formatted results in chunks, so they are never fully held
+// in memory. Errors found before the first chunk is sent are returned as a JSON error. Since the status is already
+// sent after that, later errors, such as exceeding the maximum response size, abort the response.
+// If atBlock isn't zero, the query reads the state of the tables at that block, which can't be paginated.
 func (c *Controller) runReadRequest(
 	ctx context.Context,
 	stm string,
-	params []string,
+	params []any,
+	cursor string,
+	pageSize int,
+	atBlock int64,
+	opts []formatter.FormatOption,
 	rw http.ResponseWriter,
-) (*gateway.TableData, bool) {
-	res, err := c.gateway.RunReadQuery(ctx, stm, params)
-	if err != nil {
+) {
+	if atBlock > 0 && (cursor != "" || pageSize > 0) {
 		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).Error().Int64("at_block", atBlock).Msg("paginating a read at a block")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Reads at a block can't be paginated"})
+		return
+	}
+
+	opts = append(opts,
+		formatter.WithMaxRowCount(c.maxReadRowCount),
+		formatter.WithMaxSize(c.maxReadResponseSize),
+	)
+	if c.signer != nil {
+		c.runSignedReadRequest(ctx, stm, params, cursor, pageSize, atBlock, opts, rw)
+		return
+	}
+
+	cw := &chunkedResponseWriter{rw: rw}
+	bw := bufio.NewWriterSize(cw, readResponseBufferSize)
+	sw := formatter.NewStreamWriter(bw, opts...)
+	cw.contentType = sw.Config().ContentType()
+
+	start := time.Now()
+	ok, err := c.readQuery(ctx, stm, params, cursor, pageSize, atBlock, sw, rw)
+	if !ok {
+		return
+	}
+	if err == nil {
+		err = sw.Close()
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	took := time.Since(start)
+
+	if err != nil {
 		log.Ctx(ctx).
 			Error().
 			Str("sql_request", stm).
+			Bool("response_started", cw.started).
 			Err(err).
 			Msg("executing read query")
+		if cw.started {
+			panic(http.ErrAbortHandler)
+		}
 
+		rw.WriteHeader(readQueryErrorStatus(err))
 		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: err.Error()})
-		return nil, false
+		return
+	}
+
+	if !cw.started {
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	collectReadQueryMetric(ctx, stm, sw.Config(), took)
+}
+
+// runSignedReadRequest runs a read query like runReadRequest, but the response is sent after it's fully read, so
+// its signature can be sent in the headers. The signature covers the statement, the params, the hash of the
+// response, the block heights of the chains of the read tables and the time. The heights are read before and
+// after the query, and the query is run again if they changed, so the response is the state at the heights.
+// Since the response is buffered, signed reads aren't streamed. The buffer is bounded by maxReadResponseSize,
+// which the formatter options enforce, so the validator doesn't enable signed reads without it.
+func (c *Controller) runSignedReadRequest(
+	ctx context.Context,
+	stm string,
+	params []any,
+	cursor string,
+	pageSize int,
+	atBlock int64,
+	opts []formatter.FormatOption,
+	rw http.ResponseWriter,
+) {
+	var (
+		body    bytes.Buffer
+		sw      *formatter.StreamWriter
+		heights gateway.BlockHeights
+	)
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		before, err := c.gateway.GetReadQueryHeights(ctx, stm)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			log.Ctx(ctx).Error().Str("sql_request", stm).Err(err).Msg("getting read query heights")
+			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: err.Error()})
+			return
+		}
+
+		body.Reset()
+		sw = formatter.NewStreamWriter(&body, opts...)
+		ok, err := c.readQuery(ctx, stm, params, cursor, pageSize, atBlock, sw, rw)
+		if !ok {
+			return
+		}
+		if err == nil {
+			err = sw.Close()
+		}
+		if err != nil {
+			rw.WriteHeader(readQueryErrorStatus(err))
+			log.Ctx(ctx).Error().Str("sql_request", stm).Err(err).Msg("executing signed read query")
+			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: err.Error()})
+			return
+		}
+
+		if atBlock > 0 {
+			// Reads at a block always see the state of the tables at that block.
+			heights = gateway.BlockHeights{}
+			for chainID := range before {
+				heights[chainID] = atBlock
+			}
+			break
+		}
+		after, err := c.gateway.GetReadQueryHeights(ctx, stm)
+		if err != nil {
+			rw.WriteHeader(http.StatusInternalServerError)
+			log.Ctx(ctx).Error().Str("sql_request", stm).Err(err).Msg("getting read query heights")
+			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to sign the response"})
+			return
+		}
+		if sameHeights(before, after) {
+			heights = after
+			break
+		}
+		if attempt == maxSignedReadAttempts {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			log.Ctx(ctx).Warn().Str("sql_request", stm).Msg("tables changed while running signed read query")
+			_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "The tables changed while reading them"})
+			return
+		}
 	}
+	took := time.Since(start)
 
-	return res, true
+	if body.Len() == 0 {
+		rw.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	att := attestation.Attestation{
+		Statement:  stm,
+		Params:     params,
+		ResultHash: attestation.ResultHash(body.Bytes()),
+		Heights:    heights,
+		Timestamp:  time.Now().Unix(),
+	}
+	signature, err := attestation.Sign(c.signer, att)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).Error().Str("sql_request", stm).Err(err).Msg("signing read query response")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Failed to sign the response"})
+		return
+	}
+
+	attestation.SetHeaders(rw.Header(), att, c.signer.Address(), signature)
+	rw.Header().Set("Content-Type", sw.Config().ContentType())
+	rw.WriteHeader(http.StatusOK)
+	_, _ = rw.Write(body.Bytes())
+
+	collectReadQueryMetric(ctx, stm, sw.Config(), took)
+}
+
+// readQuery runs a read query writing its results to w. If a paginated read fails, the error response is
+// sent and false is returned.
+func (c *Controller) readQuery(
+	ctx context.Context,
+	stm string,
+	params []any,
+	cursor string,
+	pageSize int,
+	atBlock int64,
+	w gateway.RowWriter,
+	rw http.ResponseWriter,
+) (bool, error) {
+	if cursor != "" || pageSize > 0 {
+		return c.runReadPageRequest(ctx, stm, params, cursor, pageSize, w, rw)
+	}
+	if atBlock > 0 {
+		return true, c.gateway.StreamReadQueryAt(ctx, stm, params, atBlock, w)
+	}
+	return true, c.gateway.StreamReadQuery(ctx, stm, params, w)
+}
+
+func sameHeights(a, b gateway.BlockHeights) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for chainID, height := range a {
+		if other, ok := b[chainID]; !ok || other != height {
+			return false
+		}
+	}
+	return true
+}
+
+// runReadPageRequest runs a paginated read query and writes the page to w. The cursor of the next page,
+// if any, is returned in the X-Next-Cursor header. If the query fails, the error response is sent and
+// false is returned.
+func (c *Controller) runReadPageRequest(
+	ctx context.Context,
+	stm string,
+	params []any,
+	cursor string,
+	pageSize int,
+	w gateway.RowWriter,
+	rw http.ResponseWriter,
+) (bool, error) {
+	res, nextCursor, err := c.gateway.RunReadQueryPage(ctx, stm, params, cursor, pageSize)
+	if err != nil {
+		status, msg := readQueryErrorStatus(err), err.Error()
+		if err == gateway.ErrInvalidCursor {
+			msg = "Invalid cursor"
+		}
+		if err == gateway.ErrCursorExpired {
+			status, msg = http.StatusGone, "Cursor expired, the queried tables changed beyond the change log retention"
+		}
+		rw.WriteHeader(status)
+		log.Ctx(ctx).
+			Error().
+			Str("sql_request", stm).
+			Err(err).
+			Msg("executing paginated read query")
+
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: msg})
+		return false, nil
+	}
+
+	if nextCursor != "" {
+		rw.Header().Set("X-Next-Cursor", nextCursor)
+	}
+	if err := w.WriteColumns(res.Columns); err != nil {
+		return true, err
+	}
+	for _, row := range res.Rows {
+		if err := w.WriteRow(row); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// readQueryErrorStatus returns the status of a failed read query. Queries interrupted for running longer
+// or executing more instructions than allowed, or reading a state that isn't available, can't be processed,
+// and any other error is a bad request. Reads at blocks before a queried table was created aren't found.
+func readQueryErrorStatus(err error) int {
+	if err == gateway.ErrTableNotFound {
+		return http.StatusNotFound
+	}
+	var errTimeout *gateway.ErrReadQueryTimeout
+	var errBudget *gateway.ErrReadQueryBudgetExceeded
+	if goerrors.As(err, &errTimeout) || goerrors.As(err, &errBudget) || err == gateway.ErrHistoryUnavailable {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+// chunkedResponseWriter sends the response status with the provided content type on the first write,
+// and flushes every write to the client.
+type chunkedResponseWriter struct {
+	rw          http.ResponseWriter
+	contentType string
+	started     bool
+}
+
+func (w *chunkedResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.rw.Header().Set("Content-Type", w.contentType)
+		w.rw.WriteHeader(http.StatusOK)
+	}
+	n, err := w.rw.Write(p)
+	if f, ok := w.rw.(http.Flusher); ok {
+		f.Flush()
+	}
+	return n, err
 }
 
 func formatterOptions(r *http.Request) ([]formatter.FormatOption, error) {
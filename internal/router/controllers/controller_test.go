@@ -6,13 +6,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -20,38 +26,39 @@ import (
 	"github.com/textileio/go-tableland/internal/router/middlewares"
 	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/mocks"
+	"github.com/textileio/go-tableland/pkg/attestation"
+	"github.com/textileio/go-tableland/pkg/relay"
 	"github.com/textileio/go-tableland/pkg/tables"
+	"github.com/textileio/go-tableland/pkg/wallet"
 )
 
 func TestQuery(t *testing.T) {
 	r := mocks.NewGateway(t)
-	r.EXPECT().RunReadQuery(mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("[]string")).Return(
-		&gateway.TableData{
-			Columns: []gateway.Column{
-				{Name: "id"},
-				{Name: "eyes"},
-				{Name: "mouth"},
+	expectBlockHeights(r)
+	expectStreamReadQuery(r, &gateway.TableData{
+		Columns: []gateway.Column{
+			{Name: "id"},
+			{Name: "eyes"},
+			{Name: "mouth"},
+		},
+		Rows: [][]*gateway.ColumnValue{
+			{
+				gateway.OtherColValue(1),
+				gateway.OtherColValue("Big"),
+				gateway.OtherColValue("Surprised"),
 			},
-			Rows: [][]*gateway.ColumnValue{
-				{
-					gateway.OtherColValue(1),
-					gateway.OtherColValue("Big"),
-					gateway.OtherColValue("Surprised"),
-				},
-				{
-					gateway.OtherColValue(2),
-					gateway.OtherColValue("Medium"),
-					gateway.OtherColValue("Sad"),
-				},
-				{
-					gateway.OtherColValue(3),
-					gateway.OtherColValue("Small"),
-					gateway.OtherColValue("Happy"),
-				},
+			{
+				gateway.OtherColValue(2),
+				gateway.OtherColValue("Medium"),
+				gateway.OtherColValue("Sad"),
+			},
+			{
+				gateway.OtherColValue(3),
+				gateway.OtherColValue("Small"),
+				gateway.OtherColValue("Happy"),
 			},
 		},
-		nil,
-	)
+	})
 
 	ctrl := NewController(r)
 
@@ -94,33 +101,30 @@ func TestQuery(t *testing.T) {
 
 func TestPostQuery(t *testing.T) {
 	r := mocks.NewGateway(t)
-	r.EXPECT().RunReadQuery(mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("[]string")).Return(
-		&gateway.TableData{
-			Columns: []gateway.Column{
-				{Name: "id"},
-				{Name: "eyes"},
-				{Name: "mouth"},
+	expectStreamReadQuery(r, &gateway.TableData{
+		Columns: []gateway.Column{
+			{Name: "id"},
+			{Name: "eyes"},
+			{Name: "mouth"},
+		},
+		Rows: [][]*gateway.ColumnValue{
+			{
+				gateway.OtherColValue(1),
+				gateway.OtherColValue("Big"),
+				gateway.OtherColValue("Surprised"),
 			},
-			Rows: [][]*gateway.ColumnValue{
-				{
-					gateway.OtherColValue(1),
-					gateway.OtherColValue("Big"),
-					gateway.OtherColValue("Surprised"),
-				},
-				{
-					gateway.OtherColValue(2),
-					gateway.OtherColValue("Medium"),
-					gateway.OtherColValue("Sad"),
-				},
-				{
-					gateway.OtherColValue(3),
-					gateway.OtherColValue("Small"),
-					gateway.OtherColValue("Happy"),
-				},
+			{
+				gateway.OtherColValue(2),
+				gateway.OtherColValue("Medium"),
+				gateway.OtherColValue("Sad"),
+			},
+			{
+				gateway.OtherColValue(3),
+				gateway.OtherColValue("Small"),
+				gateway.OtherColValue("Happy"),
 			},
 		},
-		nil,
-	)
+	})
 
 	ctrl := NewController(r)
 
@@ -191,18 +195,203 @@ func TestPostQuery(t *testing.T) {
 	}
 }
 
+func TestPostQueryParams(t *testing.T) {
+	t.Parallel()
+
+	g := mocks.NewGateway(t)
+	g.EXPECT().StreamReadQuery(
+		mock.Anything,
+		"select * from foo_1337_1 where a = ? and b = ? and c = ? and a < ?",
+		[]any{json.Number("1"), "it's", []byte{1, 2}, json.Number("1")},
+		mock.Anything,
+	).Return(nil).Once()
+	g.EXPECT().StreamReadQuery(
+		mock.Anything,
+		"select * from foo_1337_1 where a = ? and b = ?",
+		[]any{json.Number("1.5"), nil},
+		mock.Anything,
+	).Return(nil).Once()
+	ctrl := NewController(g)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("POST", "/query", strings.NewReader(body))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		ctrl.PostTableQuery(rr, req)
+		return rr
+	}
+
+	// Named params.
+	rr := post(`{
+		"statement": "select * from foo_1337_1 where a = :a and b = @b and c = :c and a < :a",
+		"params": {"a": 1, "b": "it's", "c": {"blob": "AQI="}}
+	}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	// Positional params.
+	rr = post(`{"statement": "select * from foo_1337_1 where a = ? and b = ?", "params": [1.5, null]}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rr = post(`{"statement": "select * from foo_1337_1 where a = :a and b = :b", "params": {"a": 1}}`)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.JSONEq(t, `{"message": "missing values for parameters :b"}`, rr.Body.String())
+
+	rr = post(`{"statement": "select * from foo_1337_1 where a = :a", "params": {"a": 1, "b": 2}}`)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.JSONEq(t, `{"message": "unknown parameters b"}`, rr.Body.String())
+
+	rr = post(`{"statement": "select * from foo_1337_1 where a = :a", "params": {"a": [1]}}`)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.JSONEq(t, `{"message": "invalid param a: invalid type ([]interface {})"}`, rr.Body.String())
+
+	rr = post(`{"statement": "select * from foo_1337_1 where a = ?", "params": "1"}`)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.JSONEq(t, `{"message": "params must be an array or an object"}`, rr.Body.String())
+}
+
+func TestPostQueryBatch(t *testing.T) {
+	t.Parallel()
+
+	data := &gateway.TableData{
+		Columns: []gateway.Column{{Name: "id"}},
+		Rows: [][]*gateway.ColumnValue{
+			{gateway.OtherColValue(1)},
+			{gateway.OtherColValue(2)},
+			{gateway.OtherColValue(3)},
+		},
+	}
+
+	g := mocks.NewGateway(t)
+	g.EXPECT().RunReadQueryBatch(
+		mock.Anything,
+		[]gateway.ReadQuery{
+			{Statement: "select id from foo_1337_1", Params: []any{}},
+			{Statement: "select id from foo_1337_1 where id > ?", Params: []any{json.Number("1")}},
+			{Statement: "select * from bar_1337_2", Params: []any{}},
+		},
+		mock.Anything,
+	).Run(func(_ context.Context, _ []gateway.ReadQuery, w gateway.BatchWriter) {
+		writeBatchResult(w, 0, data)
+		writeBatchResult(w, 1, &gateway.TableData{Columns: data.Columns, Rows: data.Rows[1:]})
+		w.End(2, errors.New("no such table: bar_1337_2"))
+	}).Return(nil).Once()
+	ctrl := NewController(g, WithMaxReadRowCount(3))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("POST", "/query/batch", strings.NewReader(body))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		ctrl.PostTableQueryBatch(rr, req)
+		return rr
+	}
+
+	rr := post(`{
+		"queries": [
+			{"statement": "select id from foo_1337_1"},
+			{"statement": "select id from foo_1337_1 where id > :id", "params": {"id": 1}},
+			{"statement": "select * from bar_1337_2"},
+			{"statement": "select id from foo_1337_1 where id = ?", "params": "1"}
+		],
+		"format": "table"
+	}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	expJSON := `[
+		{"result": {"columns": [{"name": "id"}], "rows": [[1], [2], [3]]}},
+		{"result": {"columns": [{"name": "id"}], "rows": [[2], [3]]}},
+		{"error": "no such table: bar_1337_2"},
+		{"error": "params must be an array or an object"}
+	]`
+	require.JSONEq(t, expJSON, rr.Body.String())
+
+	rr = post(`{"queries": []}`)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = post(`{"queries": [{"statement": "select id from foo_1337_1"}], "format": "csv"}`)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	queries := strings.Repeat(`{"statement": "select id from foo_1337_1"},`, gateway.MaxReadQueryBatchSize+1)
+	rr = post(fmt.Sprintf(`{"queries": [%s]}`, strings.TrimSuffix(queries, ",")))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	expJSON = fmt.Sprintf(`{"message": "Too many queries, the maximum is %d"}`, gateway.MaxReadQueryBatchSize)
+	require.JSONEq(t, expJSON, rr.Body.String())
+}
+
+func TestPostQueryBatchMaxSize(t *testing.T) {
+	t.Parallel()
+
+	data := &gateway.TableData{
+		Columns: []gateway.Column{{Name: "id"}},
+		Rows: [][]*gateway.ColumnValue{
+			{gateway.OtherColValue(1)},
+			{gateway.OtherColValue(2)},
+			{gateway.OtherColValue(3)},
+		},
+	}
+
+	var rowsWritten int
+	g := mocks.NewGateway(t)
+	g.EXPECT().RunReadQueryBatch(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ []gateway.ReadQuery, w gateway.BatchWriter) {
+			// [{"id":1},{"id":2},{"id":3}] takes 28 bytes, and leaves 12 for the other queries.
+			rowsWritten += writeBatchResult(w, 0, data)
+			rowsWritten += writeBatchResult(w, 1, data)
+			rowsWritten += writeBatchResult(w, 2, &gateway.TableData{Columns: data.Columns, Rows: data.Rows[:1]})
+			rowsWritten += writeBatchResult(w, 3, data)
+		}).Return(nil).Once()
+	ctrl := NewController(g, WithMaxReadResponseSize(40))
+
+	req, err := http.NewRequest("POST", "/query/batch", strings.NewReader(`{
+		"queries": [
+			{"statement": "select id from foo_1337_1"},
+			{"statement": "select id from foo_1337_1"},
+			{"statement": "select id from foo_1337_1 limit 1"},
+			{"statement": "select id from foo_1337_1"}
+		]
+	}`))
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	ctrl.PostTableQueryBatch(rr, req)
+
+	// Queries stop being read as soon as they exceed the size left by the previous ones.
+	require.Equal(t, http.StatusOK, rr.Code)
+	expJSON := `[
+		{"result": [{"id": 1}, {"id": 2}, {"id": 3}]},
+		{"error": "query results are too large (max 40 bytes)"},
+		{"result": [{"id": 1}]},
+		{"error": "query results are too large (max 40 bytes)"}
+	]`
+	require.JSONEq(t, expJSON, rr.Body.String())
+	require.Equal(t, 3+1+1, rowsWritten)
+}
+
+// writeBatchResult writes the data as the results of the query i of a batch, the way the gateway does, and
+// returns the number of rows written.
+func writeBatchResult(w gateway.BatchWriter, i int, data *gateway.TableData) int {
+	rw := w.Begin(i)
+	err := rw.WriteColumns(data.Columns)
+	rows := 0
+	for _, row := range data.Rows {
+		if err != nil {
+			break
+		}
+		if err = rw.WriteRow(row); err == nil {
+			rows++
+		}
+	}
+	w.End(i, err)
+	return rows
+}
+
 func TestQueryEmptyTable(t *testing.T) {
 	r := mocks.NewGateway(t)
-	r.EXPECT().RunReadQuery(mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("[]string")).Return(
-		&gateway.TableData{
-			Columns: []gateway.Column{
-				{Name: "id"},
-				{Name: "name"},
-			},
-			Rows: [][]*gateway.ColumnValue{},
+	expectBlockHeights(r)
+	expectStreamReadQuery(r, &gateway.TableData{
+		Columns: []gateway.Column{
+			{Name: "id"},
+			{Name: "name"},
 		},
-		nil,
-	)
+		Rows: [][]*gateway.ColumnValue{},
+	})
 
 	ctrl := NewController(r)
 
@@ -227,19 +416,42 @@ func TestQueryEmptyTable(t *testing.T) {
 	require.Equal(t, http.StatusNotFound, rr.Code)
 }
 
+func TestQueryCSV(t *testing.T) {
+	r := mocks.NewGateway(t)
+	expectBlockHeights(r)
+	expectStreamReadQuery(r, &gateway.TableData{
+		Columns: []gateway.Column{{Name: "id"}, {Name: "eyes"}},
+		Rows: [][]*gateway.ColumnValue{
+			{gateway.OtherColValue(1), gateway.OtherColValue("Big")},
+			{gateway.OtherColValue(2), gateway.OtherColValue(nil)},
+		},
+	})
+
+	ctrl := NewController(r)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/query", ctrl.GetTableQuery)
+
+	req, err := http.NewRequest("GET", "/query?statement=select%20*%20from%20foo%3B&format=csv", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+	require.Equal(t, "id,eyes\n1,Big\n2,\n", rr.Body.String())
+}
+
 func TestQueryExtracted(t *testing.T) {
 	r := mocks.NewGateway(t)
-	r.EXPECT().RunReadQuery(mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("[]string")).Return(
-		&gateway.TableData{
-			Columns: []gateway.Column{{Name: "name"}},
-			Rows: [][]*gateway.ColumnValue{
-				{gateway.OtherColValue("bob")},
-				{gateway.OtherColValue("jane")},
-				{gateway.OtherColValue("alex")},
-			},
+	expectBlockHeights(r)
+	expectStreamReadQuery(r, &gateway.TableData{
+		Columns: []gateway.Column{{Name: "name"}},
+		Rows: [][]*gateway.ColumnValue{
+			{gateway.OtherColValue("bob")},
+			{gateway.OtherColValue("jane")},
+			{gateway.OtherColValue("alex")},
 		},
-		nil,
-	)
+	})
 
 	ctrl := NewController(r)
 
@@ -276,17 +488,14 @@ func TestQueryExtracted(t *testing.T) {
 
 func TestPostQueryExtracted(t *testing.T) {
 	r := mocks.NewGateway(t)
-	r.EXPECT().RunReadQuery(mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("[]string")).Return(
-		&gateway.TableData{
-			Columns: []gateway.Column{{Name: "name"}},
-			Rows: [][]*gateway.ColumnValue{
-				{gateway.OtherColValue("bob")},
-				{gateway.OtherColValue("jane")},
-				{gateway.OtherColValue("alex")},
-			},
+	expectStreamReadQuery(r, &gateway.TableData{
+		Columns: []gateway.Column{{Name: "name"}},
+		Rows: [][]*gateway.ColumnValue{
+			{gateway.OtherColValue("bob")},
+			{gateway.OtherColValue("jane")},
+			{gateway.OtherColValue("alex")},
 		},
-		nil,
-	)
+	})
 
 	ctrl := NewController(r)
 
@@ -341,10 +550,240 @@ func TestPostQueryExtracted(t *testing.T) {
 	}
 }
 
+func TestQueryLimits(t *testing.T) {
+	t.Parallel()
+
+	data := &gateway.TableData{Columns: []gateway.Column{{Name: "id"}}}
+	for i := 0; i < 10000; i++ {
+		data.Rows = append(data.Rows, []*gateway.ColumnValue{gateway.OtherColValue(i)})
+	}
+
+	t.Run("max row count", func(t *testing.T) {
+		t.Parallel()
+
+		g := mocks.NewGateway(t)
+		expectBlockHeights(g)
+		expectStreamReadQuery(g, data)
+		ctrl := NewController(g, WithMaxReadRowCount(100))
+
+		req, err := http.NewRequest("GET", "/query?statement=select%20*%20from%20foo%3B", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		ctrl.GetTableQuery(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		require.Contains(t, rr.Body.String(), "too many rows")
+	})
+
+	t.Run("expensive query", func(t *testing.T) {
+		t.Parallel()
+
+		g := mocks.NewGateway(t)
+		expectBlockHeights(g)
+		g.EXPECT().StreamReadQuery(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			fmt.Errorf("running read statement: %w", &gateway.ErrReadQueryBudgetExceeded{MaxInstructions: 10}),
+		)
+		ctrl := NewController(g)
+
+		req, err := http.NewRequest("GET", "/query?statement=select%20*%20from%20foo%3B", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		ctrl.GetTableQuery(rr, req)
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		require.Contains(t, rr.Body.String(), "too expensive")
+	})
+
+	t.Run("max response size exceeded after the response started", func(t *testing.T) {
+		t.Parallel()
+
+		g := mocks.NewGateway(t)
+		expectBlockHeights(g)
+		expectStreamReadQuery(g, data)
+		ctrl := NewController(g, WithMaxReadResponseSize(2*readResponseBufferSize))
+		server := httptest.NewServer(http.HandlerFunc(ctrl.GetTableQuery))
+		defer server.Close()
+
+		res, err := http.Get(server.URL + "/query?statement=select%20*%20from%20foo%3B&format=ndjson")
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+
+		// The response is aborted, so the client knows it's incomplete.
+		_, err = io.ReadAll(res.Body)
+		require.Error(t, err)
+	})
+}
+
+func TestQueryPage(t *testing.T) {
+	t.Parallel()
+
+	stmt := "select * from foo_1337_1"
+	g := mocks.NewGateway(t)
+	expectBlockHeights(g)
+	g.EXPECT().RunReadQueryPage(mock.Anything, stmt, []any{}, "", 1).Return(
+		&gateway.TableData{
+			Columns: []gateway.Column{{Name: "id"}},
+			Rows:    [][]*gateway.ColumnValue{{gateway.OtherColValue(1)}},
+		},
+		"next",
+		nil,
+	)
+	g.EXPECT().RunReadQueryPage(mock.Anything, stmt, []any{}, "next", 0).Return(
+		&gateway.TableData{
+			Columns: []gateway.Column{{Name: "id"}},
+			Rows:    [][]*gateway.ColumnValue{{gateway.OtherColValue(2)}},
+		},
+		"",
+		nil,
+	)
+	g.EXPECT().RunReadQueryPage(mock.Anything, stmt, []any{}, "expired", 0).Return(
+		nil, "", gateway.ErrCursorExpired,
+	)
+
+	ctrl := NewController(g)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/query", ctrl.GetTableQuery).Methods("GET")
+	router.HandleFunc("/api/v1/query", ctrl.PostTableQuery).Methods("POST")
+
+	t.Run("first page", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequest("GET", "/api/v1/query?statement="+url.QueryEscape(stmt)+"&page_size=1", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "next", rr.Header().Get("X-Next-Cursor"))
+		require.JSONEq(t, `[{"id":1}]`, rr.Body.String())
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		t.Parallel()
+		body := strings.NewReader(`{"statement":"` + stmt + `","cursor":"next"}`)
+		req, err := http.NewRequest("POST", "/api/v1/query", body)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Empty(t, rr.Header().Get("X-Next-Cursor"))
+		require.JSONEq(t, `[{"id":2}]`, rr.Body.String())
+	})
+
+	t.Run("expired cursor", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequest("GET", "/api/v1/query?statement="+url.QueryEscape(stmt)+"&cursor=expired", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusGone, rr.Code)
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequest("GET", "/api/v1/query?statement="+url.QueryEscape(stmt)+"&page_size=0", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.JSONEq(t, `{"message": "Invalid page size"}`, rr.Body.String())
+	})
+}
+
+func TestQueryAtBlock(t *testing.T) {
+	t.Parallel()
+
+	stmt := "select * from foo_1337_1"
+	g := mocks.NewGateway(t)
+	expectBlockHeights(g)
+	call := g.EXPECT().StreamReadQueryAt(mock.Anything, stmt, []any{}, int64(10), mock.Anything)
+	call.Run(func(_ context.Context, _ string, _ []any, _ int64, w gateway.RowWriter) {
+		if err := w.WriteColumns([]gateway.Column{{Name: "id"}}); err != nil {
+			call.Return(err)
+			return
+		}
+		call.Return(w.WriteRow([]*gateway.ColumnValue{gateway.OtherColValue(1)}))
+	})
+	g.EXPECT().StreamReadQueryAt(mock.Anything, stmt, []any{}, int64(1), mock.Anything).Return(
+		gateway.ErrHistoryUnavailable,
+	)
+	g.EXPECT().StreamReadQueryAt(mock.Anything, stmt, []any{}, int64(2), mock.Anything).Return(
+		gateway.ErrTableNotFound,
+	)
+
+	ctrl := NewController(g)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/query", ctrl.GetTableQuery).Methods("GET")
+	router.HandleFunc("/api/v1/query", ctrl.PostTableQuery).Methods("POST")
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequest("GET", "/api/v1/query?statement="+url.QueryEscape(stmt)+"&at_block=10", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `[{"id":1}]`, rr.Body.String())
+	})
+
+	t.Run("post", func(t *testing.T) {
+		t.Parallel()
+		body := strings.NewReader(`{"statement":"` + stmt + `","at_block":10}`)
+		req, err := http.NewRequest("POST", "/api/v1/query", body)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `[{"id":1}]`, rr.Body.String())
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequest("GET", "/api/v1/query?statement="+url.QueryEscape(stmt)+"&at_block=1", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("before creation", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequest("GET", "/api/v1/query?statement="+url.QueryEscape(stmt)+"&at_block=2", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+		require.JSONEq(t, `{"message": "table not found"}`, rr.Body.String())
+	})
+
+	t.Run("invalid block", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequest("GET", "/api/v1/query?statement="+url.QueryEscape(stmt)+"&at_block=-1", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.JSONEq(t, `{"message": "Invalid block number"}`, rr.Body.String())
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequest(
+			"GET", "/api/v1/query?statement="+url.QueryEscape(stmt)+"&at_block=10&page_size=1", nil,
+		)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
 func TestGetTablesByMocked(t *testing.T) {
 	t.Parallel()
 
 	g := mocks.NewGateway(t)
+	expectBlockHeights(g)
 	g.EXPECT().GetTableMetadata(mock.Anything, mock.Anything, mock.Anything).Return(
 		gateway.TableMetadata{
 			Name:        "name-1",
@@ -408,6 +847,7 @@ func TestGetTableWithInvalidID(t *testing.T) {
 	req = req.WithContext(context.WithValue(req.Context(), middlewares.ContextKeyChainID, tableland.ChainID(1337)))
 
 	gateway := mocks.NewGateway(t)
+	expectBlockHeights(gateway)
 	ctrl := NewController(gateway)
 
 	router := mux.NewRouter()
@@ -431,6 +871,7 @@ func TestTableNotFoundMock(t *testing.T) {
 	req = req.WithContext(context.WithValue(req.Context(), middlewares.ContextKeyChainID, tableland.ChainID(1337)))
 
 	g := mocks.NewGateway(t)
+	expectBlockHeights(g)
 	g.EXPECT().GetTableMetadata(mock.Anything, mock.Anything, mock.Anything).Return(
 		gateway.TableMetadata{},
 		errors.New("failed"),
@@ -484,7 +925,756 @@ func TestReceipt(t *testing.T) {
 	require.JSONEq(t, exp, rr.Body.String())
 }
 
-func parseJSONLString(val string) []string {
-	s := strings.TrimRight(val, "\n")
-	return strings.Split(s, "\n")
+func TestReceiptsByTransactionHashes(t *testing.T) {
+	t.Parallel()
+
+	found := common.HexToHash("0x1")
+	missing := common.HexToHash("0x2")
+	g := mocks.NewGateway(t)
+	// All the transactions are looked up at once.
+	g.EXPECT().GetReceiptsByTransactionHashes(mock.Anything, []gateway.ReceiptLookup{
+		{ChainID: 1337, TxnHash: found},
+		{ChainID: 1337, TxnHash: missing},
+	}).Return(
+		[]gateway.Receipt{{
+			ChainID:      1337,
+			BlockNumber:  10,
+			IndexInBlock: 0,
+			TxnHash:      found.Hex(),
+			TableIDs:     []tables.TableID{tables.TableID(*big.NewInt(1))},
+		}},
+		nil,
+	).Once()
+
+	ctrl := NewController(g, WithSupportedChainIDs([]tableland.ChainID{1337}))
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/receipts", ctrl.GetReceiptsByTransactionHashes)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("POST", "/api/v1/receipts", strings.NewReader(body))
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("found and missing", func(t *testing.T) {
+		t.Parallel()
+
+		body := fmt.Sprintf(
+			`[{"chain_id":1337,"transaction_hash":%q},{"chain_id":1337,"transaction_hash":%q}]`, found.Hex(), missing.Hex(),
+		)
+		rr := post(body)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		exp := fmt.Sprintf(`[
+			{
+				"chain_id":1337,
+				"transaction_hash":%[1]q,
+				"found":true,
+				"receipt":{"table_ids":["1"],"transaction_hash":%[1]q,"block_number":10,"chain_id":1337}
+			},
+			{"chain_id":1337,"transaction_hash":%[2]q,"found":false}
+		]`, found.Hex(), missing.Hex())
+		require.JSONEq(t, exp, rr.Body.String())
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		t.Parallel()
+
+		lookups := make([]string, maxReceiptLookups+1)
+		for i := range lookups {
+			lookups[i] = fmt.Sprintf(`{"chain_id":1337,"transaction_hash":%q}`, missing.Hex())
+		}
+		for body, msg := range map[string]string{
+			`[{"chain_id":1,"transaction_hash":"0x1"}]`:     "Unsupported chain id 1",
+			`[{"chain_id":1337,"transaction_hash":"0xzz"}]`: "Invalid transaction hash 0xzz",
+			"[" + strings.Join(lookups, ",") + "]":          "Too many transactions, the maximum is 100",
+		} {
+			rr := post(body)
+			require.Equal(t, http.StatusBadRequest, rr.Code)
+			require.JSONEq(t, fmt.Sprintf(`{"message": %q}`, msg), rr.Body.String())
+		}
+
+		rr := post(`{"chain_id":1337}`)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestListTables(t *testing.T) {
+	t.Parallel()
+
+	owner := common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF")
+	g := mocks.NewGateway(t)
+	expectBlockHeights(g)
+	g.EXPECT().ListTables(mock.Anything, tableland.ChainID(1337), owner, "").Return(
+		[]gateway.Table{
+			{
+				ID:         tables.TableID(*big.NewInt(42)),
+				ChainID:    1337,
+				Controller: owner.Hex(),
+				Prefix:     "foo",
+				Structure:  "structure",
+				CreatedAt:  time.Unix(1546360800, 0),
+			},
+		},
+		"NDI",
+		nil,
+	)
+	g.EXPECT().ListTables(mock.Anything, tableland.ChainID(1337), owner, "bad").Return(
+		nil, "", gateway.ErrInvalidCursor,
+	)
+
+	ctrl := NewController(g)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/tables/{chainId}", ctrl.ListTables)
+
+	ctx := context.WithValue(context.Background(), middlewares.ContextKeyChainID, tableland.ChainID(1337))
+
+	t.Run("first page", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/v1/tables/1337?owner="+owner.Hex(), nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		//nolint
+		expJSON := `{
+			"tables":[{"id":"42","chain_id":1337,"name":"foo_1337_42","controller":"0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF","structure":"structure","created_at":1546360800}],
+			"next_cursor":"NDI"
+		}`
+		require.JSONEq(t, expJSON, rr.Body.String())
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/v1/tables/1337?cursor=bad&owner="+owner.Hex(), nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.JSONEq(t, `{"message": "Invalid cursor"}`, rr.Body.String())
+	})
+
+	t.Run("invalid owner", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/v1/tables/1337?owner=0xinvalid", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.JSONEq(t, `{"message": "Invalid owner address"}`, rr.Body.String())
+	})
+}
+
+func TestGetTableEvents(t *testing.T) {
+	t.Parallel()
+
+	tableID, _ := tables.NewTableID("1")
+	timestamp := time.Unix(1700000000, 0)
+	errMsg := "column not found"
+	errorEventIdx := 0
+	g := mocks.NewGateway(t)
+	expectBlockHeights(g)
+	g.EXPECT().GetTableEvents(mock.Anything, tableland.ChainID(1337), tableID, "").Return(
+		[]gateway.TableEvent{
+			{
+				BlockNumber:    1,
+				BlockTimestamp: &timestamp,
+				TxnHash:        "0x1",
+				EventType:      "ContractCreateTable",
+				EventJSON:      []byte(`{"TableId":1}`),
+				Executed:       true,
+			},
+			{
+				BlockNumber:   2,
+				TxnHash:       "0x2",
+				TxnIndex:      1,
+				EventIndex:    3,
+				EventType:     "ContractRunSQL",
+				EventJSON:     []byte(`{"TableId":1}`),
+				Executed:      true,
+				Error:         &errMsg,
+				ErrorEventIdx: &errorEventIdx,
+			},
+			{
+				BlockNumber: 3,
+				TxnHash:     "0x3",
+				EventType:   "ContractRunSQL",
+				EventJSON:   []byte(`{"TableId":1}`),
+			},
+		},
+		"Mzow",
+		nil,
+	)
+	g.EXPECT().GetTableEvents(mock.Anything, tableland.ChainID(1337), tableID, "bad").Return(
+		nil, "", gateway.ErrInvalidCursor,
+	)
+
+	ctrl := NewController(g)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/tables/{chainId}/{tableId}/events", ctrl.GetTableEvents)
+
+	ctx := context.WithValue(context.Background(), middlewares.ContextKeyChainID, tableland.ChainID(1337))
+
+	t.Run("events", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/v1/tables/1337/1/events", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		//nolint
+		expJSON := `{
+			"events":[
+				{"block_number":1,"block_timestamp":1700000000,"transaction_hash":"0x1","transaction_index":0,"event_index":0,"event_type":"ContractCreateTable","event":{"TableId":1},"status":"success"},
+				{"block_number":2,"transaction_hash":"0x2","transaction_index":1,"event_index":3,"event_type":"ContractRunSQL","event":{"TableId":1},"status":"failed","error":"column not found"},
+				{"block_number":3,"transaction_hash":"0x3","transaction_index":0,"event_index":0,"event_type":"ContractRunSQL","event":{"TableId":1},"status":"pending"}
+			],
+			"next_cursor":"Mzow"
+		}`
+		require.JSONEq(t, expJSON, rr.Body.String())
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/v1/tables/1337/1/events?cursor=bad", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.JSONEq(t, `{"message": "Invalid cursor"}`, rr.Body.String())
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/v1/tables/1337/abc/events", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestListReceipts(t *testing.T) {
+	t.Parallel()
+
+	tableID, _ := tables.NewTableID("42")
+	errMsg := "table not found"
+	errorEventIdx := 1
+	g := mocks.NewGateway(t)
+	g.EXPECT().ListReceipts(
+		mock.Anything, tableland.ChainID(1337), gateway.ReceiptFilter{FromBlock: 10, ToBlock: 20, TableID: &tableID}, "",
+	).Return(
+		[]gateway.Receipt{
+			{
+				ChainID:      1337,
+				BlockNumber:  10,
+				IndexInBlock: 0,
+				TxnHash:      "0x10",
+				TableIDs:     []tables.TableID{tableID},
+			},
+			{
+				ChainID:       1337,
+				BlockNumber:   11,
+				IndexInBlock:  2,
+				TxnHash:       "0x11",
+				Error:         &errMsg,
+				ErrorEventIdx: &errorEventIdx,
+			},
+		},
+		"MTE6Mg",
+		nil,
+	)
+	g.EXPECT().ListReceipts(mock.Anything, tableland.ChainID(1337), gateway.ReceiptFilter{}, "bad").Return(
+		nil, "", gateway.ErrInvalidCursor,
+	)
+
+	ctrl := NewController(g)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/receipts/{chainId}", ctrl.ListReceipts)
+
+	ctx := context.WithValue(context.Background(), middlewares.ContextKeyChainID, tableland.ChainID(1337))
+
+	t.Run("filtered page", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/v1/receipts/1337?from_block=10&to_block=20&table_id=42", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		expJSON := `{
+			"receipts":[
+				{"table_ids":["42"],"transaction_hash":"0x10","block_number":10,"chain_id":1337},
+				{"transaction_hash":"0x11","block_number":11,"chain_id":1337,"error":"table not found","error_event_idx":1}
+			],
+			"next_cursor":"MTE6Mg"
+		}`
+		require.JSONEq(t, expJSON, rr.Body.String())
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		t.Parallel()
+		req, err := http.NewRequestWithContext(ctx, "GET", "/api/v1/receipts/1337?cursor=bad", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.JSONEq(t, `{"message": "Invalid cursor"}`, rr.Body.String())
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		t.Parallel()
+		for query, msg := range map[string]string{
+			"from_block=-1":             "Invalid filter: from_block must be a non-negative integer",
+			"to_block=foo":              "Invalid filter: to_block must be a non-negative integer",
+			"from_block=20&to_block=10": "Invalid filter: to_block is lower than from_block",
+			"table_id=notanumber":       "Invalid filter: table_id: parsing stringified id failed",
+		} {
+			req, err := http.NewRequestWithContext(ctx, "GET", "/api/v1/receipts/1337?"+query, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			require.Equal(t, http.StatusBadRequest, rr.Code, query)
+			require.JSONEq(t, fmt.Sprintf(`{"message": %q}`, msg), rr.Body.String())
+		}
+	})
+}
+
+// expectStreamReadQuery expects a read query that streams the provided results. Like the gateway,
+// the query fails with the first error returned by the writer.
+func TestSignedReads(t *testing.T) {
+	t.Parallel()
+
+	signer, err := wallet.NewWallet("5b2dc4ea1bfc1ad1f7a3ed8ab42dc9e1a3ed3a5ee7fb1ae8ecc1ee2f0c57e3b8")
+	require.NoError(t, err)
+	data := &gateway.TableData{
+		Columns: []gateway.Column{{Name: "id"}},
+		Rows:    [][]*gateway.ColumnValue{{gateway.OtherColValue(1)}},
+	}
+
+	get := func(ctrl *Controller) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", "/query?statement=select%20*%20from%20foo_1337_1&params=1", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctrl.GetTableQuery).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("signed", func(t *testing.T) {
+		t.Parallel()
+
+		g := mocks.NewGateway(t)
+		expectBlockHeights(g)
+		expectStreamReadQuery(g, data)
+		rr := get(NewController(g, WithSignedReads(signer)))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `[{"id":1}]`, rr.Body.String())
+
+		timestamp, err := strconv.ParseInt(rr.Header().Get(attestation.HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		att := attestation.Attestation{
+			Statement:  "select * from foo_1337_1",
+			Params:     []any{int64(1)},
+			ResultHash: attestation.ResultHash(rr.Body.Bytes()),
+			Heights:    map[tableland.ChainID]int64{1337: 10},
+			Timestamp:  timestamp,
+		}
+		require.Equal(t, att.ResultHash.Hex(), rr.Header().Get(attestation.HeaderResultHash))
+		require.Equal(t, "1337:10", rr.Header().Get(attestation.HeaderBlockHeights))
+		require.Equal(t, signer.Address().Hex(), rr.Header().Get(attestation.HeaderSigner))
+
+		signature, err := hexutil.Decode(rr.Header().Get(attestation.HeaderSignature))
+		require.NoError(t, err)
+		addr, err := attestation.RecoverSigner(att, signature)
+		require.NoError(t, err)
+		require.Equal(t, signer.Address(), addr)
+	})
+
+	t.Run("tables keep changing", func(t *testing.T) {
+		t.Parallel()
+
+		g := mocks.NewGateway(t)
+		var height int64
+		g.On("GetReadQueryHeights", mock.Anything, mock.Anything).Return(
+			func(context.Context, string) gateway.BlockHeights {
+				height++
+				return gateway.BlockHeights{1337: height}
+			},
+			nil,
+		)
+		expectStreamReadQuery(g, data)
+		rr := get(NewController(g, WithSignedReads(signer)))
+		require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		g.AssertNumberOfCalls(t, "StreamReadQuery", maxSignedReadAttempts)
+	})
+}
+
+func expectStreamReadQuery(g *mocks.Gateway, data *gateway.TableData) {
+	call := g.EXPECT().StreamReadQuery(
+		mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("[]interface {}"), mock.Anything,
+	)
+	call.Run(func(_ context.Context, _ string, _ []any, w gateway.RowWriter) {
+		if err := w.WriteColumns(data.Columns); err != nil {
+			call.Return(err)
+			return
+		}
+		for _, row := range data.Rows {
+			if err := w.WriteRow(row); err != nil {
+				call.Return(err)
+				return
+			}
+		}
+		call.Return(nil)
+	})
+}
+
+// expectBlockHeights makes the gateway return the block heights used to compute ETags, if asked.
+func expectBlockHeights(g *mocks.Gateway) {
+	heights := gateway.BlockHeights{1337: 10}
+	g.EXPECT().GetBlockHeights(mock.Anything, mock.Anything).Return(heights, nil).Maybe()
+	g.EXPECT().GetReadQueryHeights(mock.Anything, mock.Anything).Return(heights, nil).Maybe()
+}
+
+func parseJSONLString(val string) []string {
+	s := strings.TrimRight(val, "\n")
+	return strings.Split(s, "\n")
+}
+
+func TestGraphQL(t *testing.T) {
+	t.Parallel()
+
+	g := mocks.NewGateway(t)
+	g.EXPECT().GetTableSchema(mock.Anything, "foo_1337_1").Return(
+		gateway.TableSchema{Columns: []gateway.ColumnSchema{{Name: "id", Type: "integer"}}}, nil,
+	)
+	g.EXPECT().GetTableSchema(mock.Anything, "name").Return(gateway.TableSchema{}, gateway.ErrTableNotFound)
+	call := g.EXPECT().StreamReadQuery(mock.Anything, mock.Anything, []any{int64(1)}, mock.Anything)
+	call.Run(func(_ context.Context, _ string, _ []any, w gateway.RowWriter) {
+		if err := w.WriteColumns([]gateway.Column{{Name: "object"}}); err != nil {
+			call.Return(err)
+			return
+		}
+		call.Return(w.WriteRow([]*gateway.ColumnValue{gateway.JSONColValue([]byte(`{"id":1}`))}))
+	})
+
+	ctrl := NewController(g)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/graphql", ctrl.GraphQL).Methods("GET", "POST")
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+		query := url.QueryEscape(`query($id: Int) { foo_1337_1(where: {id: $id}) { id } }`)
+		req, err := http.NewRequest("GET", "/api/v1/graphql?query="+query+"&variables="+url.QueryEscape(`{"id":1}`), nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `{"data":{"foo_1337_1":[{"id":1}]}}`, rr.Body.String())
+	})
+
+	t.Run("post", func(t *testing.T) {
+		t.Parallel()
+		body := strings.NewReader(`{"query":"{ foo_1337_1(where: {id: {eq: 1}}) { id } }"}`)
+		req, err := http.NewRequest("POST", "/api/v1/graphql", body)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.JSONEq(t, `{"data":{"foo_1337_1":[{"id":1}]}}`, rr.Body.String())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		body := strings.NewReader(`{"query":"{ foo_1337_1 { name } }"}`)
+		req, err := http.NewRequest("POST", "/api/v1/graphql", body)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.JSONEq(t, `{"errors":[{"message":"unknown field name of foo_1337_1"}]}`, rr.Body.String())
+	})
+}
+
+func TestGetTableACL(t *testing.T) {
+	t.Parallel()
+
+	updatedAt := time.Unix(1700000100, 0)
+	tableID, _ := tables.NewTableID("100")
+	g := mocks.NewGateway(t)
+	expectBlockHeights(g)
+	g.EXPECT().GetTableACL(mock.Anything, tableland.ChainID(1337), tableID).Return(tableland.TableACL{
+		Controller: "0x07dfFc57AA386D2b239CaBE8993358DF20BAFBE2",
+		Rules: []tableland.ACLRule{
+			{
+				Controller: common.HexToAddress("0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF"),
+				Privileges: tableland.Privileges{tableland.PrivInsert, tableland.PrivDelete},
+				CreatedAt:  time.Unix(1700000000, 0),
+				UpdatedAt:  &updatedAt,
+			},
+		},
+	}, nil)
+	notFoundID, _ := tables.NewTableID("101")
+	g.EXPECT().GetTableACL(mock.Anything, tableland.ChainID(1337), notFoundID).Return(
+		tableland.TableACL{}, gateway.ErrTableNotFound,
+	)
+
+	ctrl := NewController(g)
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/tables/{chainId}/{tableId}/acl", ctrl.GetTableACL)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		require.NoError(t, err)
+		req = req.WithContext(context.WithValue(req.Context(), middlewares.ContextKeyChainID, tableland.ChainID(1337)))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get("/api/v1/tables/1337/100/acl")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{
+		"controller": "0x07dfFc57AA386D2b239CaBE8993358DF20BAFBE2",
+		"rules": [{
+			"controller": "0xb451cee4A42A652Fe77d373BAe66D42fd6B8D8FF",
+			"privileges": ["insert", "delete"],
+			"created_at": 1700000000,
+			"updated_at": 1700000100
+		}]
+	}`, rr.Body.String())
+
+	rr = get("/api/v1/tables/1337/101/acl")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = get("/api/v1/tables/1337/invalid/acl")
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestGetTableStats(t *testing.T) {
+	t.Parallel()
+
+	bytes := int64(8192)
+	tableID, _ := tables.NewTableID("100")
+	g := mocks.NewGateway(t)
+	expectBlockHeights(g)
+	g.EXPECT().GetTableStats(mock.Anything, tableland.ChainID(1337), tableID).Return(gateway.TableStats{
+		RowCount:    42,
+		MaxRowCount: 100000,
+		ColumnCount: 2,
+		Indexes: []gateway.TableIndex{
+			{Name: "sqlite_autoindex_foo_1337_100_1", Unique: true, Columns: []string{"id"}},
+		},
+		CreatedAt:           time.Unix(1700000000, 0),
+		Bytes:               &bytes,
+		LastModifiedBlock:   10,
+		LastModifiedTxnHash: "0x0000000000000000000000000000000000000000000000000000000000000001",
+	}, nil)
+	notFoundID, _ := tables.NewTableID("101")
+	g.EXPECT().GetTableStats(mock.Anything, tableland.ChainID(1337), notFoundID).Return(
+		gateway.TableStats{}, gateway.ErrTableNotFound,
+	)
+
+	ctrl := NewController(g)
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/tables/{chainId}/{tableId}/stats", ctrl.GetTableStats)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		require.NoError(t, err)
+		req = req.WithContext(context.WithValue(req.Context(), middlewares.ContextKeyChainID, tableland.ChainID(1337)))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get("/api/v1/tables/1337/100/stats")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{
+		"row_count": 42,
+		"max_row_count": 100000,
+		"bytes": 8192,
+		"column_count": 2,
+		"indexes": [{"name": "sqlite_autoindex_foo_1337_100_1", "unique": true, "columns": ["id"]}],
+		"created_at": 1700000000,
+		"last_modified_block": 10,
+		"last_modified_transaction_hash": "0x0000000000000000000000000000000000000000000000000000000000000001"
+	}`, rr.Body.String())
+
+	rr = get("/api/v1/tables/1337/101/stats")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = get("/api/v1/tables/1337/invalid/stats")
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestGetTableImage(t *testing.T) {
+	t.Parallel()
+
+	tableID, _ := tables.NewTableID("100")
+	g := mocks.NewGateway(t)
+	expectBlockHeights(g)
+	g.EXPECT().GetTableImage(mock.Anything, tableland.ChainID(1337), tableID).Return([]byte("<svg></svg>"), nil)
+	notFoundID, _ := tables.NewTableID("101")
+	g.EXPECT().GetTableImage(mock.Anything, tableland.ChainID(1337), notFoundID).Return(nil, gateway.ErrTableNotFound)
+
+	ctrl := NewController(g)
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/tables/{chainId}/{tableId}/image", ctrl.GetTableImage)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		require.NoError(t, err)
+		req = req.WithContext(context.WithValue(req.Context(), middlewares.ContextKeyChainID, tableland.ChainID(1337)))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get("/api/v1/tables/1337/100/image")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "image/svg+xml", rr.Header().Get("Content-Type"))
+	require.Equal(t, "<svg></svg>", rr.Body.String())
+
+	rr = get("/api/v1/tables/1337/101/image")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = get("/api/v1/tables/1337/invalid/image")
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestGetStateHash(t *testing.T) {
+	t.Parallel()
+
+	g := mocks.NewGateway(t)
+	expectBlockHeights(g)
+	g.EXPECT().GetStateHash(mock.Anything, tableland.ChainID(1337), int64(math.MaxInt64)).Return(gateway.StateHash{
+		ChainID:     1337,
+		BlockNumber: 200,
+		Hash:        "4f1ac2",
+		CreatedAt:   time.Unix(1700000000, 0),
+	}, true, nil)
+	g.EXPECT().GetStateHash(mock.Anything, tableland.ChainID(1337), int64(150)).Return(gateway.StateHash{
+		ChainID:     1337,
+		BlockNumber: 100,
+		Hash:        "0b95e1",
+		CreatedAt:   time.Unix(1690000000, 0),
+	}, true, nil)
+	g.EXPECT().GetStateHash(mock.Anything, tableland.ChainID(1337), int64(50)).Return(gateway.StateHash{}, false, nil)
+
+	ctrl := NewController(g)
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/statehash/{chainId}", ctrl.GetStateHash)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		require.NoError(t, err)
+		req = req.WithContext(context.WithValue(req.Context(), middlewares.ContextKeyChainID, tableland.ChainID(1337)))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get("/api/v1/statehash/1337")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"chain_id":1337,"block_number":200,"hash":"4f1ac2","created_at":1700000000}`, rr.Body.String())
+
+	rr = get("/api/v1/statehash/1337?block=150")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"chain_id":1337,"block_number":100,"hash":"0b95e1","created_at":1690000000}`, rr.Body.String())
+
+	rr = get("/api/v1/statehash/1337?block=50")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = get("/api/v1/statehash/1337?block=latest")
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestRelayWrite(t *testing.T) {
+	t.Parallel()
+
+	signer := common.HexToAddress("0xd43c59d5694ec111eb9e986c233200b14249558d")
+	txnHash := common.HexToHash("0x01")
+	var submitted relay.SignedWrite
+	r := relayFunc(func(_ context.Context, sw relay.SignedWrite) (relay.Submission, error) {
+		switch sw.Nonce.Int64() {
+		case 1:
+			submitted = sw
+			return relay.Submission{Signer: signer, TxnHash: txnHash}, nil
+		case 2:
+			return relay.Submission{}, fmt.Errorf("%w: missing privilege for OpDelete", relay.ErrNotAllowed)
+		case 3:
+			return relay.Submission{}, relay.ErrQuotaExceeded
+		default:
+			return relay.Submission{}, errors.New("insufficient funds")
+		}
+	})
+
+	post := func(ctrl *Controller, body string) *httptest.ResponseRecorder {
+		router := mux.NewRouter()
+		router.HandleFunc("/api/v1/relay/{chainId}", ctrl.RelayWrite)
+		req, err := http.NewRequest("POST", "/api/v1/relay/1337", strings.NewReader(body))
+		require.NoError(t, err)
+		req = req.WithContext(context.WithValue(req.Context(), middlewares.ContextKeyChainID, tableland.ChainID(1337)))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+	body := func(nonce string, signature string) string {
+		return fmt.Sprintf(`{
+			"table_id": "100",
+			"statement": "insert into foo_1337_100 values (1)",
+			"nonce": "%s",
+			"deadline": 1700000000,
+			"signature": "%s"
+		}`, nonce, signature)
+	}
+
+	ctrl := NewController(mocks.NewGateway(t), WithRelay(r))
+
+	rr := post(ctrl, body("1", "0x0102"))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, fmt.Sprintf(
+		`{"transaction_hash": "%s", "signer": "%s"}`, txnHash.Hex(), signer.Hex(),
+	), rr.Body.String())
+	require.Equal(t, tableland.ChainID(1337), submitted.ChainID)
+	require.Equal(t, "100", submitted.TableID.String())
+	require.Equal(t, "insert into foo_1337_100 values (1)", submitted.Statement)
+	require.Equal(t, int64(1700000000), submitted.Deadline)
+	require.Equal(t, []byte{1, 2}, submitted.Signature)
+
+	rr = post(ctrl, body("2", "0x0102"))
+	require.Equal(t, http.StatusForbidden, rr.Code)
+	require.Contains(t, rr.Body.String(), "missing privilege for OpDelete")
+
+	rr = post(ctrl, body("3", "0x0102"))
+	require.Equal(t, http.StatusTooManyRequests, rr.Code)
+
+	rr = post(ctrl, body("4", "0x0102"))
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+	require.NotContains(t, rr.Body.String(), "insufficient funds")
+
+	rr = post(ctrl, body("-1", "0x0102"))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = post(ctrl, body("1", "invalid"))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// Without a relay, writes are rejected.
+	rr = post(NewController(mocks.NewGateway(t)), body("1", "0x0102"))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+type relayFunc func(context.Context, relay.SignedWrite) (relay.Submission, error)
+
+func (f relayFunc) Submit(ctx context.Context, sw relay.SignedWrite) (relay.Submission, error) {
+	return f(ctx, sw)
 }
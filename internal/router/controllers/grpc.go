@@ -0,0 +1,281 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"github.com/textileio/go-tableland/internal/formatter"
+	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/internal/tableland"
+	grpcv1 "github.com/textileio/go-tableland/pkg/grpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// GRPCController implements the gRPC API. It shares the validation and error mapping of the HTTP
+// handlers of a Controller, translating the HTTP statuses to gRPC codes.
+type GRPCController struct {
+	grpcv1.UnimplementedTablelandServer
+
+	ctrl *Controller
+}
+
+var _ grpcv1.TablelandServer = (*GRPCController)(nil)
+
+// NewGRPCController creates a new GRPCController.
+func NewGRPCController(ctrl *Controller) *GRPCController {
+	return &GRPCController{ctrl: ctrl}
+}
+
+// Query runs a read query, streaming the results in chunks of rows.
+func (g *GRPCController) Query(req *grpcv1.QueryRequest, stream grpcv1.Tableland_QueryServer) error {
+	ctx := stream.Context()
+
+	params := make([]any, len(req.Params))
+	for i, p := range req.Params {
+		params[i] = paramValue(p)
+	}
+
+	if req.AtBlock < 0 {
+		log.Ctx(ctx).Error().Int64("at_block", req.AtBlock).Msg("invalid block number")
+		return status.Error(codes.InvalidArgument, "Invalid block number")
+	}
+
+	w := &grpcRowWriter{
+		stream:      stream,
+		maxRowCount: g.ctrl.maxReadRowCount,
+		maxSize:     g.ctrl.maxReadResponseSize,
+	}
+	var err error
+	if req.AtBlock > 0 {
+		err = g.ctrl.gateway.StreamReadQueryAt(ctx, req.Statement, params, req.AtBlock, w)
+	} else {
+		err = g.ctrl.gateway.StreamReadQuery(ctx, req.Statement, params, w)
+	}
+	if err == nil {
+		err = w.flush()
+	}
+	if err != nil {
+		log.Ctx(ctx).
+			Error().
+			Str("sql_request", req.Statement).
+			Err(err).
+			Msg("executing read query")
+		return status.Error(grpcCode(readQueryErrorStatus(err)), err.Error())
+	}
+	return nil
+}
+
+// GetTable returns the metadata of a table.
+func (g *GRPCController) GetTable(ctx context.Context, req *grpcv1.GetTableRequest) (*grpcv1.Table, error) {
+	if err := g.checkChainID(ctx, req.ChainId); err != nil {
+		return nil, err
+	}
+	metadata, reqErr := g.ctrl.tableMetadata(ctx, tableland.ChainID(req.ChainId), req.TableId)
+	if reqErr != nil {
+		return nil, statusFromRequestError(reqErr, "Table not found")
+	}
+
+	table := &grpcv1.Table{
+		Name:         metadata.Name,
+		ExternalUrl:  metadata.ExternalURL,
+		AnimationUrl: metadata.AnimationURL,
+		Image:        metadata.Image,
+		Attributes:   make([]*grpcv1.TableAttribute, len(metadata.Attributes)),
+		Schema: &grpcv1.Schema{
+			Columns:          make([]*grpcv1.ColumnSchema, len(metadata.Schema.Columns)),
+			TableConstraints: metadata.Schema.TableConstraints,
+		},
+	}
+	for i, attr := range metadata.Attributes {
+		table.Attributes[i] = &grpcv1.TableAttribute{
+			DisplayType: attr.DisplayType,
+			TraitType:   attr.TraitType,
+			Value:       protoValue(attr.Value),
+		}
+	}
+	for i, column := range metadata.Schema.Columns {
+		table.Schema.Columns[i] = &grpcv1.ColumnSchema{
+			Name:        column.Name,
+			Type:        column.Type,
+			Constraints: column.Constraints,
+		}
+	}
+	return table, nil
+}
+
+// GetReceipt returns the receipt of a transaction.
+func (g *GRPCController) GetReceipt(ctx context.Context, req *grpcv1.GetReceiptRequest) (*grpcv1.Receipt, error) {
+	if err := g.checkChainID(ctx, req.ChainId); err != nil {
+		return nil, err
+	}
+	receipt, reqErr := g.ctrl.receipt(ctx, tableland.ChainID(req.ChainId), req.TransactionHash)
+	if reqErr != nil {
+		return nil, statusFromRequestError(reqErr, "Receipt not found")
+	}
+
+	res := &grpcv1.Receipt{
+		ChainId:         int64(receipt.ChainID),
+		TransactionHash: req.TransactionHash,
+		BlockNumber:     receipt.BlockNumber,
+		TableIds:        make([]string, len(receipt.TableIDs)),
+	}
+	for i, id := range receipt.TableIDs {
+		res.TableIds[i] = id.String()
+	}
+	if receipt.Error != nil {
+		res.Error = *receipt.Error
+		if receipt.ErrorEventIdx != nil {
+			res.ErrorEventIdx = int32(*receipt.ErrorEventIdx)
+		}
+	}
+	return res, nil
+}
+
+func (g *GRPCController) checkChainID(ctx context.Context, chainID int64) error {
+	if !g.ctrl.isSupportedChainID(tableland.ChainID(chainID)) {
+		msg := fmt.Sprintf("Unsupported chain id %d", chainID)
+		log.Ctx(ctx).Error().Int64("chain_id", chainID).Msg(msg)
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
+// statusFromRequestError maps a failed request to a gRPC status. Requests failing without a message, such as
+// not found ones, use the default message.
+func statusFromRequestError(reqErr *requestError, defaultMsg string) error {
+	msg := reqErr.message
+	if msg == "" {
+		msg = defaultMsg
+	}
+	return status.Error(grpcCode(reqErr.status), msg)
+}
+
+// grpcCode maps an HTTP status to the closest gRPC code.
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusGone, http.StatusUnprocessableEntity:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusInternalServerError:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// paramValue returns a query parameter as the typed value bound to the statement.
+func paramValue(v *grpcv1.Value) any {
+	switch kind := v.GetKind().(type) {
+	case *grpcv1.Value_IntegerValue:
+		return kind.IntegerValue
+	case *grpcv1.Value_RealValue:
+		return kind.RealValue
+	case *grpcv1.Value_TextValue:
+		return kind.TextValue
+	case *grpcv1.Value_JsonValue:
+		return kind.JsonValue
+	case *grpcv1.Value_BlobValue:
+		return kind.BlobValue
+	default:
+		return nil
+	}
+}
+
+// protoValue returns a value read from the database as a Value.
+func protoValue(v interface{}) *grpcv1.Value {
+	switch v := v.(type) {
+	case nil:
+		return &grpcv1.Value{Kind: &grpcv1.Value_NullValue{}}
+	case int64:
+		return &grpcv1.Value{Kind: &grpcv1.Value_IntegerValue{IntegerValue: v}}
+	case int:
+		return &grpcv1.Value{Kind: &grpcv1.Value_IntegerValue{IntegerValue: int64(v)}}
+	case float64:
+		return &grpcv1.Value{Kind: &grpcv1.Value_RealValue{RealValue: v}}
+	case string:
+		return &grpcv1.Value{Kind: &grpcv1.Value_TextValue{TextValue: v}}
+	case json.RawMessage:
+		return &grpcv1.Value{Kind: &grpcv1.Value_JsonValue{JsonValue: string(v)}}
+	case []byte:
+		return &grpcv1.Value{Kind: &grpcv1.Value_BlobValue{BlobValue: v}}
+	case bool:
+		var i int64
+		if v {
+			i = 1
+		}
+		return &grpcv1.Value{Kind: &grpcv1.Value_IntegerValue{IntegerValue: i}}
+	default:
+		return &grpcv1.Value{Kind: &grpcv1.Value_TextValue{TextValue: fmt.Sprint(v)}}
+	}
+}
+
+// grpcRowWriter sends the results of a read query to a stream, in responses of about readResponseBufferSize
+// bytes. The columns are sent in the first response.
+type grpcRowWriter struct {
+	stream      grpcv1.Tableland_QueryServer
+	maxRowCount int
+	maxSize     int
+
+	pending     *grpcv1.QueryResponse
+	pendingSize int
+	rowCount    int
+	size        int
+}
+
+func (w *grpcRowWriter) WriteColumns(columns []gateway.Column) error {
+	w.pending = &grpcv1.QueryResponse{Columns: make([]*grpcv1.Column, len(columns))}
+	for i, column := range columns {
+		w.pending.Columns[i] = &grpcv1.Column{Name: column.Name}
+	}
+	return nil
+}
+
+func (w *grpcRowWriter) WriteRow(row []*gateway.ColumnValue) error {
+	w.rowCount++
+	if w.maxRowCount > 0 && w.rowCount > w.maxRowCount {
+		return &formatter.ErrMaxRowCountExceeded{MaxAllowed: w.maxRowCount}
+	}
+
+	values := make([]*grpcv1.Value, len(row))
+	for i, v := range row {
+		values[i] = protoValue(v.Value())
+	}
+	protoRow := &grpcv1.Row{Values: values}
+	rowSize := proto.Size(protoRow)
+	w.size += rowSize
+	if w.maxSize > 0 && w.size > w.maxSize {
+		return &formatter.ErrMaxSizeExceeded{MaxAllowed: w.maxSize}
+	}
+
+	if w.pending == nil {
+		w.pending = &grpcv1.QueryResponse{}
+	}
+	w.pending.Rows = append(w.pending.Rows, protoRow)
+	w.pendingSize += rowSize
+	if w.pendingSize >= readResponseBufferSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// flush sends the pending response, if any.
+func (w *grpcRowWriter) flush() error {
+	if w.pending == nil {
+		return nil
+	}
+	if err := w.stream.Send(w.pending); err != nil {
+		return fmt.Errorf("sending response: %s", err)
+	}
+	w.pending, w.pendingSize = nil, 0
+	return nil
+}
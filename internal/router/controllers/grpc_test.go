@@ -0,0 +1,265 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/mocks"
+	grpcv1 "github.com/textileio/go-tableland/pkg/grpc/v1"
+	"github.com/textileio/go-tableland/pkg/tables"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		// Enough rows to be sent in many responses.
+		data := &gateway.TableData{Columns: []gateway.Column{{Name: "id"}, {Name: "name"}, {Name: "info"}}}
+		for i := 0; i < 2000; i++ {
+			data.Rows = append(data.Rows, []*gateway.ColumnValue{
+				gateway.OtherColValue(int64(i)),
+				gateway.OtherColValue(strings.Repeat("a", 32)),
+				gateway.JSONColValue([]byte(`{"a":1}`)),
+			})
+		}
+		data.Rows[0][1] = gateway.OtherColValue(nil)
+
+		g := mocks.NewGateway(t)
+		expectStreamReadQuery(g, data)
+		client := newGRPCClient(t, g)
+
+		responses, err := grpcQuery(client, &grpcv1.QueryRequest{Statement: "select * from foo_1337_1"})
+		require.NoError(t, err)
+		require.Greater(t, len(responses), 1)
+		require.Equal(t, []string{"id", "name", "info"}, []string{
+			responses[0].Columns[0].Name, responses[0].Columns[1].Name, responses[0].Columns[2].Name,
+		})
+
+		var rows []*grpcv1.Row
+		for i, res := range responses {
+			if i > 0 {
+				require.Empty(t, res.Columns)
+			}
+			rows = append(rows, res.Rows...)
+		}
+		require.Len(t, rows, 2000)
+		require.Equal(t, int64(1999), rows[1999].Values[0].GetIntegerValue())
+		require.Equal(t, strings.Repeat("a", 32), rows[1999].Values[1].GetTextValue())
+		require.Equal(t, `{"a":1}`, rows[1999].Values[2].GetJsonValue())
+		require.IsType(t, &grpcv1.Value_NullValue{}, rows[0].Values[1].Kind)
+	})
+
+	t.Run("params", func(t *testing.T) {
+		t.Parallel()
+
+		g := mocks.NewGateway(t)
+		g.EXPECT().StreamReadQuery(
+			mock.Anything, "select * from foo_1337_1 where id = ? and name = ? and a = ? and b = ?",
+			[]any{int64(1), "bob", nil, []byte{1}}, mock.Anything,
+		).Return(nil)
+		client := newGRPCClient(t, g)
+
+		_, err := grpcQuery(client, &grpcv1.QueryRequest{
+			Statement: "select * from foo_1337_1 where id = ? and name = ? and a = ? and b = ?",
+			Params: []*grpcv1.Value{
+				{Kind: &grpcv1.Value_IntegerValue{IntegerValue: 1}},
+				{Kind: &grpcv1.Value_TextValue{TextValue: "bob"}},
+				{Kind: &grpcv1.Value_NullValue{}},
+				{Kind: &grpcv1.Value_BlobValue{BlobValue: []byte{1}}},
+			},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("max row count", func(t *testing.T) {
+		t.Parallel()
+
+		g := mocks.NewGateway(t)
+		expectStreamReadQuery(g, &gateway.TableData{
+			Columns: []gateway.Column{{Name: "id"}},
+			Rows: [][]*gateway.ColumnValue{
+				{gateway.OtherColValue(int64(1))},
+				{gateway.OtherColValue(int64(2))},
+			},
+		})
+		client := newGRPCClient(t, g, WithMaxReadRowCount(1))
+
+		_, err := grpcQuery(client, &grpcv1.QueryRequest{Statement: "select * from foo_1337_1"})
+		requireGRPCStatus(t, err, codes.InvalidArgument, "query results have too many rows (max 1)")
+	})
+
+	t.Run("history unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		g := mocks.NewGateway(t)
+		g.EXPECT().StreamReadQueryAt(mock.Anything, mock.Anything, mock.Anything, int64(10), mock.Anything).
+			Return(gateway.ErrHistoryUnavailable)
+		client := newGRPCClient(t, g)
+
+		_, err := grpcQuery(client, &grpcv1.QueryRequest{Statement: "select * from foo_1337_1", AtBlock: 10})
+		requireGRPCStatus(t, err, codes.FailedPrecondition, gateway.ErrHistoryUnavailable.Error())
+
+		_, err = grpcQuery(client, &grpcv1.QueryRequest{Statement: "select * from foo_1337_1", AtBlock: -1})
+		requireGRPCStatus(t, err, codes.InvalidArgument, "Invalid block number")
+	})
+}
+
+func TestGRPCGetTable(t *testing.T) {
+	t.Parallel()
+
+	g := mocks.NewGateway(t)
+	id, _ := tables.NewTableID("100")
+	g.EXPECT().GetTableMetadata(mock.Anything, tableland.ChainID(1337), id).Return(
+		gateway.TableMetadata{
+			Name:        "foo_1337_100",
+			ExternalURL: "https://gateway.network/tables/100",
+			Attributes:  []gateway.TableMetadataAttribute{{DisplayType: "date", TraitType: "created", Value: int64(10)}},
+			Schema: gateway.TableSchema{
+				Columns:          []gateway.ColumnSchema{{Name: "foo", Type: "text", Constraints: []string{"NOT NULL"}}},
+				TableConstraints: []string{"UNIQUE(foo)"},
+			},
+		}, nil).Once()
+	g.EXPECT().GetTableMetadata(mock.Anything, tableland.ChainID(1337), mock.Anything).
+		Return(gateway.TableMetadata{}, gateway.ErrTableNotFound).Once()
+	client := newGRPCClient(t, g)
+	ctx := context.Background()
+
+	table, err := client.GetTable(ctx, &grpcv1.GetTableRequest{ChainId: 1337, TableId: "100"})
+	require.NoError(t, err)
+	require.Equal(t, "foo_1337_100", table.Name)
+	require.Equal(t, "https://gateway.network/tables/100", table.ExternalUrl)
+	require.Equal(t, "created", table.Attributes[0].TraitType)
+	require.Equal(t, int64(10), table.Attributes[0].Value.GetIntegerValue())
+	require.Equal(t, "foo", table.Schema.Columns[0].Name)
+	require.Equal(t, []string{"NOT NULL"}, table.Schema.Columns[0].Constraints)
+	require.Equal(t, []string{"UNIQUE(foo)"}, table.Schema.TableConstraints)
+
+	_, err = client.GetTable(ctx, &grpcv1.GetTableRequest{ChainId: 1337, TableId: "101"})
+	requireGRPCStatus(t, err, codes.NotFound, "Table not found")
+
+	_, err = client.GetTable(ctx, &grpcv1.GetTableRequest{ChainId: 1337, TableId: "invalid"})
+	requireGRPCStatus(t, err, codes.InvalidArgument, "Invalid id format")
+
+	_, err = client.GetTable(ctx, &grpcv1.GetTableRequest{ChainId: 1, TableId: "100"})
+	requireGRPCStatus(t, err, codes.InvalidArgument, "Unsupported chain id 1")
+}
+
+func TestGRPCGetReceipt(t *testing.T) {
+	t.Parallel()
+
+	txnHash := "0xb5c8bd9430b6cc87a0e2fe110ece6bf527fa4f170a4bc8cd032f768fc5219838"
+	tableID, _ := tables.NewTableID("1")
+	errMsg, errIdx := "table not found", 1
+
+	g := mocks.NewGateway(t)
+	g.EXPECT().GetReceiptByTransactionHash(mock.Anything, tableland.ChainID(1337), common.HexToHash(txnHash)).
+		Return(gateway.Receipt{
+			ChainID:       1337,
+			BlockNumber:   10,
+			TxnHash:       txnHash,
+			TableIDs:      []tables.TableID{tableID},
+			Error:         &errMsg,
+			ErrorEventIdx: &errIdx,
+		}, true, nil).Once()
+	noIdxHash := "0x" + strings.Repeat("3", 64)
+	g.EXPECT().GetReceiptByTransactionHash(mock.Anything, tableland.ChainID(1337), common.HexToHash(noIdxHash)).
+		Return(gateway.Receipt{ChainID: 1337, BlockNumber: 11, TxnHash: noIdxHash, Error: &errMsg}, true, nil).Once()
+	g.EXPECT().GetReceiptByTransactionHash(mock.Anything, tableland.ChainID(1337), mock.Anything).
+		Return(gateway.Receipt{}, false, nil).Once()
+	g.EXPECT().GetReceiptByTransactionHash(mock.Anything, tableland.ChainID(1337), mock.Anything).
+		Return(gateway.Receipt{}, false, errors.New("failed")).Once()
+	client := newGRPCClient(t, g)
+	ctx := context.Background()
+
+	receipt, err := client.GetReceipt(ctx, &grpcv1.GetReceiptRequest{ChainId: 1337, TransactionHash: txnHash})
+	require.NoError(t, err)
+	require.Equal(t, int64(1337), receipt.ChainId)
+	require.Equal(t, txnHash, receipt.TransactionHash)
+	require.Equal(t, int64(10), receipt.BlockNumber)
+	require.Equal(t, []string{"1"}, receipt.TableIds)
+	require.Equal(t, "table not found", receipt.Error)
+	require.Equal(t, int32(1), receipt.ErrorEventIdx)
+
+	// A failed receipt without an event index doesn't make the handler panic.
+	receipt, err = client.GetReceipt(ctx, &grpcv1.GetReceiptRequest{ChainId: 1337, TransactionHash: noIdxHash})
+	require.NoError(t, err)
+	require.Equal(t, "table not found", receipt.Error)
+	require.Equal(t, int32(0), receipt.ErrorEventIdx)
+
+	missingHash := "0x" + strings.Repeat("1", 64)
+	_, err = client.GetReceipt(ctx, &grpcv1.GetReceiptRequest{ChainId: 1337, TransactionHash: missingHash})
+	requireGRPCStatus(t, err, codes.NotFound, "Receipt not found")
+
+	failingHash := "0x" + strings.Repeat("2", 64)
+	_, err = client.GetReceipt(ctx, &grpcv1.GetReceiptRequest{ChainId: 1337, TransactionHash: failingHash})
+	requireGRPCStatus(t, err, codes.InvalidArgument, "Get receipt by transaction hash failed")
+
+	_, err = client.GetReceipt(ctx, &grpcv1.GetReceiptRequest{ChainId: 1337, TransactionHash: "0xINVALID"})
+	requireGRPCStatus(t, err, codes.InvalidArgument, "Invalid transaction hash")
+}
+
+func newGRPCClient(t *testing.T, g gateway.Gateway, opts ...ControllerOption) grpcv1.TablelandClient {
+	t.Helper()
+
+	opts = append([]ControllerOption{WithSupportedChainIDs([]tableland.ChainID{1337})}, opts...)
+	server := grpc.NewServer()
+	grpcv1.RegisterTablelandServer(server, NewGRPCController(NewController(g, opts...)))
+
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return grpcv1.NewTablelandClient(conn)
+}
+
+func grpcQuery(client grpcv1.TablelandClient, req *grpcv1.QueryRequest) ([]*grpcv1.QueryResponse, error) {
+	stream, err := client.Query(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	var responses []*grpcv1.QueryResponse
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return responses, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, res)
+	}
+}
+
+func requireGRPCStatus(t *testing.T, err error, code codes.Code, msg string) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+	require.Equal(t, msg, st.Message())
+}
@@ -0,0 +1,277 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/tablelandnetwork/sqlparser"
+	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/internal/router/controllers/apiv1"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/errors"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
+	"github.com/textileio/go-tableland/pkg/pubsub"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+const (
+	// subscriptionBackfillBatchSize is the number of stored receipts fetched at a time
+	// when resuming a subscription.
+	subscriptionBackfillBatchSize = 100
+
+	// subscriptionKeepAliveInterval is how often a comment is sent to keep idle connections open.
+	subscriptionKeepAliveInterval = 15 * time.Second
+
+	// subscriptionMaxDuration limits how long a single stream is kept open. It must be lower than
+	// the HTTP server write timeout. Clients reconnect using the Last-Event-ID header.
+	subscriptionMaxDuration = 50 * time.Second
+)
+
+// SubscriptionController defines the HTTP handlers for streaming table changes.
+type SubscriptionController struct {
+	gateway gateway.Gateway
+	hub     *pubsub.Hub
+}
+
+// NewSubscriptionController creates a new SubscriptionController.
+func NewSubscriptionController(gateway gateway.Gateway, hub *pubsub.Hub) *SubscriptionController {
+	return &SubscriptionController{
+		gateway: gateway,
+		hub:     hub,
+	}
+}
+
+// receiptPosition identifies a receipt by its execution order in a chain.
+type receiptPosition struct {
+	blockNumber  int64
+	indexInBlock int64
+}
+
+func (p receiptPosition) before(blockNumber int64, indexInBlock int64) bool {
+	return p.blockNumber < blockNumber || (p.blockNumber == blockNumber && p.indexInBlock < indexInBlock)
+}
+
+// SubscribeToTables handles the GET /subscribe/{chainId}?tables=[names]&from_block=[height] call.
+// It streams a Server-Sent Event for every receipt that touches any of the subscribed tables.
+// If from_block is provided, stored receipts starting at that block are sent before live ones.
+// A reconnecting client can send the Last-Event-ID header to resume after the last received event.
+func (c *SubscriptionController) SubscribeToTables(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	chainID := ctx.Value(middlewares.ContextKeyChainID).(tableland.ChainID)
+
+	tableIDs, err := subscriptionTableIDs(r, chainID)
+	if err != nil {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).Error().Err(err).Msg("invalid subscription tables")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: err.Error()})
+		return
+	}
+
+	pos, resume, err := subscriptionStartPosition(r)
+	if err != nil {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusBadRequest)
+		log.Ctx(ctx).Error().Err(err).Msg("invalid subscription start position")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: err.Error()})
+		return
+	}
+
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Ctx(ctx).Error().Msg("response writer doesn't support flushing")
+		_ = json.NewEncoder(rw).Encode(errors.ServiceError{Message: "Streaming is not supported"})
+		return
+	}
+
+	// We subscribe before reading stored receipts, so nothing committed in between is missed.
+	// Receipts that are both stored and published are deduplicated by their position.
+	sub := c.hub.Subscribe()
+	defer sub.Close()
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	rw.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprintf(rw, "retry: %d\n\n", time.Second.Milliseconds())
+	flusher.Flush()
+
+	if resume {
+		for {
+			receipts, err := c.gateway.ListReceiptsAfter(
+				ctx, chainID, pos.blockNumber, pos.indexInBlock, subscriptionBackfillBatchSize,
+			)
+			if err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("listing stored receipts")
+				_, _ = fmt.Fprint(rw, "event: error\ndata: {\"message\":\"Failed to fetch stored receipts\"}\n\n")
+				flusher.Flush()
+				return
+			}
+			for _, receipt := range receipts {
+				pos = receiptPosition{receipt.BlockNumber, receipt.IndexInBlock}
+				if receiptTouchesTables(receipt.TableIDs, receipt.TableID, tableIDs) { // nolint
+					writeReceiptEvent(rw, pos, toAPIReceipt(receipt))
+				}
+			}
+			flusher.Flush()
+			if len(receipts) < subscriptionBackfillBatchSize {
+				break
+			}
+		}
+	}
+
+	keepAlive := time.NewTicker(subscriptionKeepAliveInterval)
+	defer keepAlive.Stop()
+	maxDuration := time.NewTimer(subscriptionMaxDuration)
+	defer maxDuration.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-maxDuration.C:
+			return
+		case <-keepAlive.C:
+			_, _ = fmt.Fprint(rw, ": keepalive\n\n")
+			flusher.Flush()
+		case receipt, ok := <-sub.Receipts():
+			if !ok {
+				// The subscription couldn't keep up, the client should reconnect and resume.
+				return
+			}
+			if receipt.ChainID != chainID || !pos.before(receipt.BlockNumber, receipt.IndexInBlock) {
+				continue
+			}
+			pos = receiptPosition{receipt.BlockNumber, receipt.IndexInBlock}
+			if receiptTouchesTables(receipt.TableIDs, receipt.TableID, tableIDs) { // nolint
+				writeReceiptEvent(rw, pos, eventReceiptToAPIReceipt(receipt))
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+func subscriptionTableIDs(r *http.Request, chainID tableland.ChainID) (map[string]struct{}, error) {
+	tableIDs := map[string]struct{}{}
+	for _, param := range r.URL.Query()["tables"] {
+		for _, name := range strings.Split(param, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			table, err := sqlparser.ValidateTargetTable(&sqlparser.Table{Name: sqlparser.Identifier(name), IsTarget: true})
+			if err != nil {
+				return nil, fmt.Errorf("invalid table name %s", name)
+			}
+			if table.ChainID() != int64(chainID) {
+				return nil, fmt.Errorf("table %s doesn't belong to chain %d", name, chainID)
+			}
+			tableIDs[strconv.FormatInt(table.TokenID(), 10)] = struct{}{}
+		}
+	}
+	if len(tableIDs) == 0 {
+		return nil, fmt.Errorf("at least one table must be provided")
+	}
+
+	return tableIDs, nil
+}
+
+// subscriptionStartPosition returns the position after which stored receipts should be streamed,
+// and if stored receipts should be streamed at all.
+func subscriptionStartPosition(r *http.Request) (receiptPosition, bool, error) {
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		parts := strings.Split(lastEventID, "-")
+		if len(parts) != 2 {
+			return receiptPosition{}, false, fmt.Errorf("invalid Last-Event-ID header")
+		}
+		blockNumber, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return receiptPosition{}, false, fmt.Errorf("invalid Last-Event-ID header")
+		}
+		indexInBlock, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return receiptPosition{}, false, fmt.Errorf("invalid Last-Event-ID header")
+		}
+		return receiptPosition{blockNumber, indexInBlock}, true, nil
+	}
+
+	if fromBlock := r.URL.Query().Get("from_block"); fromBlock != "" {
+		blockNumber, err := strconv.ParseInt(fromBlock, 10, 64)
+		if err != nil || blockNumber < 0 {
+			return receiptPosition{}, false, fmt.Errorf("invalid from_block")
+		}
+		// The position is exclusive, so we start right before the first receipt of the block.
+		return receiptPosition{blockNumber, -1}, true, nil
+	}
+
+	// Live receipts are streamed from the start of the chain, including the first possible one.
+	return receiptPosition{0, -1}, false, nil
+}
+
+// receiptTouchesTables returns true if a receipt is about any subscribed table. Failed transactions only have
+// the deprecated table id set, so it's used when there are no table ids.
+func receiptTouchesTables(ids []tables.TableID, id *tables.TableID, subscribed map[string]struct{}) bool {
+	if len(ids) == 0 && id != nil {
+		ids = []tables.TableID{*id}
+	}
+	for _, id := range ids {
+		if _, ok := subscribed[id.String()]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// writeReceiptEvent writes a receipt event. The event id is the receipt position, which
+// allows clients to resume the subscription with the Last-Event-ID header.
+func writeReceiptEvent(w io.Writer, pos receiptPosition, receipt apiv1.TransactionReceipt) {
+	data, _ := json.Marshal(receipt)
+	_, _ = fmt.Fprintf(w, "id: %d-%d\nevent: receipt\ndata: %s\n\n", pos.blockNumber, pos.indexInBlock, data)
+}
+
+func toAPIReceipt(receipt gateway.Receipt) apiv1.TransactionReceipt {
+	return eventReceiptToAPIReceipt(eventprocessor.Receipt{
+		ChainID:       receipt.ChainID,
+		BlockNumber:   receipt.BlockNumber,
+		IndexInBlock:  receipt.IndexInBlock,
+		TxnHash:       receipt.TxnHash,
+		TableIDs:      receipt.TableIDs,
+		Error:         receipt.Error,
+		ErrorEventIdx: receipt.ErrorEventIdx,
+		TableID:       receipt.TableID, // nolint
+	})
+}
+
+func eventReceiptToAPIReceipt(receipt eventprocessor.Receipt) apiv1.TransactionReceipt {
+	ids := make([]string, len(receipt.TableIDs))
+	for i, tblID := range receipt.TableIDs {
+		ids[i] = tblID.String()
+	}
+
+	res := apiv1.TransactionReceipt{
+		TableIds:        ids,
+		TransactionHash: receipt.TxnHash,
+		BlockNumber:     receipt.BlockNumber,
+		ChainId:         int32(receipt.ChainID),
+	}
+	if receipt.TableID != nil { // nolint
+		res.TableId = receipt.TableID.String() // nolint
+	}
+	if receipt.Error != nil {
+		res.Error_ = *receipt.Error
+		if receipt.ErrorEventIdx != nil {
+			res.ErrorEventIdx = int32(*receipt.ErrorEventIdx)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/mocks"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
+	"github.com/textileio/go-tableland/pkg/pubsub"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+func TestSubscribeToTables(t *testing.T) {
+	t.Parallel()
+
+	tableID := func(id int64) tables.TableID {
+		return tables.TableID(*big.NewInt(id))
+	}
+	// Failed transactions only have the deprecated table id.
+	failedTableID, errMsg := tableID(1), "table not found"
+
+	g := mocks.NewGateway(t)
+	g.EXPECT().ListReceiptsAfter(mock.Anything, tableland.ChainID(1337), int64(10), int64(-1), 100).Return(
+		[]gateway.Receipt{
+			{ChainID: 1337, BlockNumber: 10, IndexInBlock: 0, TxnHash: "0x1", TableIDs: []tables.TableID{tableID(1)}},
+			{ChainID: 1337, BlockNumber: 10, IndexInBlock: 1, TxnHash: "0x2", TableIDs: []tables.TableID{tableID(2)}},
+			{ChainID: 1337, BlockNumber: 10, IndexInBlock: 2, TxnHash: "0x6", TableID: &failedTableID, Error: &errMsg},
+		},
+		nil,
+	)
+	hub := pubsub.NewHub(10)
+	ctrl := NewSubscriptionController(g, hub)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/subscribe/{chainId}", func(rw http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), middlewares.ContextKeyChainID, tableland.ChainID(1337))
+		ctrl.SubscribeToTables(rw, r.WithContext(ctx))
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	t.Run("backfill and live", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(
+			ctx, "GET", server.URL+"/api/v1/subscribe/1337?tables=foo_1337_1&from_block=10", nil,
+		)
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+		events := bufio.NewReader(res.Body)
+		require.Equal(t, "retry: 1000\n", readLine(t, events))
+		require.Equal(t, "\n", readLine(t, events))
+
+		// Stored receipts are sent first, filtered by table.
+		requireReceiptEvent(t, events, "10-0", `"transaction_hash":"0x1"`)
+		requireReceiptEvent(t, events, "10-2", `"error":"table not found"`)
+
+		// Live receipts already sent as stored, from other tables or from other chains are skipped.
+		hub.Publish([]eventprocessor.Receipt{
+			{ChainID: 1337, BlockNumber: 10, IndexInBlock: 0, TxnHash: "0x1", TableIDs: []tables.TableID{tableID(1)}},
+			{ChainID: 1, BlockNumber: 11, IndexInBlock: 0, TxnHash: "0x3", TableIDs: []tables.TableID{tableID(1)}},
+			{ChainID: 1337, BlockNumber: 11, IndexInBlock: 0, TxnHash: "0x4", TableIDs: []tables.TableID{tableID(3)}},
+			{ChainID: 1337, BlockNumber: 11, IndexInBlock: 1, TxnHash: "0x5", TableIDs: []tables.TableID{tableID(1)}},
+			{ChainID: 1337, BlockNumber: 11, IndexInBlock: 2, TxnHash: "0x7", TableID: &failedTableID, Error: &errMsg},
+		})
+		requireReceiptEvent(t, events, "11-1", `"transaction_hash":"0x5"`)
+		requireReceiptEvent(t, events, "11-2", `"transaction_hash":"0x7"`)
+	})
+
+	t.Run("live from the first receipt", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/v1/subscribe/1337?tables=foo_1337_1", nil)
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+
+		events := bufio.NewReader(res.Body)
+		require.Equal(t, "retry: 1000\n", readLine(t, events))
+		require.Equal(t, "\n", readLine(t, events))
+
+		hub.Publish([]eventprocessor.Receipt{
+			{ChainID: 1337, BlockNumber: 0, IndexInBlock: 0, TxnHash: "0x8", TableIDs: []tables.TableID{tableID(1)}},
+		})
+		requireReceiptEvent(t, events, "0-0", `"transaction_hash":"0x8"`)
+	})
+
+	t.Run("invalid table", func(t *testing.T) {
+		res, err := http.Get(server.URL + "/api/v1/subscribe/1337?tables=foo_1_1")
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("missing tables", func(t *testing.T) {
+		res, err := http.Get(server.URL + "/api/v1/subscribe/1337")
+		require.NoError(t, err)
+		defer func() { _ = res.Body.Close() }()
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
+
+func readLine(t *testing.T, r *bufio.Reader) string {
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	return line
+}
+
+func requireReceiptEvent(t *testing.T, r *bufio.Reader, id string, contains string) {
+	require.Equal(t, "id: "+id+"\n", readLine(t, r))
+	require.Equal(t, "event: receipt\n", readLine(t, r))
+	data := readLine(t, r)
+	require.True(t, strings.HasPrefix(data, "data: "))
+	require.Contains(t, data, contains)
+	require.Equal(t, "\n", readLine(t, r))
+}
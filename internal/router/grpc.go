@@ -0,0 +1,39 @@
+package router
+
+import (
+	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/internal/router/controllers"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
+	"github.com/textileio/go-tableland/internal/tableland"
+	grpcv1 "github.com/textileio/go-tableland/pkg/grpc/v1"
+	"google.golang.org/grpc"
+)
+
+// grpcRateLimitRoutes maps the gRPC methods to the REST routes whose rate limits apply to them.
+var grpcRateLimitRoutes = map[string]string{
+	grpcv1.Tableland_Query_FullMethodName:      "QueryByStatementPost",
+	grpcv1.Tableland_GetTable_FullMethodName:   "GetTableById",
+	grpcv1.Tableland_GetReceipt_FullMethodName: "ReceiptByTransactionHash",
+}
+
+// ConfiguredGRPCServer returns a gRPC server serving the same operations as the REST API. Calls are rate limited
+// by the same rate limiter as the REST API, so they share the quotas of clients.
+func ConfiguredGRPCServer(
+	gateway gateway.Gateway,
+	rateLim *middlewares.RateLimiter,
+	supportedChainIDs []tableland.ChainID,
+	serverOpts []grpc.ServerOption,
+	ctrlOpts ...controllers.ControllerOption,
+) *grpc.Server {
+	ctrlOpts = append([]controllers.ControllerOption{controllers.WithSupportedChainIDs(supportedChainIDs)}, ctrlOpts...)
+	ctrl := controllers.NewController(gateway, ctrlOpts...)
+
+	serverOpts = append(serverOpts,
+		grpc.ChainUnaryInterceptor(middlewares.GRPCUnaryTraceID, rateLim.GRPCUnary(grpcRateLimitRoutes)),
+		grpc.ChainStreamInterceptor(middlewares.GRPCStreamTraceID, rateLim.GRPCStream(grpcRateLimitRoutes)),
+	)
+	server := grpc.NewServer(serverOpts...)
+	grpcv1.RegisterTablelandServer(server, controllers.NewGRPCController(ctrl))
+
+	return server
+}
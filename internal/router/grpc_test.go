@@ -0,0 +1,98 @@
+package router
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/gateway"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/mocks"
+	grpcv1 "github.com/textileio/go-tableland/pkg/grpc/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCRateLimit(t *testing.T) {
+	t.Parallel()
+
+	g := mocks.NewGateway(t)
+	g.EXPECT().GetTableMetadata(mock.Anything, tableland.ChainID(1337), mock.Anything).
+		Return(gateway.TableMetadata{Name: "foo_1337_1"}, nil)
+	g.EXPECT().StreamReadQuery(mock.Anything, "select * from foo_1337_1", mock.Anything, mock.Anything).
+		Return(nil).Once()
+	rateLim, err := middlewares.NewRateLimiter(middlewares.RateLimiterConfig{
+		Default: middlewares.RateLimiterRouteConfig{MaxRPI: 2, Interval: time.Hour},
+		Routes: map[string]middlewares.RateLimiterRouteConfig{
+			"QueryByStatementPost": {MaxRPI: 1, Interval: time.Hour},
+		},
+		APIKeys: map[string]middlewares.RateLimiterRouteConfig{
+			"gold": {MaxRPI: 3, Interval: time.Hour},
+		},
+	})
+	require.NoError(t, err)
+
+	server := ConfiguredGRPCServer(g, rateLim, []tableland.ChainID{1337}, nil)
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	client := grpcv1.NewTablelandClient(conn)
+
+	getTable := func(ip string, apiKey string) (metadata.MD, error) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-forwarded-for", ip)
+		if apiKey != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "api-key", apiKey)
+		}
+		var header metadata.MD
+		_, err := client.GetTable(ctx, &grpcv1.GetTableRequest{ChainId: 1337, TableId: "1"}, grpc.Header(&header))
+		return header, err
+	}
+
+	// Calls are limited by client address.
+	header, err := getTable("10.0.0.1", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"2"}, header.Get("ratelimit-limit"))
+	require.Equal(t, []string{"1"}, header.Get("ratelimit-remaining"))
+	_, err = getTable("10.0.0.1", "")
+	require.NoError(t, err)
+	_, err = getTable("10.0.0.1", "")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, err = getTable("10.0.0.2", "")
+	require.NoError(t, err)
+
+	// API key tiers apply to gRPC calls too.
+	for i := 0; i < 3; i++ {
+		_, err = getTable("10.0.0.1", "gold")
+		require.NoError(t, err)
+	}
+	_, err = getTable("10.0.0.2", "gold")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Streaming queries are limited like the POST query route.
+	query := func() error {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-forwarded-for", "10.0.0.3")
+		stream, err := client.Query(ctx, &grpcv1.QueryRequest{Statement: "select * from foo_1337_1"})
+		if err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	}
+	require.Equal(t, codes.ResourceExhausted, status.Code(func() error { _ = query(); return query() }()))
+}
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// GRPCUnaryTraceID is the TraceID middleware of unary gRPC calls. The trace id is returned in the trace-id header.
+func GRPCUnaryTraceID(
+	ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (interface{}, error) {
+	return handler(withGRPCTraceID(ctx), req)
+}
+
+// GRPCStreamTraceID is the TraceID middleware of streaming gRPC calls. The trace id is returned in the trace-id
+// header.
+func GRPCStreamTraceID(
+	srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	return handler(srv, &tracedServerStream{ServerStream: ss, ctx: withGRPCTraceID(ss.Context())})
+}
+
+func withGRPCTraceID(ctx context.Context) context.Context {
+	uuid, err := uuid.NewRandom()
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to generate a trace id")
+		return ctx
+	}
+
+	traceID := uuid.String()
+	if err := grpc.SetHeader(ctx, metadata.Pairs("trace-id", traceID)); err != nil {
+		log.Warn().Err(err).Msg("failed to set the trace id header")
+	}
+	logger := log.With().Str("trace_id", traceID).Logger()
+	return logger.WithContext(ctx)
+}
+
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -43,3 +43,10 @@ func (r *responseWriterLogger) WriteHeader(statusCode int) {
 	r.ResponseWriter.WriteHeader(statusCode)
 	r.statusCode = statusCode
 }
+
+// Flush implements http.Flusher, so streaming handlers can be wrapped.
+func (r *responseWriterLogger) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
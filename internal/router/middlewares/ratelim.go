@@ -1,22 +1,39 @@
 package middlewares
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
-	"github.com/sethvargo/go-limiter"
+	"github.com/rs/zerolog/log"
 	"github.com/sethvargo/go-limiter/httplimit"
-	"github.com/sethvargo/go-limiter/memorystore"
 )
 
-// RateLimiterConfig specifies a default rate limiting configuration.
+// RateLimiterConfig specifies a default rate limiting configuration, overrides of it for
+// particular routes, and the rate limiting tiers of API keys.
 type RateLimiterConfig struct {
 	Default RateLimiterRouteConfig
+
+	// Routes overrides the default configuration for the routes with the given names.
+	Routes map[string]RateLimiterRouteConfig
+
+	// APIKeys maps API keys to their own rate limiting tier. Requests with a known API key
+	// are limited by key instead of by client IP, and the tier applies to all routes. Routes
+	// with an override are also limited by key with the override, so the stricter one applies.
+	// API keys are matched case-insensitively, like the unlimited API key.
+	APIKeys map[string]RateLimiterRouteConfig
+
+	// Store keeps the rate limiting state. If nil, it's kept in memory.
+	Store RateLimiterStore
 }
 
 // RateLimiterRouteConfig specifies the maximum request per interval, and
@@ -27,11 +44,18 @@ type RateLimiterRouteConfig struct {
 	APIKey   string
 }
 
-// RateLimitController creates a new middleware to rate limit requests.
-// It applies a priority based rate limiting key for the rate limiting:
-// 1. If found, use an existing X-Forwarded-For IP included by a load-balancer in the infrastructure.
-// 2. If 1. isn't present, it will use the connection remote address.
-func RateLimitController(cfg RateLimiterConfig) (mux.MiddlewareFunc, error) {
+// RateLimiter creates the rate limiting middlewares of routes. All the middlewares share
+// the API key tiers, so an API key quota is consumed by requests to any route.
+type RateLimiter struct {
+	defaultRL *middleware
+	routes    map[string]*middleware
+
+	apiKeysMu sync.RWMutex
+	apiKeys   map[string]rule // by lowercase API key
+}
+
+// NewRateLimiter creates a new RateLimiter.
+func NewRateLimiter(cfg RateLimiterConfig) (*RateLimiter, error) {
 	keyFunc := func(r *http.Request) (string, error) {
 		ip, err := extractClientIP(r)
 		if err != nil {
@@ -40,30 +64,133 @@ func RateLimitController(cfg RateLimiterConfig) (mux.MiddlewareFunc, error) {
 		return ip, nil
 	}
 
-	defaultRL, err := createRateLimiter(cfg.Default, keyFunc)
-	if err != nil {
-		return nil, fmt.Errorf("creating default rate limiter: %s", err)
+	store := cfg.Store
+	if store == nil {
+		store = NewMemoryRateLimiterStore()
+	}
+
+	rl := &RateLimiter{}
+	if err := rl.SetAPIKeys(cfg.APIKeys); err != nil {
+		return nil, err
+	}
+
+	rl.defaultRL = &middleware{
+		rl:      rl,
+		store:   store,
+		rule:    rule{name: "default", cfg: withDefaults(cfg.Default)},
+		keyFunc: keyFunc,
+	}
+
+	rl.routes = make(map[string]*middleware, len(cfg.Routes))
+	for name, routeCfg := range cfg.Routes {
+		if routeCfg.APIKey == "" {
+			routeCfg.APIKey = cfg.Default.APIKey
+		}
+		rl.routes[name] = &middleware{
+			rl:       rl,
+			store:    store,
+			rule:     rule{name: "route:" + name, cfg: withDefaults(routeCfg)},
+			keyFunc:  keyFunc,
+			override: true,
+		}
+	}
+
+	return rl, nil
+}
+
+// SetAPIKeys replaces the rate limiting tiers of API keys. The quotas of keys that are kept
+// aren't reset.
+func (rl *RateLimiter) SetAPIKeys(tiers map[string]RateLimiterRouteConfig) error {
+	// API keys aren't kept in plain text by the stores, which can be shared.
+	apiKeys := make(map[string]rule, len(tiers))
+	for key, tier := range tiers {
+		if key == "" {
+			return fmt.Errorf("api key tiers must have a key")
+		}
+		key = strings.ToLower(key)
+		hash := sha256.Sum256([]byte(key))
+		hashedKey := hex.EncodeToString(hash[:])
+		apiKeys[key] = rule{name: "api-key:" + hashedKey, key: hashedKey, cfg: withDefaults(tier)}
 	}
 
+	rl.apiKeysMu.Lock()
+	rl.apiKeys = apiKeys
+	rl.apiKeysMu.Unlock()
+
+	return nil
+}
+
+// ReloadAPIKeys replaces the rate limiting tiers of API keys with the ones returned by load
+// every interval, until the context is canceled. If loading fails, the current tiers are kept.
+func (rl *RateLimiter) ReloadAPIKeys(
+	ctx context.Context,
+	interval time.Duration,
+	load func(context.Context) (map[string]RateLimiterRouteConfig, error),
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tiers, err := load(ctx)
+			if err == nil {
+				err = rl.SetAPIKeys(tiers)
+			}
+			if err != nil {
+				log.Error().Err(err).Msg("reloading api keys")
+			}
+		}
+	}
+}
+
+func (rl *RateLimiter) route(name string) *middleware {
+	if m, ok := rl.routes[name]; ok {
+		return m
+	}
+	return rl.defaultRL
+}
+
+func (rl *RateLimiter) apiKeyTier(apiKey string) (rule, bool) {
+	rl.apiKeysMu.RLock()
+	defer rl.apiKeysMu.RUnlock()
+
+	tier, ok := rl.apiKeys[strings.ToLower(apiKey)]
+	return tier, ok
+}
+
+// Route returns the rate limiting middleware of the route with the provided name.
+// Routes without an override share the default rate limiter.
+func (rl *RateLimiter) Route(name string) mux.MiddlewareFunc {
+	m := rl.route(name)
 	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(defaultRL.Handle(next).ServeHTTP)
-	}, nil
+		return http.HandlerFunc(m.Handle(next).ServeHTTP)
+	}
 }
 
-func createRateLimiter(cfg RateLimiterRouteConfig, kf httplimit.KeyFunc) (*middleware, error) {
-	defaultStore, err := memorystore.New(&memorystore.Config{
-		Tokens:   cfg.MaxRPI,
-		Interval: cfg.Interval,
-	})
+// RateLimitController creates a new middleware to rate limit requests.
+// It applies a priority based rate limiting key for the rate limiting:
+// 1. If found, use an existing X-Forwarded-For IP included by a load-balancer in the infrastructure.
+// 2. If 1. isn't present, it will use the connection remote address.
+func RateLimitController(cfg RateLimiterConfig) (mux.MiddlewareFunc, error) {
+	rl, err := NewRateLimiter(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("creating default memory: %s", err)
+		return nil, err
 	}
+	return rl.Route(""), nil
+}
 
-	return &middleware{
-		store:   defaultStore,
-		keyFunc: kf,
-		apiKey:  cfg.APIKey,
-	}, nil
+// withDefaults fills the zero values of a config with the defaults of go-limiter stores.
+func withDefaults(cfg RateLimiterRouteConfig) RateLimiterRouteConfig {
+	if cfg.MaxRPI == 0 {
+		cfg.MaxRPI = 1
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = time.Second
+	}
+	return cfg
 }
 
 func extractClientIP(r *http.Request) (string, error) {
@@ -82,12 +209,65 @@ func extractClientIP(r *http.Request) (string, error) {
 	return ip, nil
 }
 
+// rule is a rate limiting rule, which has a bucket per key in the store.
+type rule struct {
+	name string
+	key  string // if empty, the rule has a bucket per client IP
+	cfg  RateLimiterRouteConfig
+}
+
 type middleware struct {
-	store   limiter.Store
+	rl      *RateLimiter
+	store   RateLimiterStore
+	rule    rule
 	keyFunc httplimit.KeyFunc
 
-	// clients with key are not affected by rate limiter
-	apiKey string
+	// override is true if the rule is a route override, which also limits clients with an API key tier
+	override bool
+}
+
+// limitResult is the state of the bucket that limited a request.
+type limitResult struct {
+	limit     uint64
+	remaining uint64
+	reset     uint64
+	ok        bool
+}
+
+// take takes a token of the buckets that limit a client, identified by its address and API key. The
+// returned result is the one of the most restrictive bucket. It returns false if the client is unlimited.
+func (m *middleware) take(ctx context.Context, clientKey string, apiKey string) (limitResult, bool, error) {
+	rules := []rule{m.rule}
+	keys := []string{clientKey}
+	if apiKey != "" {
+		// skip rate limiting checks if the unlimited api key is provided
+		if m.rule.cfg.APIKey != "" && strings.EqualFold(apiKey, m.rule.cfg.APIKey) {
+			return limitResult{}, false, nil
+		}
+
+		// api keys with a tier are limited by key, no matter the client address
+		if tier, ok := m.rl.apiKeyTier(apiKey); ok {
+			rules, keys = []rule{tier}, []string{tier.key}
+			if m.override {
+				rules, keys = append(rules, m.rule), append(keys, tier.key)
+			}
+		}
+	}
+
+	var res limitResult
+	for i, rule := range rules {
+		remaining, reset, ok, err := m.store.Take(ctx, rule.name, keys[i], rule.cfg.MaxRPI, rule.cfg.Interval)
+		if err != nil {
+			return limitResult{}, false, err
+		}
+		if i == 0 || !ok || remaining < res.remaining {
+			res = limitResult{limit: rule.cfg.MaxRPI, remaining: remaining, reset: reset, ok: ok}
+		}
+		if !ok {
+			break
+		}
+	}
+	return res, true, nil
 }
 
 // Handle returns the HTTP handler as a middleware. This handler calls Take() on
@@ -106,27 +286,28 @@ func (m *middleware) Handle(next http.Handler) http.Handler {
 			return
 		}
 
-		// skip rate limiting checks if api key is provided
-		if key := r.Header.Get("Api-Key"); key != "" && m.apiKey != "" {
-			if strings.EqualFold(key, m.apiKey) {
-				next.ServeHTTP(w, r)
-				return
-			}
-		}
-
-		// Take from the store.
-		limit, remaining, reset, ok, err := m.store.Take(ctx, key)
+		// Take from the store. If it fails, the request isn't limited, so the API stays available.
+		res, limited, err := m.take(ctx, key, r.Header.Get("Api-Key"))
 		if err != nil {
-			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			log.Error().Err(err).Str("rule", m.rule.name).Msg("taking rate limiter token")
+		}
+		if err != nil || !limited {
+			next.ServeHTTP(w, r)
 			return
 		}
+		limit, remaining, reset, ok := res.limit, res.remaining, res.reset, res.ok
 
-		resetTime := time.Unix(0, int64(reset)).UTC().Format(time.RFC1123)
+		resetAt := time.Unix(0, int64(reset))
+		resetTime := resetAt.UTC().Format(time.RFC1123)
+		resetSeconds := strconv.FormatInt(int64(math.Ceil(math.Max(time.Until(resetAt).Seconds(), 0))), 10)
 
 		// Set headers (we do this regardless of whether the request is permitted).
 		w.Header().Set("X-RateLimit-Limit", strconv.FormatUint(limit, 10))
 		w.Header().Set("X-RateLimit-Remaining", strconv.FormatUint(remaining, 10))
 		w.Header().Set("X-RateLimit-Reset", resetTime)
+		w.Header().Set("RateLimit-Limit", strconv.FormatUint(limit, 10))
+		w.Header().Set("RateLimit-Remaining", strconv.FormatUint(remaining, 10))
+		w.Header().Set("RateLimit-Reset", resetSeconds)
 
 		// Fail if there were no tokens remaining.
 		if !ok {
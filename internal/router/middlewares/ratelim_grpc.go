@@ -0,0 +1,98 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCUnary returns the rate limiting middleware of unary gRPC calls. Calls are limited like requests to the
+// REST routes that routes maps their full method names to, sharing their quotas. The client address and API key
+// are taken from the x-forwarded-for and api-key metadata, like the headers of REST requests.
+func (rl *RateLimiter) GRPCUnary(routes map[string]string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := rl.takeGRPC(ctx, rl.route(routes[info.FullMethod])); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// GRPCStream returns the rate limiting middleware of streaming gRPC calls. See GRPCUnary.
+func (rl *RateLimiter) GRPCStream(routes map[string]string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := rl.takeGRPC(ss.Context(), rl.route(routes[info.FullMethod])); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// takeGRPC takes a token for a gRPC call, and sets the rate limiting headers. It returns a ResourceExhausted
+// error if there were no tokens remaining.
+func (rl *RateLimiter) takeGRPC(ctx context.Context, m *middleware) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	key, err := grpcClientIP(ctx, md)
+	if err != nil {
+		return status.Error(codes.Internal, "Extracting client ip")
+	}
+
+	// If the store fails, the call isn't limited, so the API stays available.
+	var apiKey string
+	if apiKeys := md.Get("api-key"); len(apiKeys) > 0 {
+		apiKey = apiKeys[0]
+	}
+	res, limited, err := m.take(ctx, key, apiKey)
+	if err != nil {
+		log.Error().Err(err).Str("rule", m.rule.name).Msg("taking rate limiter token")
+	}
+	if err != nil || !limited {
+		return nil
+	}
+
+	resetAt := time.Unix(0, int64(res.reset))
+	resetSeconds := strconv.FormatInt(int64(math.Ceil(math.Max(time.Until(resetAt).Seconds(), 0))), 10)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(
+		"ratelimit-limit", strconv.FormatUint(res.limit, 10),
+		"ratelimit-remaining", strconv.FormatUint(res.remaining, 10),
+		"ratelimit-reset", resetSeconds,
+	)); err != nil {
+		log.Warn().Err(err).Msg("failed to set the rate limit headers")
+	}
+
+	if !res.ok {
+		return status.Errorf(codes.ResourceExhausted, "Rate limit exceeded, retry in %s seconds", resetSeconds)
+	}
+	return nil
+}
+
+func grpcClientIP(ctx context.Context, md metadata.MD) (string, error) {
+	// Use X-Forwarded-For IP if present.
+	if xff := md.Get("x-forwarded-for"); len(xff) > 0 && xff[0] != "" {
+		return strings.Split(xff[0], ",")[0], nil
+	}
+
+	// Use the peer address, which might not have a port, e.g. with in-memory listeners.
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "", fmt.Errorf("missing peer")
+	}
+	ip, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String(), nil
+	}
+	return ip, nil
+}
@@ -0,0 +1,293 @@
+package middlewares
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	redisRateLimiterStoreTimeout      = time.Second
+	redisRateLimiterStoreMaxIdleConns = 16
+)
+
+// RedisRateLimiterStore is a RateLimiterStore that keeps the buckets in a server speaking the Redis protocol,
+// e.g. Redis, Valkey or KeyDB. Replicas using the same server share the rate limits, wherever they run.
+//
+// Buckets are fixed windows aligned to the unix epoch, like in SQLiteRateLimiterStore. A take is an INCR of
+// the key of the current window, pipelined with a PEXPIREAT at the end of the window, so the server deletes
+// the buckets once they expire.
+type RedisRateLimiterStore struct {
+	addr     string
+	password string
+	db       int
+
+	mu     sync.Mutex
+	idle   []*redisConn
+	closed bool
+}
+
+var _ RateLimiterStore = (*RedisRateLimiterStore)(nil)
+
+// NewRedisRateLimiterStore creates a new RedisRateLimiterStore for the server in rawURL, which has the form
+// redis://[:password@]host[:port][/db]. Connections are opened when needed, so the server isn't required to
+// be reachable yet.
+func NewRedisRateLimiterStore(rawURL string) (*RedisRateLimiterStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing url: %s", err)
+	}
+	if u.Scheme != "redis" {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, errors.New("url must have a host")
+	}
+	s := &RedisRateLimiterStore{addr: u.Host}
+	if u.Port() == "" {
+		s.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if password, ok := u.User.Password(); ok {
+		s.password = password
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		if s.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("parsing database number: %s", err)
+		}
+	}
+	return s, nil
+}
+
+// Take implements RateLimiterStore.
+func (s *RedisRateLimiterStore) Take(
+	ctx context.Context, rule string, key string, tokens uint64, interval time.Duration,
+) (uint64, uint64, bool, error) {
+	now := time.Now().UnixNano()
+	windowEnd := now - now%int64(interval) + int64(interval)
+	windowKey := fmt.Sprintf("tableland:ratelim:%s:%s:%d", rule, key, windowEnd)
+	expireAt := (windowEnd + int64(time.Millisecond) - 1) / int64(time.Millisecond)
+
+	replies, err := s.do(ctx, [][]string{
+		{"INCR", windowKey},
+		{"PEXPIREAT", windowKey, strconv.FormatInt(expireAt, 10)},
+	})
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("taking token: %s", err)
+	}
+	count, ok := replies[0].(int64)
+	if !ok {
+		return 0, 0, false, fmt.Errorf("unexpected INCR reply %v", replies[0])
+	}
+
+	if uint64(count) > tokens {
+		return 0, uint64(windowEnd), false, nil
+	}
+	return tokens - uint64(count), uint64(windowEnd), true, nil
+}
+
+// Close closes the idle connections of the store.
+func (s *RedisRateLimiterStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for _, c := range s.idle {
+		_ = c.conn.Close()
+	}
+	s.idle = nil
+	return nil
+}
+
+// do sends the commands in a single round trip and returns their replies. Error replies are returned
+// as redisError.
+func (s *RedisRateLimiterStore) do(ctx context.Context, cmds [][]string) ([]interface{}, error) {
+	c, err := s.getConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(redisRateLimiterStoreTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		_ = c.conn.Close()
+		return nil, fmt.Errorf("setting deadline: %s", err)
+	}
+
+	replies, err := c.do(cmds)
+	if err != nil {
+		// The connection is out of sync with the server unless all the replies were read.
+		var errReply redisError
+		if !errors.As(err, &errReply) {
+			_ = c.conn.Close()
+			return nil, err
+		}
+	}
+	s.putConn(c)
+	return replies, err
+}
+
+func (s *RedisRateLimiterStore) getConn(ctx context.Context) (*redisConn, error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, errors.New("store is closed")
+	}
+	if n := len(s.idle); n > 0 {
+		c := s.idle[n-1]
+		s.idle = s.idle[:n-1]
+		s.mu.Unlock()
+		return c, nil
+	}
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, redisRateLimiterStoreTimeout)
+	defer cancel()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %s", s.addr, err)
+	}
+	c := &redisConn{conn: conn, r: bufio.NewReader(conn)}
+
+	var handshake [][]string
+	if s.password != "" {
+		handshake = append(handshake, []string{"AUTH", s.password})
+	}
+	if s.db != 0 {
+		handshake = append(handshake, []string{"SELECT", strconv.Itoa(s.db)})
+	}
+	if len(handshake) > 0 {
+		deadline, _ := ctx.Deadline()
+		if err := conn.SetDeadline(deadline); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("setting deadline: %s", err)
+		}
+		if _, err := c.do(handshake); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("initializing connection: %s", err)
+		}
+	}
+	return c, nil
+}
+
+func (s *RedisRateLimiterStore) putConn(c *redisConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || len(s.idle) >= redisRateLimiterStoreMaxIdleConns {
+		_ = c.conn.Close()
+		return
+	}
+	s.idle = append(s.idle, c)
+}
+
+// redisError is an error reply of the server.
+type redisError string
+
+func (e redisError) Error() string {
+	return string(e)
+}
+
+// redisConn is a connection speaking RESP, the protocol of Redis.
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// do pipelines the commands and reads all their replies. If any reply is an error, the first one is returned.
+func (c *redisConn) do(cmds [][]string) ([]interface{}, error) {
+	var b strings.Builder
+	for _, args := range cmds {
+		writeRESPArray(&b, args)
+	}
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return nil, fmt.Errorf("sending commands: %s", err)
+	}
+
+	replies := make([]interface{}, len(cmds))
+	var errReply error
+	for i := range cmds {
+		reply, err := readRESP(c.r)
+		if err != nil {
+			return nil, fmt.Errorf("reading reply: %s", err)
+		}
+		if err, ok := reply.(redisError); ok && errReply == nil {
+			errReply = err
+		}
+		replies[i] = reply
+	}
+	return replies, errReply
+}
+
+// writeRESPArray writes args as an array of bulk strings, which is how commands are sent.
+func writeRESPArray(b *strings.Builder, args []string) {
+	b.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		b.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n")
+	}
+}
+
+// readRESP reads a RESP value. Simple and bulk strings are returned as strings, or nil for null bulk
+// strings, integers as int64, errors as redisError, and arrays as []interface{}.
+func readRESP(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || !strings.HasSuffix(line, "\r\n") {
+		return nil, fmt.Errorf("malformed line %q", line)
+	}
+	kind, value := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return value, nil
+	case '-':
+		return redisError(value), nil
+	case ':':
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing integer: %s", err)
+		}
+		return n, nil
+	case '$':
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("parsing bulk string length: %s", err)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("parsing array length: %s", err)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		values := make([]interface{}, n)
+		for i := range values {
+			if values[i], err = readRESP(r); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unknown reply type %q", kind)
+	}
+}
@@ -0,0 +1,123 @@
+package middlewares
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver
+	logger "github.com/rs/zerolog/log"
+)
+
+const sqliteRateLimiterStoreCleanupInterval = time.Minute
+
+// SQLiteRateLimiterStore is a RateLimiterStore that keeps the buckets in a SQLite database.
+// Replicas opening the same database file share the rate limits. SQLite relies on file locks,
+// which network filesystems don't implement reliably, so the replicas must run on the same host,
+// e.g. containers sharing a volume. Limits across hosts need RedisRateLimiterStore.
+//
+// The buckets aren't worth an fsync, so the database is opened with synchronous=OFF. A crash
+// of the host can at most reset some buckets.
+//
+// Buckets are fixed windows aligned to the unix epoch, so all the replicas agree on when
+// a bucket is refilled without coordinating.
+type SQLiteRateLimiterStore struct {
+	db *sql.DB
+
+	closeCtx  context.Context
+	closeFunc context.CancelFunc
+	closed    chan struct{}
+}
+
+var _ RateLimiterStore = (*SQLiteRateLimiterStore)(nil)
+
+// NewSQLiteRateLimiterStore creates a new SQLiteRateLimiterStore from the database in path.
+func NewSQLiteRateLimiterStore(path string) (*SQLiteRateLimiterStore, error) {
+	// A relative path would be the host of the file URI.
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("getting absolute path: %s", err)
+	}
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file://%s?_busy_timeout=5000&_synchronous=OFF", absPath))
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %s", err)
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS rate_limits (
+		rule TEXT NOT NULL,
+		key TEXT NOT NULL,
+		reset_at INTEGER NOT NULL,
+		count INTEGER NOT NULL,
+
+		PRIMARY KEY(rule, key)
+	)`); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("creating rate_limits table: %s", err)
+	}
+
+	ctx, cls := context.WithCancel(context.Background())
+	s := &SQLiteRateLimiterStore{
+		db:        db,
+		closeCtx:  ctx,
+		closeFunc: cls,
+		closed:    make(chan struct{}),
+	}
+	go s.cleanup()
+
+	return s, nil
+}
+
+// Take implements RateLimiterStore.
+func (s *SQLiteRateLimiterStore) Take(
+	ctx context.Context, rule string, key string, tokens uint64, interval time.Duration,
+) (uint64, uint64, bool, error) {
+	now := time.Now().UnixNano()
+	windowEnd := now - now%int64(interval) + int64(interval)
+
+	var resetAt int64
+	var count uint64
+	if err := s.db.QueryRowContext(ctx, `
+		INSERT INTO rate_limits (rule, key, reset_at, count) VALUES (?1, ?2, ?3, 1)
+		ON CONFLICT (rule, key) DO UPDATE SET
+			count = CASE WHEN reset_at > ?4 THEN count + 1 ELSE 1 END,
+			reset_at = CASE WHEN reset_at > ?4 THEN reset_at ELSE ?3 END
+		RETURNING reset_at, count`, rule, key, windowEnd, now).Scan(&resetAt, &count); err != nil {
+		return 0, 0, false, fmt.Errorf("taking token: %s", err)
+	}
+
+	if count > tokens {
+		return 0, uint64(resetAt), false, nil
+	}
+	return tokens - count, uint64(resetAt), true, nil
+}
+
+// Close closes the store.
+func (s *SQLiteRateLimiterStore) Close() error {
+	s.closeFunc()
+	<-s.closed
+	if err := s.db.Close(); err != nil {
+		return fmt.Errorf("closing database: %s", err)
+	}
+	return nil
+}
+
+// cleanup deletes the expired buckets periodically.
+func (s *SQLiteRateLimiterStore) cleanup() {
+	defer close(s.closed)
+	log := logger.With().Str("component", "ratelimiter").Logger()
+
+	ticker := time.NewTicker(sqliteRateLimiterStoreCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closeCtx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.db.ExecContext(s.closeCtx,
+				"DELETE FROM rate_limits WHERE reset_at <= ?1", time.Now().UnixNano()); err != nil {
+				log.Error().Err(err).Msg("deleting expired rate limits")
+			}
+		}
+	}
+}
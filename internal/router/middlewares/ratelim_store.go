@@ -0,0 +1,74 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sethvargo/go-limiter"
+	"github.com/sethvargo/go-limiter/memorystore"
+)
+
+// RateLimiterStore keeps the token buckets of the rate limiting rules. A store shared by
+// many validator replicas makes the limits hold across all of them, instead of each
+// replica allowing the configured rate. If a store fails, requests aren't limited.
+//
+// SQLiteRateLimiterStore is shared by replicas on the same host, and RedisRateLimiterStore
+// by replicas on any host.
+type RateLimiterStore interface {
+	// Take takes a token from the bucket of key in rule, which allows tokens per interval.
+	// It returns the remaining tokens in the interval, the time in unix nanoseconds when
+	// new tokens will be available, and whether the take was successful.
+	Take(
+		ctx context.Context, rule string, key string, tokens uint64, interval time.Duration,
+	) (remaining uint64, reset uint64, ok bool, err error)
+}
+
+// MemoryRateLimiterStore is a RateLimiterStore that keeps the buckets in memory.
+type MemoryRateLimiterStore struct {
+	mu     sync.Mutex
+	stores map[string]limiter.Store
+}
+
+var _ RateLimiterStore = (*MemoryRateLimiterStore)(nil)
+
+// NewMemoryRateLimiterStore creates a new MemoryRateLimiterStore.
+func NewMemoryRateLimiterStore() *MemoryRateLimiterStore {
+	return &MemoryRateLimiterStore{
+		stores: map[string]limiter.Store{},
+	}
+}
+
+// Take implements RateLimiterStore.
+func (s *MemoryRateLimiterStore) Take(
+	ctx context.Context, rule string, key string, tokens uint64, interval time.Duration,
+) (uint64, uint64, bool, error) {
+	store, err := s.ruleStore(rule, tokens, interval)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	_, remaining, reset, ok, err := store.Take(ctx, key)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("take from memory store: %s", err)
+	}
+	return remaining, reset, ok, nil
+}
+
+func (s *MemoryRateLimiterStore) ruleStore(rule string, tokens uint64, interval time.Duration) (limiter.Store, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if store, ok := s.stores[rule]; ok {
+		return store, nil
+	}
+	store, err := memorystore.New(&memorystore.Config{
+		Tokens:   tokens,
+		Interval: interval,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating memory store: %s", err)
+	}
+	s.stores[rule] = store
+	return store, nil
+}
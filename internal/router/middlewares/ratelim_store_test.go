@@ -0,0 +1,266 @@
+package middlewares
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteRateLimiterStore(t *testing.T) {
+	t.Parallel()
+
+	dbPath := path.Join(t.TempDir(), "ratelim.db")
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	relPath, err := filepath.Rel(wd, dbPath)
+	require.NoError(t, err)
+
+	// Two stores on the same database act as two replicas, and relative paths work too.
+	store1, err := NewSQLiteRateLimiterStore(dbPath)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store1.Close()) }()
+	store2, err := NewSQLiteRateLimiterStore(relPath)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store2.Close()) }()
+
+	testSharedRateLimiterStore(t, store1, store2)
+}
+
+func TestRedisRateLimiterStore(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeRedisServer(t, "secret")
+
+	// Two stores on the same server act as two replicas.
+	store1, err := NewRedisRateLimiterStore("redis://:secret@" + server.addr + "/2")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store1.Close()) }()
+	store2, err := NewRedisRateLimiterStore("redis://:secret@" + server.addr + "/2")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store2.Close()) }()
+
+	testSharedRateLimiterStore(t, store1, store2)
+
+	// Buckets expire at the end of their window.
+	server.mu.Lock()
+	for key, expireAt := range server.expireAt {
+		require.True(t, strings.HasPrefix(key, "2:tableland:ratelim:"))
+		require.Greater(t, expireAt, int64(0))
+	}
+	server.mu.Unlock()
+
+	// The server authenticates the connections.
+	store, err := NewRedisRateLimiterStore("redis://" + server.addr)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Close()) }()
+	_, _, _, err = store.Take(context.Background(), "default", "ip1", 4, time.Second)
+	require.ErrorContains(t, err, "NOAUTH")
+
+	_, err = NewRedisRateLimiterStore("http://" + server.addr)
+	require.Error(t, err)
+}
+
+// testSharedRateLimiterStore checks that two stores keeping the buckets in the same place share them.
+func testSharedRateLimiterStore(t *testing.T, store1 RateLimiterStore, store2 RateLimiterStore) {
+	ctx := context.Background()
+
+	// Start at the beginning of a window, so all the takes fall in the same one.
+	interval := 2 * time.Second
+	time.Sleep(interval - time.Duration(time.Now().UnixNano()%int64(interval)))
+	for i := 0; i < 4; i++ {
+		remaining, reset, ok, err := []RateLimiterStore{store1, store2}[i%2].Take(ctx, "default", "ip1", 4, interval)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, uint64(3-i), remaining)
+		require.Greater(t, int64(reset), time.Now().UnixNano())
+	}
+	_, reset, ok, err := store1.Take(ctx, "default", "ip1", 4, interval)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Other keys and rules have their own bucket.
+	_, _, ok, err = store2.Take(ctx, "default", "ip2", 4, interval)
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, _, ok, err = store2.Take(ctx, "route:Version", "ip1", 4, interval)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// The bucket is refilled after the reset time.
+	time.Sleep(time.Until(time.Unix(0, int64(reset))))
+	remaining, _, ok, err := store2.Take(ctx, "default", "ip1", 4, interval)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(3), remaining)
+}
+
+func TestRateLimSharedStore(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewSQLiteRateLimiterStore(path.Join(t.TempDir(), "ratelim.db"))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Close()) }()
+
+	cfg := RateLimiterConfig{
+		Default: RateLimiterRouteConfig{MaxRPI: 3, Interval: time.Hour},
+		APIKeys: map[string]RateLimiterRouteConfig{
+			"gold": {MaxRPI: 2, Interval: time.Hour},
+		},
+		Store: store,
+	}
+	replica1, err := NewRateLimiter(cfg)
+	require.NoError(t, err)
+	replica2, err := NewRateLimiter(cfg)
+	require.NoError(t, err)
+
+	call := func(rl *RateLimiter, apiKey string) int {
+		r, err := http.NewRequestWithContext(context.Background(), "", "", nil)
+		require.NoError(t, err)
+		r.Header.Set("X-Forwarded-For", "10.0.0.1")
+		if apiKey != "" {
+			r.Header.Set("Api-Key", apiKey)
+		}
+		res := httptest.NewRecorder()
+		rl.Route("Version")(dummyHandler{}).ServeHTTP(res, r)
+		return res.Code
+	}
+
+	// The limits hold across replicas.
+	require.Equal(t, 200, call(replica1, ""))
+	require.Equal(t, 200, call(replica2, ""))
+	require.Equal(t, 200, call(replica1, ""))
+	require.Equal(t, 429, call(replica2, ""))
+
+	require.Equal(t, 200, call(replica2, "gold"))
+	require.Equal(t, 200, call(replica1, "gold"))
+	require.Equal(t, 429, call(replica2, "gold"))
+}
+
+func TestRateLimFailingStore(t *testing.T) {
+	t.Parallel()
+
+	rl, err := NewRateLimiter(RateLimiterConfig{
+		Default: RateLimiterRouteConfig{MaxRPI: 1, Interval: time.Hour},
+		Store:   failingStore{},
+	})
+	require.NoError(t, err)
+
+	// Requests aren't limited while the store fails.
+	for i := 0; i < 3; i++ {
+		r, err := http.NewRequestWithContext(context.Background(), "", "", nil)
+		require.NoError(t, err)
+		r.Header.Set("X-Forwarded-For", "10.0.0.1")
+		res := httptest.NewRecorder()
+		rl.Route("Version")(dummyHandler{}).ServeHTTP(res, r)
+		require.Equal(t, 200, res.Code)
+		require.Empty(t, res.Header().Get("RateLimit-Limit"))
+	}
+}
+
+// fakeRedisServer is a stand-in of a Redis server that supports the commands used by RedisRateLimiterStore.
+type fakeRedisServer struct {
+	addr     string
+	password string
+
+	mu       sync.Mutex
+	values   map[string]int64
+	expireAt map[string]int64
+}
+
+func newFakeRedisServer(t *testing.T, password string) *fakeRedisServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	s := &fakeRedisServer{
+		addr:     l.Addr().String(),
+		password: password,
+		values:   map[string]int64{},
+		expireAt: map[string]int64{},
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeRedisServer) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	r := bufio.NewReader(conn)
+	authenticated, db := s.password == "", "0"
+	for {
+		cmd, err := readRESP(r)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, arg := range cmd.([]interface{}) {
+			args = append(args, arg.(string))
+		}
+
+		var reply string
+		switch {
+		case args[0] == "AUTH":
+			authenticated = args[1] == s.password
+			reply = "+OK\r\n"
+			if !authenticated {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case args[0] == "SELECT":
+			db, reply = args[1], "+OK\r\n"
+		case args[0] == "INCR":
+			s.mu.Lock()
+			key := db + ":" + args[1]
+			if expireAt, ok := s.expireAt[key]; ok && expireAt <= time.Now().UnixMilli() {
+				delete(s.values, key)
+				delete(s.expireAt, key)
+			}
+			s.values[key]++
+			reply = ":" + strconv.FormatInt(s.values[key], 10) + "\r\n"
+			s.mu.Unlock()
+		case args[0] == "PEXPIREAT":
+			expireAt, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				reply = "-ERR value is not an integer\r\n"
+				break
+			}
+			s.mu.Lock()
+			s.expireAt[db+":"+args[1]] = expireAt
+			s.mu.Unlock()
+			reply = ":1\r\n"
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, string, uint64, time.Duration) (uint64, uint64, bool, error) {
+	return 0, 0, false, errors.New("database is locked")
+}
@@ -115,6 +115,147 @@ func TestRateLim10IPs(t *testing.T) {
 	}
 }
 
+func TestRateLimAPIKeyTiers(t *testing.T) {
+	t.Parallel()
+
+	rl, err := NewRateLimiter(RateLimiterConfig{
+		Default: RateLimiterRouteConfig{MaxRPI: 2, Interval: time.Hour},
+		APIKeys: map[string]RateLimiterRouteConfig{
+			"gold":   {MaxRPI: 5, Interval: time.Hour},
+			"silver": {MaxRPI: 3, Interval: time.Hour},
+		},
+	})
+	require.NoError(t, err)
+	rlcA, rlcB := rl.Route("A")(dummyHandler{}), rl.Route("B")(dummyHandler{})
+
+	call := func(rlc http.Handler, ip string, apiKey string) *httptest.ResponseRecorder {
+		r, err := http.NewRequestWithContext(context.Background(), "", "", nil)
+		require.NoError(t, err)
+		r.Header.Set("X-Forwarded-For", ip)
+		if apiKey != "" {
+			r.Header.Set("Api-Key", apiKey)
+		}
+		res := httptest.NewRecorder()
+		rlc.ServeHTTP(res, r)
+		return res
+	}
+
+	// The gold quota is shared by all the routes and client addresses using the key.
+	for i := 0; i < 5; i++ {
+		res := call([]http.Handler{rlcA, rlcB}[i%2], uuid.NewString(), "gold")
+		require.Equal(t, 200, res.Code)
+		require.Equal(t, "5", res.Header().Get("RateLimit-Limit"))
+		require.Equal(t, strconv.Itoa(4-i), res.Header().Get("RateLimit-Remaining"))
+		require.Equal(t, strconv.Itoa(4-i), res.Header().Get("X-RateLimit-Remaining"))
+	}
+	res := call(rlcA, uuid.NewString(), "gold")
+	require.Equal(t, 429, res.Code)
+	require.NotEmpty(t, res.Header().Get("Retry-After"))
+
+	// Other keys have their own quota.
+	for i := 0; i < 3; i++ {
+		require.Equal(t, 200, call(rlcA, uuid.NewString(), "silver").Code)
+	}
+	require.Equal(t, 429, call(rlcA, uuid.NewString(), "silver").Code)
+
+	// Unknown keys are limited by client address with the default config.
+	ip := uuid.NewString()
+	require.Equal(t, 200, call(rlcA, ip, "unknown").Code)
+	res = call(rlcA, ip, "unknown")
+	require.Equal(t, 200, res.Code)
+	require.Equal(t, "2", res.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "0", res.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, 429, call(rlcA, ip, "").Code)
+}
+
+func TestRateLimRouteOverride(t *testing.T) {
+	t.Parallel()
+
+	rl, err := NewRateLimiter(RateLimiterConfig{
+		Default: RateLimiterRouteConfig{MaxRPI: 5, Interval: time.Hour},
+		Routes: map[string]RateLimiterRouteConfig{
+			"QueryByStatementPost": {MaxRPI: 1, Interval: time.Hour},
+		},
+	})
+	require.NoError(t, err)
+
+	call := func(route string) *httptest.ResponseRecorder {
+		r, err := http.NewRequestWithContext(context.Background(), "", "", nil)
+		require.NoError(t, err)
+		r.Header.Set("X-Forwarded-For", "10.0.0.1")
+		res := httptest.NewRecorder()
+		rl.Route(route)(dummyHandler{}).ServeHTTP(res, r)
+		return res
+	}
+
+	res := call("QueryByStatementPost")
+	require.Equal(t, 200, res.Code)
+	require.Equal(t, "1", res.Header().Get("RateLimit-Limit"))
+	require.Equal(t, 429, call("QueryByStatementPost").Code)
+
+	// Routes without an override share the default quota.
+	for i := 0; i < 5; i++ {
+		res := call([]string{"QueryByStatement", "Version"}[i%2])
+		require.Equal(t, 200, res.Code)
+		require.Equal(t, "5", res.Header().Get("RateLimit-Limit"))
+	}
+	require.Equal(t, 429, call("Health").Code)
+}
+
+func TestRateLimAPIKeyRouteOverride(t *testing.T) {
+	t.Parallel()
+
+	rl, err := NewRateLimiter(RateLimiterConfig{
+		Default: RateLimiterRouteConfig{MaxRPI: 1, Interval: time.Hour},
+		Routes: map[string]RateLimiterRouteConfig{
+			"QueryByStatementPost": {MaxRPI: 2, Interval: time.Hour},
+		},
+		APIKeys: map[string]RateLimiterRouteConfig{
+			"Gold": {MaxRPI: 4, Interval: time.Hour},
+		},
+	})
+	require.NoError(t, err)
+
+	call := func(route string, apiKey string) *httptest.ResponseRecorder {
+		r, err := http.NewRequestWithContext(context.Background(), "", "", nil)
+		require.NoError(t, err)
+		r.Header.Set("X-Forwarded-For", uuid.NewString())
+		r.Header.Set("Api-Key", apiKey)
+		res := httptest.NewRecorder()
+		rl.Route(route)(dummyHandler{}).ServeHTTP(res, r)
+		return res
+	}
+
+	// The stricter route override applies to the key, which is matched case-insensitively.
+	res := call("QueryByStatementPost", "gold")
+	require.Equal(t, 200, res.Code)
+	require.Equal(t, "2", res.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "1", res.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, 200, call("QueryByStatementPost", "GOLD").Code)
+	require.Equal(t, 429, call("QueryByStatementPost", "gold").Code)
+
+	// The tier quota is still available for other routes.
+	res = call("Version", "gold")
+	require.Equal(t, 200, res.Code)
+	require.Equal(t, "4", res.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "0", res.Header().Get("RateLimit-Remaining"))
+
+	// Reloaded keys keep their quota, and removed keys are limited by client address.
+	require.NoError(t, rl.SetAPIKeys(map[string]RateLimiterRouteConfig{
+		"gold":   {MaxRPI: 4, Interval: time.Hour},
+		"silver": {MaxRPI: 2, Interval: time.Hour},
+	}))
+	require.Equal(t, 429, call("Version", "gold").Code)
+	res = call("Version", "silver")
+	require.Equal(t, 200, res.Code)
+	require.Equal(t, "2", res.Header().Get("RateLimit-Limit"))
+
+	require.NoError(t, rl.SetAPIKeys(nil))
+	res = call("Version", "silver")
+	require.Equal(t, 200, res.Code)
+	require.Equal(t, "1", res.Header().Get("RateLimit-Limit"))
+}
+
 type dummyHandler struct{}
 
 func (dh dummyHandler) ServeHTTP(_ http.ResponseWriter, _ *http.Request) {
@@ -3,7 +3,6 @@ package router
 import (
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/textileio/go-tableland/internal/gateway"
@@ -11,47 +10,46 @@ import (
 	"github.com/textileio/go-tableland/internal/router/controllers/apiv1"
 	"github.com/textileio/go-tableland/internal/router/middlewares"
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/pubsub"
 )
 
 // ConfiguredRouter returns a fully configured Router that can be used as an http handler.
 func ConfiguredRouter(
 	gateway gateway.Gateway,
-	maxRPI uint64,
-	rateLimInterval time.Duration,
+	hub *pubsub.Hub,
+	rateLim *middlewares.RateLimiter,
 	supportedChainIDs []tableland.ChainID,
-	apiKey string,
+	ctrlOpts ...controllers.ControllerOption,
 ) (*Router, error) {
 	// General router configuration.
 	router := newRouter()
 	router.use(middlewares.CORS, middlewares.TraceID)
 
-	cfg := middlewares.RateLimiterConfig{
-		Default: middlewares.RateLimiterRouteConfig{
-			MaxRPI:   maxRPI,
-			Interval: rateLimInterval,
-			APIKey:   apiKey,
-		},
-	}
-	rateLim, err := middlewares.RateLimitController(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("creating rate limit controller middleware: %s", err)
-	}
-
-	ctrl := controllers.NewController(gateway)
+	ctrlOpts = append([]controllers.ControllerOption{controllers.WithSupportedChainIDs(supportedChainIDs)}, ctrlOpts...)
+	ctrl := controllers.NewController(gateway, ctrlOpts...)
+	subCtrl := controllers.NewSubscriptionController(gateway, hub)
 
 	// APIs V1
-	if err := configureAPIV1Routes(router, supportedChainIDs, rateLim, ctrl); err != nil {
+	if err := configureAPIV1Routes(router, supportedChainIDs, rateLim, ctrl, subCtrl); err != nil {
 		return nil, fmt.Errorf("configuring API v1: %s", err)
 	}
 
+	// GraphQL isn't part of the API v1 spec, but it's served next to it.
+	graphQLMiddlewares := []mux.MiddlewareFunc{
+		middlewares.OtelHTTP("GraphQL"), middlewares.WithLogging, rateLim.Route("GraphQL"),
+	}
+	router.get("/api/v1/graphql", ctrl.GraphQL, graphQLMiddlewares...)
+	router.post("/api/v1/graphql", ctrl.GraphQL, graphQLMiddlewares...)
+
 	return router, nil
 }
 
 func configureAPIV1Routes(
 	router *Router,
 	supportedChainIDs []tableland.ChainID,
-	rateLim mux.MiddlewareFunc,
+	rateLim *middlewares.RateLimiter,
 	userCtrl *controllers.Controller,
+	subCtrl *controllers.SubscriptionController,
 ) error {
 	handlers := map[string]struct {
 		handler     http.HandlerFunc
@@ -59,27 +57,71 @@ func configureAPIV1Routes(
 	}{
 		"QueryByStatement": {
 			userCtrl.GetTableQuery,
-			[]mux.MiddlewareFunc{middlewares.WithLogging, rateLim},
+			[]mux.MiddlewareFunc{middlewares.WithLogging},
 		},
 		"QueryByStatementPost": {
 			userCtrl.PostTableQuery,
-			[]mux.MiddlewareFunc{middlewares.WithLogging, rateLim},
+			[]mux.MiddlewareFunc{middlewares.WithLogging},
+		},
+		"QueryByStatementBatch": {
+			userCtrl.PostTableQueryBatch,
+			[]mux.MiddlewareFunc{middlewares.WithLogging},
 		},
 		"ReceiptByTransactionHash": {
 			userCtrl.GetReceiptByTransactionHash,
-			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs), rateLim},
+			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)},
+		},
+		"SubscribeToTables": {
+			subCtrl.SubscribeToTables,
+			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)},
 		},
 		"GetTableById": {
 			userCtrl.GetTable,
-			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs), rateLim},
+			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)},
+		},
+		"GetTableAcl": {
+			userCtrl.GetTableACL,
+			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)},
+		},
+		"GetTableStats": {
+			userCtrl.GetTableStats,
+			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)},
+		},
+		"GetTableImage": {
+			userCtrl.GetTableImage,
+			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)},
+		},
+		"ListReceipts": {
+			userCtrl.ListReceipts,
+			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)},
+		},
+		"ReceiptsByTransactionHashes": {
+			userCtrl.GetReceiptsByTransactionHashes,
+			[]mux.MiddlewareFunc{middlewares.WithLogging},
+		},
+		"GetStateHash": {
+			userCtrl.GetStateHash,
+			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)},
+		},
+		"RelayWrite": {
+			userCtrl.RelayWrite,
+			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)},
+		},
+		"GetTableEvents": {
+			userCtrl.GetTableEvents,
+			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)},
+		},
+		"ListTables": {
+			userCtrl.ListTables,
+			[]mux.MiddlewareFunc{middlewares.WithLogging, middlewares.RESTChainID(supportedChainIDs)},
 		},
 		"Version": {
 			userCtrl.Version,
-			[]mux.MiddlewareFunc{middlewares.WithLogging, rateLim},
+			[]mux.MiddlewareFunc{middlewares.WithLogging},
 		},
 		"Health": {
 			controllers.HealthHandler,
-			[]mux.MiddlewareFunc{middlewares.WithLogging, rateLim},
+			[]mux.MiddlewareFunc{middlewares.WithLogging},
 		},
 	}
 
@@ -106,19 +148,23 @@ func configureAPIV1Routes(
 			return fmt.Errorf("get method: %s", err)
 		}
 
+		// Rate limiting is always the last middleware, using the route override if there's one.
+		mids := append([]mux.MiddlewareFunc{middlewares.OtelHTTP(routeName)}, endpoint.middlewares...)
+		mids = append(mids, rateLim.Route(routeName))
+
 		for _, method := range methods {
 			switch method {
 			case "GET":
 				router.get(
 					pathTemplate,
 					endpoint.handler,
-					append([]mux.MiddlewareFunc{middlewares.OtelHTTP(routeName)}, endpoint.middlewares...)...,
+					mids...,
 				)
 			case "POST":
 				router.post(
 					pathTemplate,
 					endpoint.handler,
-					append([]mux.MiddlewareFunc{middlewares.OtelHTTP(routeName)}, endpoint.middlewares...)...,
+					mids...,
 				)
 			default:
 				return fmt.Errorf("unknown method")
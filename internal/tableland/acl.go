@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -14,6 +15,26 @@ import (
 type ACL interface {
 	// CheckPrivileges checks if an address can execute a specific operation on a table.
 	CheckPrivileges(context.Context, *sql.Tx, ChainID, common.Address, tables.TableID, Operation) (bool, error)
+
+	// GetTableACL returns the access control rules of a table.
+	GetTableACL(context.Context, ChainID, tables.TableID) (TableACL, error)
+}
+
+// TableACL holds the access control rules of a table.
+type TableACL struct {
+	// Controller is the address of the controller contract of the table, or empty if it has none.
+	Controller string
+
+	// Rules are the privileges granted to each address, in the order they were first granted.
+	Rules []ACLRule
+}
+
+// ACLRule represents the privileges granted to an address on a table.
+type ACLRule struct {
+	Controller common.Address
+	Privileges Privileges
+	CreatedAt  time.Time
+	UpdatedAt  *time.Time
 }
 
 // Privilege maps to SQL privilege and is the thing needed to execute an operation.
@@ -56,6 +77,17 @@ func NewPrivilegeFromSQLString(s string) (Privilege, error) {
 	return Privilege{}, fmt.Errorf("unsupported string=%s", s)
 }
 
+// NewPrivilegesFromBitfield converts a privileges bitfield, as stored in the acl table, into Privileges.
+func NewPrivilegesFromBitfield(bitfield int) Privileges {
+	var privileges Privileges
+	for _, privilege := range []Privilege{PrivInsert, PrivUpdate, PrivDelete} {
+		if bitfield&privilege.Bitfield > 0 {
+			privileges = append(privileges, privilege)
+		}
+	}
+	return privileges
+}
+
 // ToSQLString returns the SQL string representation of a Privilege.
 func (p Privilege) ToSQLString() string {
 	switch p {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/textileio/go-tableland/internal/tableland"
@@ -61,6 +62,48 @@ func (acl *ACLStore) CheckPrivileges(
 	return true, nil
 }
 
+// GetTableACL returns the access control rules of a table.
+func (acl *ACLStore) GetTableACL(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	id tables.TableID,
+) (tableland.TableACL, error) {
+	rows, err := acl.db.Queries.ListAclByTable(ctx, db.ListAclByTableParams{
+		ChainID: int64(chainID),
+		TableID: id.ToBigInt().Int64(),
+	})
+	if err != nil {
+		return tableland.TableACL{}, fmt.Errorf("acl lookup: %s", err)
+	}
+
+	controller, err := acl.db.Queries.GetController(ctx, db.GetControllerParams{
+		ChainID: int64(chainID),
+		TableID: id.ToBigInt().Int64(),
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return tableland.TableACL{}, fmt.Errorf("controller lookup: %s", err)
+	}
+
+	tableACL := tableland.TableACL{
+		Controller: controller,
+		Rules:      make([]tableland.ACLRule, len(rows)),
+	}
+	for i, row := range rows {
+		rule := tableland.ACLRule{
+			Controller: common.HexToAddress(row.Controller),
+			Privileges: tableland.NewPrivilegesFromBitfield(row.Privileges),
+			CreatedAt:  time.Unix(row.CreatedAt, 0),
+		}
+		if row.UpdatedAt.Valid {
+			updatedAt := time.Unix(row.UpdatedAt.Int64, 0)
+			rule.UpdatedAt = &updatedAt
+		}
+		tableACL.Rules[i] = rule
+	}
+
+	return tableACL, nil
+}
+
 // transforms the ACL data transfer object to ACL object model.
 func transformToObject(acl db.SystemAcl) (SystemACL, error) {
 	id, err := tables.NewTableIDFromInt64(acl.TableID)
@@ -68,16 +111,7 @@ func transformToObject(acl db.SystemAcl) (SystemACL, error) {
 		return SystemACL{}, fmt.Errorf("parsing id to string: %s", err)
 	}
 
-	var privileges tableland.Privileges
-	if acl.Privileges&tableland.PrivInsert.Bitfield > 0 {
-		privileges = append(privileges, tableland.PrivInsert)
-	}
-	if acl.Privileges&tableland.PrivUpdate.Bitfield > 0 {
-		privileges = append(privileges, tableland.PrivUpdate)
-	}
-	if acl.Privileges&tableland.PrivDelete.Bitfield > 0 {
-		privileges = append(privileges, tableland.PrivDelete)
-	}
+	privileges := tableland.NewPrivilegesFromBitfield(acl.Privileges)
 
 	systemACL := SystemACL{
 		ChainID:    tableland.ChainID(acl.ChainID),
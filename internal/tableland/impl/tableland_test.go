@@ -157,7 +157,7 @@ func TestReadSystemTable(t *testing.T) {
 	_, err := sc.CreateTable(txOpts, caller, `CREATE TABLE foo_1337 (myjson TEXT);`)
 	require.NoError(t, err)
 
-	res, err := gateway.RunReadQuery(ctx, "select * from registry", []string{})
+	res, err := gateway.RunReadQuery(ctx, "select * from registry", []any{})
 	require.NoError(t, err)
 	_, err = json.Marshal(res)
 	require.NoError(t, err)
@@ -551,7 +551,7 @@ func jsonEq(
 	expJSON string,
 ) func() bool {
 	return func() bool {
-		r, err := gateway.RunReadQuery(ctx, stm, []string{})
+		r, err := gateway.RunReadQuery(ctx, stm, []any{})
 		// if we get a table undefined error, try again
 		if err != nil && strings.Contains(err.Error(), "no such table") {
 			return false
@@ -587,7 +587,7 @@ func runSQLCountEq(
 	expCount int,
 ) func() bool {
 	return func() bool {
-		response, err := gateway.RunReadQuery(ctx, sql, []string{})
+		response, err := gateway.RunReadQuery(ctx, sql, []any{})
 		// if we get a table undefined error, try again
 		if err != nil && strings.Contains(err.Error(), "table not found") {
 			return false
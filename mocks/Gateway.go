@@ -29,6 +29,96 @@ func (_m *Gateway) EXPECT() *Gateway_Expecter {
 	return &Gateway_Expecter{mock: &_m.Mock}
 }
 
+// GetBlockHeights provides a mock function with given fields: _a0, _a1
+func (_m *Gateway) GetBlockHeights(_a0 context.Context, _a1 []tableland.ChainID) (gateway.BlockHeights, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 gateway.BlockHeights
+	if rf, ok := ret.Get(0).(func(context.Context, []tableland.ChainID) gateway.BlockHeights); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(gateway.BlockHeights)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []tableland.ChainID) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Gateway_GetBlockHeights_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockHeights'
+type Gateway_GetBlockHeights_Call struct {
+	*mock.Call
+}
+
+// GetBlockHeights is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []tableland.ChainID
+func (_e *Gateway_Expecter) GetBlockHeights(_a0 interface{}, _a1 interface{}) *Gateway_GetBlockHeights_Call {
+	return &Gateway_GetBlockHeights_Call{Call: _e.mock.On("GetBlockHeights", _a0, _a1)}
+}
+
+func (_c *Gateway_GetBlockHeights_Call) Run(run func(_a0 context.Context, _a1 []tableland.ChainID)) *Gateway_GetBlockHeights_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]tableland.ChainID))
+	})
+	return _c
+}
+
+func (_c *Gateway_GetBlockHeights_Call) Return(_a0 gateway.BlockHeights, _a1 error) *Gateway_GetBlockHeights_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GetReadQueryHeights provides a mock function with given fields: ctx, stmt
+func (_m *Gateway) GetReadQueryHeights(ctx context.Context, stmt string) (gateway.BlockHeights, error) {
+	ret := _m.Called(ctx, stmt)
+
+	var r0 gateway.BlockHeights
+	if rf, ok := ret.Get(0).(func(context.Context, string) gateway.BlockHeights); ok {
+		r0 = rf(ctx, stmt)
+	} else {
+		r0 = ret.Get(0).(gateway.BlockHeights)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stmt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Gateway_GetReadQueryHeights_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReadQueryHeights'
+type Gateway_GetReadQueryHeights_Call struct {
+	*mock.Call
+}
+
+// GetReadQueryHeights is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stmt string
+func (_e *Gateway_Expecter) GetReadQueryHeights(ctx interface{}, stmt interface{}) *Gateway_GetReadQueryHeights_Call {
+	return &Gateway_GetReadQueryHeights_Call{Call: _e.mock.On("GetReadQueryHeights", ctx, stmt)}
+}
+
+func (_c *Gateway_GetReadQueryHeights_Call) Run(run func(ctx context.Context, stmt string)) *Gateway_GetReadQueryHeights_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Gateway_GetReadQueryHeights_Call) Return(_a0 gateway.BlockHeights, _a1 error) *Gateway_GetReadQueryHeights_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // GetReceiptByTransactionHash provides a mock function with given fields: _a0, _a1, _a2
 func (_m *Gateway) GetReceiptByTransactionHash(_a0 context.Context, _a1 tableland.ChainID, _a2 common.Hash) (gateway.Receipt, bool, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -82,6 +172,256 @@ func (_c *Gateway_GetReceiptByTransactionHash_Call) Return(_a0 gateway.Receipt,
 	return _c
 }
 
+// GetReceiptsByTransactionHashes provides a mock function with given fields: _a0, _a1
+func (_m *Gateway) GetReceiptsByTransactionHashes(_a0 context.Context, _a1 []gateway.ReceiptLookup) ([]gateway.Receipt, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []gateway.Receipt
+	if rf, ok := ret.Get(0).(func(context.Context, []gateway.ReceiptLookup) []gateway.Receipt); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gateway.Receipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []gateway.ReceiptLookup) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Gateway_GetReceiptsByTransactionHashes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReceiptsByTransactionHashes'
+type Gateway_GetReceiptsByTransactionHashes_Call struct {
+	*mock.Call
+}
+
+// GetReceiptsByTransactionHashes is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []gateway.ReceiptLookup
+func (_e *Gateway_Expecter) GetReceiptsByTransactionHashes(_a0 interface{}, _a1 interface{}) *Gateway_GetReceiptsByTransactionHashes_Call {
+	return &Gateway_GetReceiptsByTransactionHashes_Call{Call: _e.mock.On("GetReceiptsByTransactionHashes", _a0, _a1)}
+}
+
+func (_c *Gateway_GetReceiptsByTransactionHashes_Call) Run(run func(_a0 context.Context, _a1 []gateway.ReceiptLookup)) *Gateway_GetReceiptsByTransactionHashes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]gateway.ReceiptLookup))
+	})
+	return _c
+}
+
+func (_c *Gateway_GetReceiptsByTransactionHashes_Call) Return(_a0 []gateway.Receipt, _a1 error) *Gateway_GetReceiptsByTransactionHashes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GetStateHash provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Gateway) GetStateHash(_a0 context.Context, _a1 tableland.ChainID, _a2 int64) (gateway.StateHash, bool, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 gateway.StateHash
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, int64) gateway.StateHash); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(gateway.StateHash)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, int64) bool); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, tableland.ChainID, int64) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Gateway_GetStateHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStateHash'
+type Gateway_GetStateHash_Call struct {
+	*mock.Call
+}
+
+// GetStateHash is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 tableland.ChainID
+//   - _a2 int64
+func (_e *Gateway_Expecter) GetStateHash(_a0 interface{}, _a1 interface{}, _a2 interface{}) *Gateway_GetStateHash_Call {
+	return &Gateway_GetStateHash_Call{Call: _e.mock.On("GetStateHash", _a0, _a1, _a2)}
+}
+
+func (_c *Gateway_GetStateHash_Call) Run(run func(_a0 context.Context, _a1 tableland.ChainID, _a2 int64)) *Gateway_GetStateHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *Gateway_GetStateHash_Call) Return(_a0 gateway.StateHash, _a1 bool, _a2 error) *Gateway_GetStateHash_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+// GetTableACL provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Gateway) GetTableACL(_a0 context.Context, _a1 tableland.ChainID, _a2 tables.TableID) (tableland.TableACL, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 tableland.TableACL
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, tables.TableID) tableland.TableACL); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(tableland.TableACL)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, tables.TableID) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Gateway_GetTableACL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTableACL'
+type Gateway_GetTableACL_Call struct {
+	*mock.Call
+}
+
+// GetTableACL is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 tableland.ChainID
+//   - _a2 tables.TableID
+func (_e *Gateway_Expecter) GetTableACL(_a0 interface{}, _a1 interface{}, _a2 interface{}) *Gateway_GetTableACL_Call {
+	return &Gateway_GetTableACL_Call{Call: _e.mock.On("GetTableACL", _a0, _a1, _a2)}
+}
+
+func (_c *Gateway_GetTableACL_Call) Run(run func(_a0 context.Context, _a1 tableland.ChainID, _a2 tables.TableID)) *Gateway_GetTableACL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(tables.TableID))
+	})
+	return _c
+}
+
+func (_c *Gateway_GetTableACL_Call) Return(_a0 tableland.TableACL, _a1 error) *Gateway_GetTableACL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GetTableEvents provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Gateway) GetTableEvents(_a0 context.Context, _a1 tableland.ChainID, _a2 tables.TableID, _a3 string) ([]gateway.TableEvent, string, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 []gateway.TableEvent
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, tables.TableID, string) []gateway.TableEvent); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gateway.TableEvent)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, tables.TableID, string) string); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, tableland.ChainID, tables.TableID, string) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Gateway_GetTableEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTableEvents'
+type Gateway_GetTableEvents_Call struct {
+	*mock.Call
+}
+
+// GetTableEvents is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 tableland.ChainID
+//   - _a2 tables.TableID
+//   - _a3 string
+func (_e *Gateway_Expecter) GetTableEvents(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *Gateway_GetTableEvents_Call {
+	return &Gateway_GetTableEvents_Call{Call: _e.mock.On("GetTableEvents", _a0, _a1, _a2, _a3)}
+}
+
+func (_c *Gateway_GetTableEvents_Call) Run(run func(_a0 context.Context, _a1 tableland.ChainID, _a2 tables.TableID, _a3 string)) *Gateway_GetTableEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(tables.TableID), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Gateway_GetTableEvents_Call) Return(_a0 []gateway.TableEvent, _a1 string, _a2 error) *Gateway_GetTableEvents_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+// GetTableImage provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Gateway) GetTableImage(_a0 context.Context, _a1 tableland.ChainID, _a2 tables.TableID) ([]byte, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, tables.TableID) []byte); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, tables.TableID) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Gateway_GetTableImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTableImage'
+type Gateway_GetTableImage_Call struct {
+	*mock.Call
+}
+
+// GetTableImage is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 tableland.ChainID
+//   - _a2 tables.TableID
+func (_e *Gateway_Expecter) GetTableImage(_a0 interface{}, _a1 interface{}, _a2 interface{}) *Gateway_GetTableImage_Call {
+	return &Gateway_GetTableImage_Call{Call: _e.mock.On("GetTableImage", _a0, _a1, _a2)}
+}
+
+func (_c *Gateway_GetTableImage_Call) Run(run func(_a0 context.Context, _a1 tableland.ChainID, _a2 tables.TableID)) *Gateway_GetTableImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(tables.TableID))
+	})
+	return _c
+}
+
+func (_c *Gateway_GetTableImage_Call) Return(_a0 []byte, _a1 error) *Gateway_GetTableImage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // GetTableMetadata provides a mock function with given fields: _a0, _a1, _a2
 func (_m *Gateway) GetTableMetadata(_a0 context.Context, _a1 tableland.ChainID, _a2 tables.TableID) (gateway.TableMetadata, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -128,12 +468,265 @@ func (_c *Gateway_GetTableMetadata_Call) Return(_a0 gateway.TableMetadata, _a1 e
 	return _c
 }
 
+// GetTableSchema provides a mock function with given fields: ctx, tableName
+func (_m *Gateway) GetTableSchema(ctx context.Context, tableName string) (gateway.TableSchema, error) {
+	ret := _m.Called(ctx, tableName)
+
+	var r0 gateway.TableSchema
+	if rf, ok := ret.Get(0).(func(context.Context, string) gateway.TableSchema); ok {
+		r0 = rf(ctx, tableName)
+	} else {
+		r0 = ret.Get(0).(gateway.TableSchema)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tableName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Gateway_GetTableSchema_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTableSchema'
+type Gateway_GetTableSchema_Call struct {
+	*mock.Call
+}
+
+// GetTableSchema is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tableName string
+func (_e *Gateway_Expecter) GetTableSchema(ctx interface{}, tableName interface{}) *Gateway_GetTableSchema_Call {
+	return &Gateway_GetTableSchema_Call{Call: _e.mock.On("GetTableSchema", ctx, tableName)}
+}
+
+func (_c *Gateway_GetTableSchema_Call) Run(run func(ctx context.Context, tableName string)) *Gateway_GetTableSchema_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Gateway_GetTableSchema_Call) Return(_a0 gateway.TableSchema, _a1 error) *Gateway_GetTableSchema_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// GetTableStats provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Gateway) GetTableStats(_a0 context.Context, _a1 tableland.ChainID, _a2 tables.TableID) (gateway.TableStats, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 gateway.TableStats
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, tables.TableID) gateway.TableStats); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(gateway.TableStats)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, tables.TableID) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Gateway_GetTableStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTableStats'
+type Gateway_GetTableStats_Call struct {
+	*mock.Call
+}
+
+// GetTableStats is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 tableland.ChainID
+//   - _a2 tables.TableID
+func (_e *Gateway_Expecter) GetTableStats(_a0 interface{}, _a1 interface{}, _a2 interface{}) *Gateway_GetTableStats_Call {
+	return &Gateway_GetTableStats_Call{Call: _e.mock.On("GetTableStats", _a0, _a1, _a2)}
+}
+
+func (_c *Gateway_GetTableStats_Call) Run(run func(_a0 context.Context, _a1 tableland.ChainID, _a2 tables.TableID)) *Gateway_GetTableStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(tables.TableID))
+	})
+	return _c
+}
+
+func (_c *Gateway_GetTableStats_Call) Return(_a0 gateway.TableStats, _a1 error) *Gateway_GetTableStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// ListReceipts provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Gateway) ListReceipts(_a0 context.Context, _a1 tableland.ChainID, _a2 gateway.ReceiptFilter, _a3 string) ([]gateway.Receipt, string, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 []gateway.Receipt
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, gateway.ReceiptFilter, string) []gateway.Receipt); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gateway.Receipt)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, gateway.ReceiptFilter, string) string); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, tableland.ChainID, gateway.ReceiptFilter, string) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Gateway_ListReceipts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReceipts'
+type Gateway_ListReceipts_Call struct {
+	*mock.Call
+}
+
+// ListReceipts is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 tableland.ChainID
+//   - _a2 gateway.ReceiptFilter
+//   - _a3 string
+func (_e *Gateway_Expecter) ListReceipts(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *Gateway_ListReceipts_Call {
+	return &Gateway_ListReceipts_Call{Call: _e.mock.On("ListReceipts", _a0, _a1, _a2, _a3)}
+}
+
+func (_c *Gateway_ListReceipts_Call) Run(run func(_a0 context.Context, _a1 tableland.ChainID, _a2 gateway.ReceiptFilter, _a3 string)) *Gateway_ListReceipts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(gateway.ReceiptFilter), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Gateway_ListReceipts_Call) Return(_a0 []gateway.Receipt, _a1 string, _a2 error) *Gateway_ListReceipts_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+// ListReceiptsAfter provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
+func (_m *Gateway) ListReceiptsAfter(_a0 context.Context, _a1 tableland.ChainID, _a2 int64, _a3 int64, _a4 int) ([]gateway.Receipt, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
+
+	var r0 []gateway.Receipt
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, int64, int64, int) []gateway.Receipt); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gateway.Receipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, int64, int64, int) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Gateway_ListReceiptsAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReceiptsAfter'
+type Gateway_ListReceiptsAfter_Call struct {
+	*mock.Call
+}
+
+// ListReceiptsAfter is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 tableland.ChainID
+//   - _a2 int64
+//   - _a3 int64
+//   - _a4 int
+func (_e *Gateway_Expecter) ListReceiptsAfter(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}, _a4 interface{}) *Gateway_ListReceiptsAfter_Call {
+	return &Gateway_ListReceiptsAfter_Call{Call: _e.mock.On("ListReceiptsAfter", _a0, _a1, _a2, _a3, _a4)}
+}
+
+func (_c *Gateway_ListReceiptsAfter_Call) Run(run func(_a0 context.Context, _a1 tableland.ChainID, _a2 int64, _a3 int64, _a4 int)) *Gateway_ListReceiptsAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(int64), args[3].(int64), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *Gateway_ListReceiptsAfter_Call) Return(_a0 []gateway.Receipt, _a1 error) *Gateway_ListReceiptsAfter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// ListTables provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Gateway) ListTables(_a0 context.Context, _a1 tableland.ChainID, _a2 common.Address, _a3 string) ([]gateway.Table, string, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 []gateway.Table
+	if rf, ok := ret.Get(0).(func(context.Context, tableland.ChainID, common.Address, string) []gateway.Table); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gateway.Table)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, tableland.ChainID, common.Address, string) string); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, tableland.ChainID, common.Address, string) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Gateway_ListTables_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTables'
+type Gateway_ListTables_Call struct {
+	*mock.Call
+}
+
+// ListTables is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 tableland.ChainID
+//   - _a2 common.Address
+//   - _a3 string
+func (_e *Gateway_Expecter) ListTables(_a0 interface{}, _a1 interface{}, _a2 interface{}, _a3 interface{}) *Gateway_ListTables_Call {
+	return &Gateway_ListTables_Call{Call: _e.mock.On("ListTables", _a0, _a1, _a2, _a3)}
+}
+
+func (_c *Gateway_ListTables_Call) Run(run func(_a0 context.Context, _a1 tableland.ChainID, _a2 common.Address, _a3 string)) *Gateway_ListTables_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tableland.ChainID), args[2].(common.Address), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *Gateway_ListTables_Call) Return(_a0 []gateway.Table, _a1 string, _a2 error) *Gateway_ListTables_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
 // RunReadQuery provides a mock function with given fields: ctx, stmt, params
-func (_m *Gateway) RunReadQuery(ctx context.Context, stmt string, params []string) (*gateway.TableData, error) {
+func (_m *Gateway) RunReadQuery(ctx context.Context, stmt string, params []any) (*gateway.TableData, error) {
 	ret := _m.Called(ctx, stmt, params)
 
 	var r0 *gateway.TableData
-	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *gateway.TableData); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, []any) *gateway.TableData); ok {
 		r0 = rf(ctx, stmt, params)
 	} else {
 		if ret.Get(0) != nil {
@@ -142,7 +735,7 @@ func (_m *Gateway) RunReadQuery(ctx context.Context, stmt string, params []strin
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string, []any) error); ok {
 		r1 = rf(ctx, stmt, params)
 	} else {
 		r1 = ret.Error(1)
@@ -159,14 +752,14 @@ type Gateway_RunReadQuery_Call struct {
 // RunReadQuery is a helper method to define mock.On call
 //   - ctx context.Context
 //   - stmt string
-//   - params []string
+//   - params []any
 func (_e *Gateway_Expecter) RunReadQuery(ctx interface{}, stmt interface{}, params interface{}) *Gateway_RunReadQuery_Call {
 	return &Gateway_RunReadQuery_Call{Call: _e.mock.On("RunReadQuery", ctx, stmt, params)}
 }
 
-func (_c *Gateway_RunReadQuery_Call) Run(run func(ctx context.Context, stmt string, params []string)) *Gateway_RunReadQuery_Call {
+func (_c *Gateway_RunReadQuery_Call) Run(run func(ctx context.Context, stmt string, params []any)) *Gateway_RunReadQuery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+		run(args[0].(context.Context), args[1].(string), args[2].([]any))
 	})
 	return _c
 }
@@ -176,6 +769,183 @@ func (_c *Gateway_RunReadQuery_Call) Return(_a0 *gateway.TableData, _a1 error) *
 	return _c
 }
 
+// RunReadQueryBatch provides a mock function with given fields: ctx, queries, w
+func (_m *Gateway) RunReadQueryBatch(ctx context.Context, queries []gateway.ReadQuery, w gateway.BatchWriter) error {
+	ret := _m.Called(ctx, queries, w)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []gateway.ReadQuery, gateway.BatchWriter) error); ok {
+		r0 = rf(ctx, queries, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Gateway_RunReadQueryBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunReadQueryBatch'
+type Gateway_RunReadQueryBatch_Call struct {
+	*mock.Call
+}
+
+// RunReadQueryBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - queries []gateway.ReadQuery
+//   - w gateway.BatchWriter
+func (_e *Gateway_Expecter) RunReadQueryBatch(ctx interface{}, queries interface{}, w interface{}) *Gateway_RunReadQueryBatch_Call {
+	return &Gateway_RunReadQueryBatch_Call{Call: _e.mock.On("RunReadQueryBatch", ctx, queries, w)}
+}
+
+func (_c *Gateway_RunReadQueryBatch_Call) Run(run func(ctx context.Context, queries []gateway.ReadQuery, w gateway.BatchWriter)) *Gateway_RunReadQueryBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]gateway.ReadQuery), args[2].(gateway.BatchWriter))
+	})
+	return _c
+}
+
+func (_c *Gateway_RunReadQueryBatch_Call) Return(_a0 error) *Gateway_RunReadQueryBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// RunReadQueryPage provides a mock function with given fields: ctx, stmt, params, cursor, pageSize
+func (_m *Gateway) RunReadQueryPage(ctx context.Context, stmt string, params []any, cursor string, pageSize int) (*gateway.TableData, string, error) {
+	ret := _m.Called(ctx, stmt, params, cursor, pageSize)
+
+	var r0 *gateway.TableData
+	if rf, ok := ret.Get(0).(func(context.Context, string, []any, string, int) *gateway.TableData); ok {
+		r0 = rf(ctx, stmt, params, cursor, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gateway.TableData)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string, []any, string, int) string); ok {
+		r1 = rf(ctx, stmt, params, cursor, pageSize)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, []any, string, int) error); ok {
+		r2 = rf(ctx, stmt, params, cursor, pageSize)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Gateway_RunReadQueryPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunReadQueryPage'
+type Gateway_RunReadQueryPage_Call struct {
+	*mock.Call
+}
+
+// RunReadQueryPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stmt string
+//   - params []any
+//   - cursor string
+//   - pageSize int
+func (_e *Gateway_Expecter) RunReadQueryPage(ctx interface{}, stmt interface{}, params interface{}, cursor interface{}, pageSize interface{}) *Gateway_RunReadQueryPage_Call {
+	return &Gateway_RunReadQueryPage_Call{Call: _e.mock.On("RunReadQueryPage", ctx, stmt, params, cursor, pageSize)}
+}
+
+func (_c *Gateway_RunReadQueryPage_Call) Run(run func(ctx context.Context, stmt string, params []any, cursor string, pageSize int)) *Gateway_RunReadQueryPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]any), args[3].(string), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *Gateway_RunReadQueryPage_Call) Return(_a0 *gateway.TableData, _a1 string, _a2 error) *Gateway_RunReadQueryPage_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+// StreamReadQuery provides a mock function with given fields: ctx, stmt, params, w
+func (_m *Gateway) StreamReadQuery(ctx context.Context, stmt string, params []any, w gateway.RowWriter) error {
+	ret := _m.Called(ctx, stmt, params, w)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []any, gateway.RowWriter) error); ok {
+		r0 = rf(ctx, stmt, params, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Gateway_StreamReadQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamReadQuery'
+type Gateway_StreamReadQuery_Call struct {
+	*mock.Call
+}
+
+// StreamReadQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stmt string
+//   - params []any
+//   - w gateway.RowWriter
+func (_e *Gateway_Expecter) StreamReadQuery(ctx interface{}, stmt interface{}, params interface{}, w interface{}) *Gateway_StreamReadQuery_Call {
+	return &Gateway_StreamReadQuery_Call{Call: _e.mock.On("StreamReadQuery", ctx, stmt, params, w)}
+}
+
+func (_c *Gateway_StreamReadQuery_Call) Run(run func(ctx context.Context, stmt string, params []any, w gateway.RowWriter)) *Gateway_StreamReadQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]any), args[3].(gateway.RowWriter))
+	})
+	return _c
+}
+
+func (_c *Gateway_StreamReadQuery_Call) Return(_a0 error) *Gateway_StreamReadQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+// StreamReadQueryAt provides a mock function with given fields: ctx, stmt, params, block, w
+func (_m *Gateway) StreamReadQueryAt(ctx context.Context, stmt string, params []any, block int64, w gateway.RowWriter) error {
+	ret := _m.Called(ctx, stmt, params, block, w)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []any, int64, gateway.RowWriter) error); ok {
+		r0 = rf(ctx, stmt, params, block, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Gateway_StreamReadQueryAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamReadQueryAt'
+type Gateway_StreamReadQueryAt_Call struct {
+	*mock.Call
+}
+
+// StreamReadQueryAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stmt string
+//   - params []any
+//   - block int64
+//   - w gateway.RowWriter
+func (_e *Gateway_Expecter) StreamReadQueryAt(ctx interface{}, stmt interface{}, params interface{}, block interface{}, w interface{}) *Gateway_StreamReadQueryAt_Call {
+	return &Gateway_StreamReadQueryAt_Call{Call: _e.mock.On("StreamReadQueryAt", ctx, stmt, params, block, w)}
+}
+
+func (_c *Gateway_StreamReadQueryAt_Call) Run(run func(ctx context.Context, stmt string, params []any, block int64, w gateway.RowWriter)) *Gateway_StreamReadQueryAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]any), args[3].(int64), args[4].(gateway.RowWriter))
+	})
+	return _c
+}
+
+func (_c *Gateway_StreamReadQueryAt_Call) Return(_a0 error) *Gateway_StreamReadQueryAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
 type mockConstructorTestingTNewGateway interface {
 	mock.TestingT
 	Cleanup(func())
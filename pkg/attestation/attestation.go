@@ -0,0 +1,224 @@
+package attestation
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/wallet"
+)
+
+// Headers of a signed read query response.
+const (
+	// HeaderSignature is the EIP-712 signature of the attestation, hex encoded.
+	HeaderSignature = "X-Tableland-Signature"
+	// HeaderSigner is the address of the signed reads wallet of the validator that signed the response.
+	HeaderSigner = "X-Tableland-Signer"
+	// HeaderResultHash is the keccak256 hash of the response body, hex encoded.
+	HeaderResultHash = "X-Tableland-Result-Hash"
+	// HeaderBlockHeights are the block heights of the chains of the tables read, e.g. 1:1500,10:3600.
+	HeaderBlockHeights = "X-Tableland-Block-Heights"
+	// HeaderTimestamp is the unix time in seconds at which the response was signed.
+	HeaderTimestamp = "X-Tableland-Timestamp"
+)
+
+// ErrInvalidSignature indicates that the signature of a response doesn't match its content.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Attestation is what a validator signs about the response of a read query. It proves that the validator
+// returned the response with ResultHash for the statement and params, when the chains of the read tables
+// were at the block heights.
+type Attestation struct {
+	Statement string
+	// Params are the values bound to the positional parameters of the statement.
+	Params     []any
+	ResultHash common.Hash
+	Heights    map[tableland.ChainID]int64
+	// Timestamp is the unix time in seconds at which the response was signed.
+	Timestamp int64
+}
+
+// ResultHash returns the hash of the body of a read query response.
+func ResultHash(body []byte) common.Hash {
+	return crypto.Keccak256Hash(body)
+}
+
+// ParamsHash returns the hash of the params of a read query. It's the keccak256 hash of the params encoded
+// as a JSON array, with blobs as {"blob": "<base64>"} objects, like in the body of a POST query.
+func ParamsHash(params []any) (common.Hash, error) {
+	values := make([]any, len(params))
+	for i, p := range params {
+		switch v := p.(type) {
+		case []byte:
+			values[i] = map[string]string{"blob": base64.StdEncoding.EncodeToString(v)}
+		case nil, bool, string, json.Number, int, int32, int64, uint64, float64:
+			values[i] = v
+		default:
+			return common.Hash{}, fmt.Errorf("unsupported param type %T", p)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(values); err != nil {
+		return common.Hash{}, fmt.Errorf("encoding params: %s", err)
+	}
+	return crypto.Keccak256Hash(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+// TypedData returns the EIP-712 typed data signed for an attestation. The domain has no chain, since reads
+// can span several chains. The heights are sorted by chain id.
+func TypedData(a Attestation) (apitypes.TypedData, error) {
+	paramsHash, err := ParamsHash(a.Params)
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+
+	chainIDs := sortedChainIDs(a.Heights)
+	chains := make([]interface{}, len(chainIDs))
+	heights := make([]interface{}, len(chainIDs))
+	for i, chainID := range chainIDs {
+		chains[i] = big.NewInt(int64(chainID))
+		heights[i] = big.NewInt(a.Heights[chainID])
+	}
+
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+			},
+			"ReadAttestation": []apitypes.Type{
+				{Name: "statement", Type: "string"},
+				{Name: "paramsHash", Type: "bytes32"},
+				{Name: "resultHash", Type: "bytes32"},
+				{Name: "chainIds", Type: "uint256[]"},
+				{Name: "blockHeights", Type: "uint256[]"},
+				{Name: "timestamp", Type: "uint256"},
+			},
+		},
+		PrimaryType: "ReadAttestation",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "Tableland Read Attestation",
+			Version: "1",
+		},
+		Message: apitypes.TypedDataMessage{
+			"statement":    a.Statement,
+			"paramsHash":   paramsHash.Bytes(),
+			"resultHash":   a.ResultHash.Bytes(),
+			"chainIds":     chains,
+			"blockHeights": heights,
+			"timestamp":    big.NewInt(a.Timestamp),
+		},
+	}, nil
+}
+
+// Sign signs the typed data of an attestation with a wallet.
+func Sign(wallet *wallet.Wallet, a Attestation) ([]byte, error) {
+	typedData, err := TypedData(a)
+	if err != nil {
+		return nil, err
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("hashing typed data: %s", err)
+	}
+	signature, err := crypto.Sign(hash, wallet.PrivateKey())
+	if err != nil {
+		return nil, fmt.Errorf("signing typed data: %s", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return signature, nil
+}
+
+// RecoverSigner returns the address that signed an attestation. Both 0/1 and 27/28 recovery ids are accepted.
+func RecoverSigner(a Attestation, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, ErrInvalidSignature
+	}
+	typedData, err := TypedData(a)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: hashing typed data: %s", ErrInvalidSignature, err)
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// SetHeaders sets the headers of a response signed by signer.
+func SetHeaders(header http.Header, a Attestation, signer common.Address, signature []byte) {
+	header.Set(HeaderSignature, hexutil.Encode(signature))
+	header.Set(HeaderSigner, signer.Hex())
+	header.Set(HeaderResultHash, a.ResultHash.Hex())
+	header.Set(HeaderBlockHeights, FormatHeights(a.Heights))
+	header.Set(HeaderTimestamp, strconv.FormatInt(a.Timestamp, 10))
+}
+
+// FormatHeights formats block heights as comma separated chainID:height pairs, sorted by chain id.
+func FormatHeights(heights map[tableland.ChainID]int64) string {
+	chainIDs := sortedChainIDs(heights)
+	pairs := make([]string, len(chainIDs))
+	for i, chainID := range chainIDs {
+		pairs[i] = fmt.Sprintf("%d:%d", chainID, heights[chainID])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// ParseHeights parses block heights formatted by FormatHeights.
+func ParseHeights(s string) (map[tableland.ChainID]int64, error) {
+	heights := map[tableland.ChainID]int64{}
+	if s == "" {
+		return heights, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid block height %q", pair)
+		}
+		chainID, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain id %q", parts[0])
+		}
+		height, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block height %q", parts[1])
+		}
+		heights[tableland.ChainID(chainID)] = height
+	}
+	return heights, nil
+}
+
+func sortedChainIDs(heights map[tableland.ChainID]int64) []tableland.ChainID {
+	chainIDs := make([]tableland.ChainID, 0, len(heights))
+	for chainID := range heights {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+	return chainIDs
+}
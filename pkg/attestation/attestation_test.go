@@ -0,0 +1,73 @@
+package attestation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/wallet"
+)
+
+func TestSignAndRecover(t *testing.T) {
+	t.Parallel()
+
+	w, err := wallet.NewWallet("5b2dc4ea1bfc1ad1f7a3ed8ab42dc9e1a3ed3a5ee7fb1ae8ecc1ee2f0c57e3b8")
+	require.NoError(t, err)
+
+	a := Attestation{
+		Statement:  "select * from foo_1_1 where a = ?",
+		Params:     []any{int64(1), "b", nil, []byte{1, 2}},
+		ResultHash: ResultHash([]byte(`[{"a":1}]`)),
+		Heights:    map[tableland.ChainID]int64{10: 3600, 1: 1500},
+		Timestamp:  1700000000,
+	}
+	signature, err := Sign(w, a)
+	require.NoError(t, err)
+
+	signer, err := RecoverSigner(a, signature)
+	require.NoError(t, err)
+	require.Equal(t, w.Address(), signer)
+
+	tampered := a
+	tampered.Heights = map[tableland.ChainID]int64{10: 3601, 1: 1500}
+	signer, err = RecoverSigner(tampered, signature)
+	require.NoError(t, err)
+	require.NotEqual(t, w.Address(), signer)
+
+	_, err = RecoverSigner(a, signature[1:])
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestParamsHash(t *testing.T) {
+	t.Parallel()
+
+	// Params bound from a GET and a POST query have the same hash.
+	get, err := ParamsHash([]any{int64(1), "<it's>", nil, true})
+	require.NoError(t, err)
+	post, err := ParamsHash([]any{json.Number("1"), "<it's>", nil, true})
+	require.NoError(t, err)
+	require.Equal(t, get, post)
+
+	empty, err := ParamsHash(nil)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash([]byte("[]")), empty)
+
+	_, err = ParamsHash([]any{struct{}{}})
+	require.Error(t, err)
+}
+
+func TestHeights(t *testing.T) {
+	t.Parallel()
+
+	heights := map[tableland.ChainID]int64{10: 3600, 1: 1500}
+	require.Equal(t, "1:1500,10:3600", FormatHeights(heights))
+
+	parsed, err := ParseHeights("1:1500,10:3600")
+	require.NoError(t, err)
+	require.Equal(t, heights, parsed)
+
+	_, err = ParseHeights("1-1500")
+	require.Error(t, err)
+}
@@ -25,8 +25,9 @@ type Scheduler struct {
 	tickerFrequency time.Duration
 
 	// control
-	close     chan struct{}
-	closeOnce sync.Once
+	close      chan struct{}
+	closeOnce  sync.Once
+	backupLock sync.Mutex
 
 	// metrics
 	mLastExecution time.Time
@@ -96,7 +97,15 @@ func (s *Scheduler) Shutdown() {
 	})
 }
 
+// BackupNow executes a backup right away. It waits if a scheduled backup is running.
+func (s *Scheduler) BackupNow() error {
+	return s.backup()
+}
+
 func (s *Scheduler) backup() error {
+	s.backupLock.Lock()
+	defer s.backupLock.Unlock()
+
 	result, err := s.backuper.Backup(context.Background())
 	if err != nil {
 		log.Error().Err(err).Msg("backup failed")
@@ -37,3 +37,22 @@ func TestScheduler(t *testing.T) {
 		require.NoError(t, controlDB.Close())
 	})
 }
+
+func TestSchedulerBackupNow(t *testing.T) {
+	t.Parallel()
+	backupDir := backupDir(t)
+	controlDB := createControlDatabase(t)
+
+	scheduler, err := NewScheduler(60, BackuperOptions{
+		SourcePath: controlDB.Path(),
+		BackupDir:  backupDir,
+	}, false)
+	require.NoError(t, err)
+
+	require.NoError(t, scheduler.BackupNow())
+	requireFileCount(t, backupDir, 1)
+
+	t.Cleanup(func() {
+		require.NoError(t, controlDB.Close())
+	})
+}
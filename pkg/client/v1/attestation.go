@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/textileio/go-tableland/pkg/attestation"
+)
+
+// ErrResponseNotSigned indicates that the validator didn't sign the response of a read query.
+var ErrResponseNotSigned = errors.New("response isn't signed")
+
+// ReadAttestation is the signature of the response of a read query by a validator.
+type ReadAttestation struct {
+	attestation.Attestation
+
+	// Signer is the address of the validator wallet that signed the response. Callers must check that it's
+	// the address of a validator they trust.
+	Signer    common.Address
+	Signature []byte
+}
+
+// VerifyReadAttestation verifies the signature sent in the headers of the response of a read query, using the
+// statement and params of the query and the response body. Params are the values bound to the statement, e.g.
+// int64 for an integer param.
+func VerifyReadAttestation(
+	header http.Header, statement string, params []any, body []byte,
+) (*ReadAttestation, error) {
+	if header.Get(attestation.HeaderSignature) == "" {
+		return nil, ErrResponseNotSigned
+	}
+	signature, err := hexutil.Decode(header.Get(attestation.HeaderSignature))
+	if err != nil {
+		return nil, fmt.Errorf("%w: decoding signature: %s", attestation.ErrInvalidSignature, err)
+	}
+	heights, err := attestation.ParseHeights(header.Get(attestation.HeaderBlockHeights))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", attestation.ErrInvalidSignature, err)
+	}
+	timestamp, err := strconv.ParseInt(header.Get(attestation.HeaderTimestamp), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid timestamp", attestation.ErrInvalidSignature)
+	}
+
+	a := attestation.Attestation{
+		Statement:  statement,
+		Params:     params,
+		ResultHash: attestation.ResultHash(body),
+		Heights:    heights,
+		Timestamp:  timestamp,
+	}
+	if v := header.Get(attestation.HeaderResultHash); v != "" && common.HexToHash(v) != a.ResultHash {
+		return nil, fmt.Errorf("%w: the body doesn't match the result hash", attestation.ErrInvalidSignature)
+	}
+	signer, err := attestation.RecoverSigner(a, signature)
+	if err != nil {
+		return nil, err
+	}
+	if v := header.Get(attestation.HeaderSigner); v != "" && common.HexToAddress(v) != signer {
+		return nil, fmt.Errorf("%w: the signature isn't from %s", attestation.ErrInvalidSignature, v)
+	}
+
+	return &ReadAttestation{Attestation: a, Signer: signer, Signature: signature}, nil
+}
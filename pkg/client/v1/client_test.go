@@ -1,14 +1,18 @@
 package v1
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"github.com/textileio/go-tableland/internal/router/controllers/apiv1"
 	"github.com/textileio/go-tableland/pkg/client"
+	"github.com/textileio/go-tableland/pkg/wallet"
 	"github.com/textileio/go-tableland/tests/fullstack"
 )
 
@@ -24,6 +28,24 @@ func TestWrite(t *testing.T) {
 	requireReceipt(t, calls, requireAlter(t, calls, tableName), WaitFor(time.Second*10))
 }
 
+func TestRelayWrite(t *testing.T) {
+	calls := setup(t)
+	tableName := requireCreate(t, calls)
+	requireReceipt(t, calls, calls.relayWrite(fmt.Sprintf("insert into %s (bar) values('baz')", tableName)),
+		WaitFor(time.Second*10))
+
+	var res []map[string]interface{}
+	calls.query(fmt.Sprintf("select * from %s", tableName), []string{}, &res)
+	require.Len(t, res, 1)
+
+	// Grants can't be relayed.
+	_, err := calls.client.RelayWrite(
+		context.Background(),
+		fmt.Sprintf("grant insert on %s to '0xd43c59d5694ec111eb9e986c233200b14249558d'", tableName),
+	)
+	require.ErrorContains(t, err, "status: 403")
+}
+
 func TestRead(t *testing.T) {
 	t.Run("status 200", func(t *testing.T) {
 		calls := setup(t)
@@ -56,6 +78,14 @@ func TestRead(t *testing.T) {
 		res4 := ""
 		calls.query(fmt.Sprintf("select * from %s", tableName), []string{}, &res4, ReadUnwrap(), ReadExtract())
 		require.Equal(t, "baz", res4)
+
+		res5 := []byte{}
+		calls.query(fmt.Sprintf("select * from %s", tableName), []string{}, &res5, ReadFormat(CSV))
+		require.Equal(t, "bar\nbaz\n", string(res5))
+
+		res6 := &bytes.Buffer{}
+		calls.query(fmt.Sprintf("select * from %s", tableName), []string{}, res6, ReadFormat(NDJSON))
+		require.Equal(t, "{\"bar\":\"baz\"}\n", res6.String())
 	})
 
 	t.Run("status 400", func(t *testing.T) {
@@ -65,6 +95,111 @@ func TestRead(t *testing.T) {
 	})
 }
 
+func TestReadVerified(t *testing.T) {
+	signer, err := wallet.NewWallet("5b2dc4ea1bfc1ad1f7a3ed8ab42dc9e1a3ed3a5ee7fb1ae8ecc1ee2f0c57e3b8")
+	require.NoError(t, err)
+	calls := setupWithDeps(t, fullstack.Deps{ReadSigner: signer})
+	tableName := requireCreate(t, calls)
+	requireReceipt(t, calls, requireInsert(t, calls, tableName), WaitFor(time.Second*10))
+
+	var res []map[string]interface{}
+	var att ReadAttestation
+	query := fmt.Sprintf("select * from %s where bar = ?", tableName)
+	calls.query(query, []string{"'baz'"}, &res, ReadVerified(&att))
+	require.Len(t, res, 1)
+	require.Equal(t, signer.Address(), att.Signer)
+	require.Equal(t, query, att.Statement)
+	require.Equal(t, []any{"baz"}, att.Params)
+	require.Greater(t, att.Heights[fullstack.ChainID], int64(0))
+	require.InDelta(t, time.Now().Unix(), att.Timestamp, 60)
+
+	// Responses aren't signed by default.
+	calls = setup(t)
+	tableName = requireCreate(t, calls)
+	err = calls.client.Read(
+		context.Background(), fmt.Sprintf("select * from %s", tableName), []string{}, &res, ReadVerified(&att),
+	)
+	require.ErrorIs(t, err, ErrResponseNotSigned)
+}
+
+func TestReadAll(t *testing.T) {
+	calls := setup(t)
+	tableName := requireCreate(t, calls)
+	hash := calls.write(fmt.Sprintf("insert into %s (bar) values ('a'), ('b'), ('c')", tableName))
+	requireReceipt(t, calls, hash, WaitFor(time.Second*10))
+
+	type result struct {
+		Bar string `json:"bar"`
+	}
+	query := fmt.Sprintf("select * from %s order by bar", tableName)
+
+	t.Run("iterator", func(t *testing.T) {
+		it := calls.client.ReadIterator(query, []string{}, ReadPageSize(2))
+		var pages [][]result
+		for {
+			page := []result{}
+			ok, err := it.Next(context.Background(), &page)
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			pages = append(pages, page)
+		}
+		require.Equal(t, [][]result{{{Bar: "a"}, {Bar: "b"}}, {{Bar: "c"}}}, pages)
+	})
+
+	t.Run("objects", func(t *testing.T) {
+		res := []result{}
+		err := calls.client.ReadAll(context.Background(), query, []string{}, &res, ReadPageSize(2))
+		require.NoError(t, err)
+		require.Equal(t, []result{{Bar: "a"}, {Bar: "b"}, {Bar: "c"}}, res)
+	})
+
+	t.Run("table", func(t *testing.T) {
+		res := struct {
+			Columns []map[string]string `json:"columns"`
+			Rows    [][]string          `json:"rows"`
+		}{}
+		err := calls.client.ReadAll(context.Background(), query, []string{}, &res, ReadPageSize(1), ReadFormat(Table))
+		require.NoError(t, err)
+		require.Len(t, res.Columns, 1)
+		require.Equal(t, [][]string{{"a"}, {"b"}, {"c"}}, res.Rows)
+	})
+}
+
+func TestReadBatch(t *testing.T) {
+	calls := setup(t)
+	tableName := requireCreate(t, calls)
+	hash := calls.write(fmt.Sprintf("insert into %s (bar) values ('a'), ('b'), ('c')", tableName))
+	requireReceipt(t, calls, hash, WaitFor(time.Second*10))
+
+	results, err := calls.client.ReadBatch(context.Background(), []BatchQuery{
+		{Statement: fmt.Sprintf("select count(*) as total from %s", tableName)},
+		{Statement: fmt.Sprintf("select bar from %s where bar > ? order by bar", tableName), Params: []interface{}{"a"}},
+		{Statement: "select * from foo_1337_100"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	var count []struct {
+		Total int `json:"total"`
+	}
+	require.NoError(t, results[0].Decode(&count))
+	require.Equal(t, 3, count[0].Total)
+
+	var rows []struct {
+		Bar string `json:"bar"`
+	}
+	require.NoError(t, results[1].Decode(&rows))
+	require.Len(t, rows, 2)
+	require.Equal(t, "b", rows[0].Bar)
+
+	require.Error(t, results[2].Decode(&rows))
+
+	_, err = calls.client.ReadBatch(context.Background(), []BatchQuery{}, ReadFormat(CSV))
+	require.Error(t, err)
+}
+
 func TestGetReceipt(t *testing.T) {
 	t.Run("status 200", func(t *testing.T) {
 		calls := setup(t)
@@ -89,6 +224,68 @@ func TestGetReceipt(t *testing.T) {
 	})
 }
 
+func TestReceiptsByHashes(t *testing.T) {
+	calls := setup(t)
+	tableName := requireCreate(t, calls)
+	hash1 := requireInsert(t, calls, tableName)
+	hash2 := requireInsert(t, calls, tableName)
+	missing := "0x5c6f90e52284726a7276d6a20a3df94a4532a8fa4c921233a301e95673ad0255"
+
+	receipts, err := calls.client.ReceiptsByHashes(context.Background(), []string{hash1, hash2}, WaitFor(time.Second*10))
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+	require.Equal(t, hash1, receipts[hash1].TransactionHash)
+	require.Equal(t, hash2, receipts[hash2].TransactionHash)
+
+	receipts, err = calls.client.ReceiptsByHashes(context.Background(), []string{hash1, missing}, WaitFor(time.Second))
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+	require.NotNil(t, receipts[hash1])
+	require.Nil(t, receipts[missing])
+
+	_, err = calls.client.ReceiptsByHashes(context.Background(), []string{"0xINVALIDHASH"})
+	require.Error(t, err)
+}
+
+func TestReceipts(t *testing.T) {
+	calls := setup(t)
+	id1, name1 := calls.create("(bar text)", WithPrefix("foo"), WithReceiptTimeout(time.Second*10))
+	id2, _ := calls.create("(baz int)", WithPrefix("bar"), WithReceiptTimeout(time.Second*10))
+	hash := calls.write(fmt.Sprintf("insert into %s (bar) values ('a')", name1))
+	receipt := requireReceipt(t, calls, hash, WaitFor(time.Second*10))
+
+	readAll := func(opts ...ReceiptsOption) []apiv1.TransactionReceipt {
+		var receipts []apiv1.TransactionReceipt
+		it := calls.client.Receipts(opts...)
+		for {
+			page, ok, err := it.Next(context.Background())
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			receipts = append(receipts, page...)
+		}
+		return receipts
+	}
+
+	receipts := readAll()
+	require.Len(t, receipts, 3)
+	require.Equal(t, []string{id1.String()}, receipts[0].TableIds)
+	require.Equal(t, []string{id2.String()}, receipts[1].TableIds)
+	require.Equal(t, hash, receipts[2].TransactionHash)
+
+	receipts = readAll(ReceiptsForTable(id1))
+	require.Len(t, receipts, 2)
+	require.Equal(t, hash, receipts[1].TransactionHash)
+
+	receipts = readAll(ReceiptsFromBlock(receipt.BlockNumber), ReceiptsToBlock(receipt.BlockNumber))
+	require.Len(t, receipts, 1)
+	require.Equal(t, hash, receipts[0].TransactionHash)
+
+	_, _, err := calls.client.Receipts(ReceiptsFromBlock(2), ReceiptsToBlock(1)).Next(context.Background())
+	require.Error(t, err)
+}
+
 func TestGetTableByID(t *testing.T) {
 	t.Run("status 200", func(t *testing.T) {
 		calls := setup(t)
@@ -126,6 +323,126 @@ func TestGetTableByID(t *testing.T) {
 	})
 }
 
+func TestGetTableEvents(t *testing.T) {
+	calls := setup(t)
+	id, tableName := calls.create("(bar text)", WithPrefix("foo"), WithReceiptTimeout(time.Second*10))
+	hash1 := calls.write(fmt.Sprintf("insert into %s (bar) values ('a')", tableName))
+	requireReceipt(t, calls, hash1, WaitFor(time.Second*10))
+	hash2 := calls.write(fmt.Sprintf("insert into %s (unknown) values ('b')", tableName))
+	requireReceipt(t, calls, hash2, WaitFor(time.Second*10))
+
+	page, err := calls.client.GetTableEvents(context.Background(), id, "")
+	require.NoError(t, err)
+	require.Empty(t, page.NextCursor)
+	require.Len(t, page.Events, 3)
+
+	require.Equal(t, "ContractCreateTable", page.Events[0].EventType)
+	require.Equal(t, "success", page.Events[0].Status)
+
+	require.Equal(t, "ContractRunSQL", page.Events[1].EventType)
+	require.Equal(t, hash1, page.Events[1].TransactionHash)
+	require.Equal(t, "success", page.Events[1].Status)
+	var event struct{ Statement string }
+	require.NoError(t, json.Unmarshal(page.Events[1].Event, &event))
+	require.Equal(t, fmt.Sprintf("insert into %s (bar) values ('a')", tableName), event.Statement)
+
+	require.Equal(t, hash2, page.Events[2].TransactionHash)
+	require.Equal(t, "failed", page.Events[2].Status)
+	require.NotEmpty(t, page.Events[2].Error_)
+
+	_, err = calls.client.GetTableEvents(context.Background(), id, "invalid")
+	require.Error(t, err)
+}
+
+func TestListTables(t *testing.T) {
+	calls := setup(t)
+	id1, name1 := calls.create("(bar text)", WithPrefix("foo"), WithReceiptTimeout(time.Second*10))
+	id2, name2 := calls.create("(baz int)", WithPrefix("bar"), WithReceiptTimeout(time.Second*10))
+
+	page, err := calls.client.ListTables(context.Background(), calls.client.wallet.Address(), "")
+	require.NoError(t, err)
+	require.Empty(t, page.NextCursor)
+	require.Len(t, page.Tables, 2)
+	require.Equal(t, id1.String(), page.Tables[0].Id)
+	require.Equal(t, name1, page.Tables[0].Name)
+	require.NotEmpty(t, page.Tables[0].Structure)
+	require.Equal(t, id2.String(), page.Tables[1].Id)
+	require.Equal(t, name2, page.Tables[1].Name)
+
+	page, err = calls.client.ListTables(context.Background(), common.HexToAddress("0x1"), "")
+	require.NoError(t, err)
+	require.Empty(t, page.Tables)
+
+	_, err = calls.client.ListTables(context.Background(), calls.client.wallet.Address(), "invalid")
+	require.Error(t, err)
+}
+
+func TestGetTableACL(t *testing.T) {
+	calls := setup(t)
+	id, tableName := calls.create("(bar text)", WithPrefix("foo"), WithReceiptTimeout(time.Second*10))
+
+	grantee := common.HexToAddress("0x07dfFc57AA386D2b239CaBE8993358DF20BAFBE2")
+	hash := calls.write(fmt.Sprintf("grant insert, update on %s to '%s'", tableName, grantee.Hex()))
+	requireReceipt(t, calls, hash, WaitFor(time.Second*10))
+
+	acl, err := calls.client.GetTableACL(context.Background(), id)
+	require.NoError(t, err)
+	require.Empty(t, acl.Controller)
+	require.Len(t, acl.Rules, 2)
+	privileges := map[string][]string{}
+	for _, rule := range acl.Rules {
+		require.NotZero(t, rule.CreatedAt)
+		privileges[rule.Controller] = rule.Privileges
+	}
+	require.Equal(t, map[string][]string{
+		calls.client.wallet.Address().Hex(): {"insert", "update", "delete"},
+		grantee.Hex():                       {"insert", "update"},
+	}, privileges)
+
+	id, err = NewTableID("1337")
+	require.NoError(t, err)
+	_, err = calls.client.GetTableACL(context.Background(), id)
+	require.ErrorIs(t, err, ErrTableNotFound)
+}
+
+func TestGetTableStats(t *testing.T) {
+	calls := setup(t)
+	id, tableName := calls.create("(id int primary key, bar text)", WithPrefix("foo"), WithReceiptTimeout(time.Second*10))
+
+	hash := calls.write(fmt.Sprintf("insert into %s (id, bar) values (1, 'a'), (2, 'b')", tableName))
+	receipt := requireReceipt(t, calls, hash, WaitFor(time.Second*10))
+
+	stats, err := calls.client.GetTableStats(context.Background(), id)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), stats.RowCount)
+	require.Equal(t, int32(2), stats.ColumnCount)
+	require.Len(t, stats.Indexes, 1)
+	require.Equal(t, []string{"id"}, stats.Indexes[0].Columns)
+	require.NotZero(t, stats.CreatedAt)
+	require.Equal(t, receipt.BlockNumber, stats.LastModifiedBlock)
+	require.Equal(t, hash, stats.LastModifiedTransactionHash)
+
+	id, err = NewTableID("1337")
+	require.NoError(t, err)
+	_, err = calls.client.GetTableStats(context.Background(), id)
+	require.ErrorIs(t, err, ErrTableNotFound)
+}
+
+func TestGetStateHash(t *testing.T) {
+	calls := setup(t)
+	requireCreate(t, calls)
+
+	// The stack calculates a state hash for the first processed block.
+	stateHash, err := calls.client.GetStateHash(context.Background(), 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(calls.client.chain.ID), stateHash.ChainId)
+	require.NotEmpty(t, stateHash.Hash)
+
+	atBlock, err := calls.client.GetStateHash(context.Background(), stateHash.BlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, stateHash, atBlock)
+}
+
 func TestVersion(t *testing.T) {
 	calls := setup(t)
 	info, err := calls.version()
@@ -214,6 +531,7 @@ type clientCalls struct {
 	client       *Client
 	create       func(schema string, opts ...CreateOption) (TableID, string)
 	write        func(query string) string
+	relayWrite   func(query string) string
 	query        func(query string, params []string, target interface{}, opts ...ReadOption)
 	receipt      func(txnHash string, options ...ReceiptOption) (*apiv1.TransactionReceipt, bool)
 	getTableByID func(tableID TableID) *apiv1.Table
@@ -222,7 +540,11 @@ type clientCalls struct {
 }
 
 func setup(t *testing.T) clientCalls {
-	stack := fullstack.CreateFullStack(t, fullstack.Deps{})
+	return setupWithDeps(t, fullstack.Deps{})
+}
+
+func setupWithDeps(t *testing.T, deps fullstack.Deps) clientCalls {
+	stack := fullstack.CreateFullStack(t, deps)
 
 	c := client.Chain{
 		Endpoint:     stack.Server.URL,
@@ -259,6 +581,12 @@ func setup(t *testing.T) clientCalls {
 			stack.Backend.Commit()
 			return hash
 		},
+		relayWrite: func(query string) string {
+			hash, err := client.RelayWrite(ctx, query)
+			require.NoError(t, err)
+			stack.Backend.Commit()
+			return hash
+		},
 		receipt: func(txnHash string, options ...ReceiptOption) (*apiv1.TransactionReceipt, bool) {
 			receipt, found, err := client.Receipt(ctx, txnHash, options...)
 			require.NoError(t, err)
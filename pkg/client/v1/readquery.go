@@ -1,12 +1,18 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+
+	"github.com/textileio/go-tableland/internal/router/controllers/apiv1"
+	"github.com/textileio/go-tableland/pkg/parsing"
 )
 
 // Output is used to control the output format of a Read using the ReadOutput option.
@@ -17,14 +23,26 @@ const (
 	Table Output = "table"
 	// Objects returns the query results as a JSON array of JSON objects. This is the default.
 	Objects Output = "objects"
+	// CSV returns the query results as CSV with a header row of column names.
+	CSV Output = "csv"
+	// NDJSON returns the query results as newline delimited JSON objects.
+	NDJSON Output = "ndjson"
+	// Arrow returns the query results as an Apache Arrow IPC stream.
+	Arrow Output = "arrow"
 )
 
 type readQueryParameters struct {
-	format  Output
-	extract bool
-	unwrap  bool
+	format      Output
+	extract     bool
+	unwrap      bool
+	pageSize    int
+	atBlock     int64
+	attestation *ReadAttestation
 }
 
+// defaultReadPageSize is the number of rows of each page read by ReadAll and ReadIterator if not specified.
+const defaultReadPageSize = 1000
+
 var defaultReadQueryParameters = readQueryParameters{
 	format:  Objects,
 	extract: false,
@@ -35,6 +53,7 @@ var defaultReadQueryParameters = readQueryParameters{
 type ReadOption func(*readQueryParameters)
 
 // ReadFormat sets the output format. Default is Objects.
+// The CSV, NDJSON and Arrow outputs aren't JSON, so the read target must be a *[]byte or an io.Writer.
 func ReadFormat(output Output) ReadOption {
 	return func(params *readQueryParameters) {
 		params.format = output
@@ -58,6 +77,31 @@ func ReadUnwrap() ReadOption {
 	}
 }
 
+// ReadPageSize sets the maximum number of rows of each page read by ReadAll and ReadIterator.
+// Default is 1000.
+func ReadPageSize(size int) ReadOption {
+	return func(params *readQueryParameters) {
+		params.pageSize = size
+	}
+}
+
+// ReadAtBlock reads the state the tables had at the end of the provided block, instead of the latest state.
+// It requires the validator to keep a change log of the chain that reaches the block, and isn't supported
+// by ReadAll and ReadIterator.
+func ReadAtBlock(block int64) ReadOption {
+	return func(params *readQueryParameters) {
+		params.atBlock = block
+	}
+}
+
+// ReadVerified verifies the signature of the response, and stores it in att. It fails if the validator didn't
+// sign the response. If several pages are read, att is the signature of the last one.
+func ReadVerified(att *ReadAttestation) ReadOption {
+	return func(params *readQueryParameters) {
+		params.attestation = att
+	}
+}
+
 var queryURL, _ = url.Parse("/api/v1/query")
 
 // Read runs a read query with the provided opts and unmarshals the results into target.
@@ -68,7 +112,134 @@ func (c *Client) Read(
 	for _, opt := range opts {
 		opt(&params)
 	}
+	params.pageSize = 0
+
+	_, err := c.readPage(ctx, query, queryParams, params, "", target)
+	return err
+}
+
+// ReadAll runs a read query following the cursors of every page, and unmarshals all the results into target.
+// Every page is read at the block heights where the first page was read. ReadAll only supports the Objects
+// and Table formats, and doesn't support ReadUnwrap.
+func (c *Client) ReadAll(
+	ctx context.Context, query string, queryParams []string, target interface{}, opts ...ReadOption,
+) error {
+	params := defaultReadQueryParameters
+	for _, opt := range opts {
+		opt(&params)
+	}
+	if params.unwrap {
+		return errors.New("unwrap isn't supported when reading all pages")
+	}
+	if params.atBlock > 0 {
+		return errors.New("reading at a block isn't supported when reading all pages")
+	}
+	if params.format != Objects && params.format != Table {
+		return fmt.Errorf("%s format isn't supported when reading all pages", params.format)
+	}
+
+	it := c.ReadIterator(query, queryParams, opts...)
+	var (
+		columns json.RawMessage
+		rows    []json.RawMessage
+	)
+	for {
+		var page json.RawMessage
+		ok, err := it.Next(ctx, &page)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		if params.format == Table {
+			var data struct {
+				Columns json.RawMessage   `json:"columns"`
+				Rows    []json.RawMessage `json:"rows"`
+			}
+			if err := json.Unmarshal(page, &data); err != nil {
+				return fmt.Errorf("decoding page: %s", err)
+			}
+			columns, rows = data.Columns, append(rows, data.Rows...)
+			continue
+		}
+		var objects []json.RawMessage
+		if err := json.Unmarshal(page, &objects); err != nil {
+			return fmt.Errorf("decoding page: %s", err)
+		}
+		rows = append(rows, objects...)
+	}
+
+	var all interface{} = rows
+	if params.format == Table {
+		all = map[string]interface{}{"columns": columns, "rows": rows}
+	}
+	b, err := json.Marshal(all)
+	if err != nil {
+		return fmt.Errorf("encoding results: %s", err)
+	}
+	if err := json.Unmarshal(b, target); err != nil {
+		return fmt.Errorf("decoding result into struct: %s", err)
+	}
+
+	return nil
+}
+
+// ReadIterator iterates over the pages of the results of a read query.
+type ReadIterator struct {
+	client      *Client
+	query       string
+	queryParams []string
+	params      readQueryParameters
+	cursor      string
+	done        bool
+}
+
+// ReadIterator returns an iterator over the pages of the results of a read query.
+// Every page is read at the block heights where the first page was read.
+func (c *Client) ReadIterator(query string, queryParams []string, opts ...ReadOption) *ReadIterator {
+	params := defaultReadQueryParameters
+	for _, opt := range opts {
+		opt(&params)
+	}
+	if params.pageSize <= 0 {
+		params.pageSize = defaultReadPageSize
+	}
+
+	return &ReadIterator{
+		client:      c,
+		query:       query,
+		queryParams: queryParams,
+		params:      params,
+	}
+}
+
+// Next reads the next page and unmarshals it into target. It returns false if there are no more pages.
+func (it *ReadIterator) Next(ctx context.Context, target interface{}) (bool, error) {
+	if it.done {
+		return false, nil
+	}
+
+	cursor, err := it.client.readPage(ctx, it.query, it.queryParams, it.params, it.cursor, target)
+	if err != nil {
+		return false, err
+	}
+	it.cursor = cursor
+	it.done = cursor == ""
 
+	return true, nil
+}
+
+// readPage runs a read query and unmarshals the results into target. If the results are paginated,
+// it returns the cursor of the next page, which is empty for the last page.
+func (c *Client) readPage(
+	ctx context.Context,
+	query string,
+	queryParams []string,
+	params readQueryParameters,
+	cursor string,
+	target interface{},
+) (string, error) {
 	url := c.baseURL.ResolveReference(queryURL)
 	values := url.Query()
 	values.Set("statement", query)
@@ -79,6 +250,15 @@ func (c *Client) Read(
 	if params.unwrap {
 		values.Set("unwrap", "true")
 	}
+	if params.pageSize > 0 {
+		values.Set("page_size", strconv.Itoa(params.pageSize))
+	}
+	if cursor != "" {
+		values.Set("cursor", cursor)
+	}
+	if params.atBlock > 0 {
+		values.Set("at_block", strconv.FormatInt(params.atBlock, 10))
+	}
 	for _, param := range queryParams {
 		values.Add("params", param)
 	}
@@ -86,26 +266,143 @@ func (c *Client) Read(
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
 	if err != nil {
-		return fmt.Errorf("creating request: %s", err)
-	}
-	q := req.URL.Query()
-	for _, param := range queryParams {
-		q.Add("params", param)
+		return "", fmt.Errorf("creating request: %s", err)
 	}
 
 	response, err := c.tblHTTP.Do(req)
 	if err != nil {
-		return fmt.Errorf("calling query: %s", err)
+		return "", fmt.Errorf("calling query: %s", err)
 	}
 	defer func() { _ = response.Body.Close() }()
 	if response.StatusCode != http.StatusOK {
 		msg, _ := io.ReadAll(response.Body)
-		return fmt.Errorf("the response wasn't successful (status: %d, body: %s)", response.StatusCode, msg)
+		return "", fmt.Errorf("the response wasn't successful (status: %d, body: %s)", response.StatusCode, msg)
 	}
 
-	if err := json.NewDecoder(response.Body).Decode(&target); err != nil {
-		return fmt.Errorf("decoding result into struct: %s", err)
+	var body io.Reader = response.Body
+	if params.attestation != nil {
+		b, err := io.ReadAll(response.Body)
+		if err != nil {
+			return "", fmt.Errorf("reading result: %s", err)
+		}
+		if err := verifyReadResponse(response.Header, query, queryParams, b, params.attestation); err != nil {
+			return "", err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	switch target := target.(type) {
+	case *[]byte:
+		if *target, err = io.ReadAll(body); err != nil {
+			return "", fmt.Errorf("reading result: %s", err)
+		}
+	case io.Writer:
+		if _, err := io.Copy(target, body); err != nil {
+			return "", fmt.Errorf("reading result: %s", err)
+		}
+	default:
+		if err := json.NewDecoder(body).Decode(&target); err != nil {
+			return "", fmt.Errorf("decoding result into struct: %s", err)
+		}
 	}
 
+	return response.Header.Get("X-Next-Cursor"), nil
+}
+
+// verifyReadResponse verifies the signature of the response of a read query with the params sent in the URL.
+func verifyReadResponse(
+	header http.Header, query string, queryParams []string, body []byte, att *ReadAttestation,
+) error {
+	values := make([]any, len(queryParams))
+	for i, p := range queryParams {
+		v, err := parsing.ParseParam(p)
+		if err != nil {
+			return fmt.Errorf("parsing param %d: %s", i+1, err)
+		}
+		values[i] = v
+	}
+	verified, err := VerifyReadAttestation(header, query, values, body)
+	if err != nil {
+		return fmt.Errorf("verifying response: %w", err)
+	}
+	*att = *verified
 	return nil
 }
+
+// BatchQuery is a read query of a batch. Params are the values of its positional parameters.
+type BatchQuery struct {
+	Statement string
+	Params    []interface{}
+}
+
+// BatchResult is the result of a read query of a batch. If the query failed, Error is its error and Result is
+// empty.
+type BatchResult struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// Decode unmarshals the results of the query into target, or returns its error if it failed.
+func (r BatchResult) Decode(target interface{}) error {
+	if r.Error != "" {
+		return errors.New(r.Error)
+	}
+	if err := json.Unmarshal(r.Result, target); err != nil {
+		return fmt.Errorf("decoding result into struct: %s", err)
+	}
+	return nil
+}
+
+var queryBatchURL, _ = url.Parse("/api/v1/query/batch")
+
+// ReadBatch runs read queries on the same state of the tables, and returns their results in the order of the
+// queries. A failing query doesn't fail the others, its error is returned in its result. ReadBatch only
+// supports the ReadFormat and ReadExtract options, with the Objects and Table formats.
+func (c *Client) ReadBatch(ctx context.Context, queries []BatchQuery, opts ...ReadOption) ([]BatchResult, error) {
+	params := defaultReadQueryParameters
+	for _, opt := range opts {
+		opt(&params)
+	}
+	if params.unwrap || params.pageSize > 0 || params.atBlock > 0 || params.attestation != nil {
+		return nil, errors.New("only the format and extract options are supported in batches")
+	}
+	if params.format != Objects && params.format != Table {
+		return nil, fmt.Errorf("%s format isn't supported in batches", params.format)
+	}
+
+	batch := apiv1.QueryBatch{
+		Queries: make([]apiv1.BatchQuery, len(queries)),
+		Format:  string(params.format),
+		Extract: params.extract,
+	}
+	for i, q := range queries {
+		batch.Queries[i] = apiv1.BatchQuery{Statement: q.Statement, Params: q.Params}
+	}
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling batch: %s", err)
+	}
+
+	url := c.baseURL.ResolveReference(queryBatchURL)
+	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	response, err := c.tblHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling query batch: %s", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("the response wasn't successful (status: %d, body: %s)", response.StatusCode, msg)
+	}
+
+	var results []BatchResult
+	if err := json.NewDecoder(response.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("unmarshaling result: %s", err)
+	}
+	return results, nil
+}
@@ -1,24 +1,30 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/textileio/go-tableland/internal/router/controllers/apiv1"
 )
 
+// maxReceiptLookups is the maximum number of transactions looked up in a single receipts request.
+const maxReceiptLookups = 100
+
 type receiptConfig struct {
 	timeout *time.Duration
 }
 
-// ReceiptOption controls the behavior of calls to Receipt.
+// ReceiptOption controls the behavior of calls to Receipt and Receipts.
 type ReceiptOption func(*receiptConfig)
 
-// WaitFor causes calls to Receipt to wait for the specified duration.
+// WaitFor causes calls to Receipt and Receipts to wait for the specified duration.
 func WaitFor(timeout time.Duration) ReceiptOption {
 	return func(rc *receiptConfig) {
 		rc.timeout = &timeout
@@ -88,3 +94,201 @@ func (c *Client) waitForReceipt(
 	}
 	return nil, false, nil
 }
+
+// ReceiptsByHashes gets the receipts of many transactions in a single call. The returned map has an entry for each hash,
+// which is nil if the transaction wasn't found. With WaitFor, it polls the transactions that weren't found every
+// second, until all of them are found or the timeout expires.
+func (c *Client) ReceiptsByHashes(
+	ctx context.Context,
+	txnHashes []string,
+	options ...ReceiptOption,
+) (map[string]*apiv1.TransactionReceipt, error) {
+	config := receiptConfig{}
+	for _, option := range options {
+		option(&config)
+	}
+
+	receipts := make(map[string]*apiv1.TransactionReceipt, len(txnHashes))
+	pending := txnHashes
+	var timeout <-chan time.Time
+	if config.timeout != nil {
+		timeout = time.After(*config.timeout)
+	}
+	for {
+		found, err := c.getReceipts(ctx, pending)
+		if err != nil {
+			return nil, err
+		}
+		var stillPending []string
+		for _, txnHash := range pending {
+			receipts[txnHash] = found[txnHash]
+			if found[txnHash] == nil {
+				stillPending = append(stillPending, txnHash)
+			}
+		}
+		pending = stillPending
+		if len(pending) == 0 || timeout == nil {
+			return receipts, nil
+		}
+
+		select {
+		case <-timeout:
+			return receipts, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// getReceipts looks up the receipts of the transactions in batches of up to maxReceiptLookups,
+// and returns the ones that were found.
+func (c *Client) getReceipts(ctx context.Context, txnHashes []string) (map[string]*apiv1.TransactionReceipt, error) {
+	receipts := make(map[string]*apiv1.TransactionReceipt, len(txnHashes))
+	for start := 0; start < len(txnHashes); start += maxReceiptLookups {
+		end := start + maxReceiptLookups
+		if end > len(txnHashes) {
+			end = len(txnHashes)
+		}
+		lookups := make([]apiv1.TransactionLookup, end-start)
+		for i, txnHash := range txnHashes[start:end] {
+			lookups[i] = apiv1.TransactionLookup{ChainId: int32(c.chain.ID), TransactionHash: txnHash}
+		}
+		body, err := json.Marshal(lookups)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling lookups: %s", err)
+		}
+
+		url := fmt.Sprintf("%s/api/v1/receipts", c.baseURL)
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %s", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		response, err := c.tblHTTP.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("calling get receipts by transaction hashes: %s", err)
+		}
+		if response.StatusCode != http.StatusOK {
+			msg, _ := io.ReadAll(response.Body)
+			_ = response.Body.Close()
+			return nil, fmt.Errorf("failed call (status: %d, body: %s)", response.StatusCode, msg)
+		}
+		var results []apiv1.ReceiptLookupResult
+		err = json.NewDecoder(response.Body).Decode(&results)
+		_ = response.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unmarshaling result: %s", err)
+		}
+		for _, result := range results {
+			if result.Found {
+				receipts[result.TransactionHash] = result.Receipt
+			}
+		}
+	}
+	return receipts, nil
+}
+
+type receiptsFilter struct {
+	fromBlock int64
+	toBlock   int64
+	tableID   *TableID
+}
+
+// ReceiptsOption controls the receipts returned by Receipts.
+type ReceiptsOption func(*receiptsFilter)
+
+// ReceiptsFromBlock returns the receipts of transactions executed at or after the provided block.
+func ReceiptsFromBlock(block int64) ReceiptsOption {
+	return func(f *receiptsFilter) {
+		f.fromBlock = block
+	}
+}
+
+// ReceiptsToBlock returns the receipts of transactions executed at or before the provided block.
+func ReceiptsToBlock(block int64) ReceiptsOption {
+	return func(f *receiptsFilter) {
+		f.toBlock = block
+	}
+}
+
+// ReceiptsForTable returns the receipts of transactions that modified the provided table.
+func ReceiptsForTable(tableID TableID) ReceiptsOption {
+	return func(f *receiptsFilter) {
+		f.tableID = &tableID
+	}
+}
+
+// ReceiptIterator iterates over the pages of the receipts of a chain, ordered by execution.
+type ReceiptIterator struct {
+	client *Client
+	filter receiptsFilter
+	cursor string
+	done   bool
+}
+
+// Receipts returns an iterator over the pages of the receipts that match the provided options.
+// Without options, it iterates over all the receipts of the chain.
+func (c *Client) Receipts(opts ...ReceiptsOption) *ReceiptIterator {
+	var filter receiptsFilter
+	for _, opt := range opts {
+		opt(&filter)
+	}
+
+	return &ReceiptIterator{
+		client: c,
+		filter: filter,
+	}
+}
+
+// Next returns the receipts of the next page. It returns false if there are no more pages.
+func (it *ReceiptIterator) Next(ctx context.Context) ([]apiv1.TransactionReceipt, bool, error) {
+	if it.done {
+		return nil, false, nil
+	}
+
+	page, err := it.client.listReceipts(ctx, it.filter, it.cursor)
+	if err != nil {
+		return nil, false, err
+	}
+	it.cursor = page.NextCursor
+	it.done = page.NextCursor == ""
+
+	return page.Receipts, true, nil
+}
+
+func (c *Client) listReceipts(ctx context.Context, filter receiptsFilter, cursor string) (*apiv1.ReceiptsPage, error) {
+	query := url.Values{}
+	if filter.fromBlock > 0 {
+		query.Set("from_block", strconv.FormatInt(filter.fromBlock, 10))
+	}
+	if filter.toBlock > 0 {
+		query.Set("to_block", strconv.FormatInt(filter.toBlock, 10))
+	}
+	if filter.tableID != nil {
+		query.Set("table_id", filter.tableID.String())
+	}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	url := fmt.Sprintf("%s/api/v1/receipts/%d?%s", c.baseURL, c.chain.ID, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %s", err)
+	}
+	response, err := c.tblHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling list receipts: %s", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("failed call (status: %d, body: %s)", response.StatusCode, msg)
+	}
+	var page apiv1.ReceiptsPage
+	if err := json.NewDecoder(response.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("unmarshaling result: %s", err)
+	}
+
+	return &page, nil
+}
@@ -0,0 +1,47 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/textileio/go-tableland/internal/router/controllers/apiv1"
+)
+
+// ErrStateHashNotFound is returned if the validator didn't calculate a state hash at or before a block.
+var ErrStateHashNotFound = errors.New("state hash not found")
+
+// GetStateHash returns the last state hash of the chain calculated by the validator at or before the provided
+// block, or the last one if block is zero. Validators with the same state hash at a block have the same state.
+// If there's none, it returns ErrStateHashNotFound.
+func (c *Client) GetStateHash(ctx context.Context, block int64) (*apiv1.StateHash, error) {
+	url := fmt.Sprintf("%s/api/v1/statehash/%d", c.baseURL, c.chain.ID)
+	if block > 0 {
+		url = fmt.Sprintf("%s?block=%d", url, block)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %s", err)
+	}
+	response, err := c.tblHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling get state hash: %s", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrStateHashNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("failed call (status: %d, body: %s)", response.StatusCode, msg)
+	}
+	var stateHash apiv1.StateHash
+	if err := json.NewDecoder(response.Body).Decode(&stateHash); err != nil {
+		return nil, fmt.Errorf("unmarshaling result: %s", err)
+	}
+
+	return &stateHash, nil
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/textileio/go-tableland/internal/router/controllers/apiv1"
 )
 
@@ -41,3 +43,122 @@ func (c *Client) GetTable(ctx context.Context, tableID TableID) (*apiv1.Table, e
 
 	return &table, nil
 }
+
+// GetTableACL returns the access control rules of a table: the privileges granted to each address, and
+// the controller contract of the table, if any. If the table ID doesn't exist, it returns ErrTableNotFound.
+func (c *Client) GetTableACL(ctx context.Context, tableID TableID) (*apiv1.TableAcl, error) {
+	url := fmt.Sprintf("%s/api/v1/tables/%d/%d/acl", c.baseURL, c.chain.ID, tableID.ToBigInt().Uint64())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %s", err)
+	}
+	response, err := c.tblHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling get table acl: %s", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrTableNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("failed call (status: %d, body: %s)", response.StatusCode, msg)
+	}
+	var acl apiv1.TableAcl
+	if err := json.NewDecoder(response.Body).Decode(&acl); err != nil {
+		return nil, fmt.Errorf("unmarshaling result: %s", err)
+	}
+
+	return &acl, nil
+}
+
+// GetTableStats returns the statistics of a table, such as its row count and approximate size.
+// If the table ID doesn't exist, it returns ErrTableNotFound.
+func (c *Client) GetTableStats(ctx context.Context, tableID TableID) (*apiv1.TableStats, error) {
+	url := fmt.Sprintf("%s/api/v1/tables/%d/%d/stats", c.baseURL, c.chain.ID, tableID.ToBigInt().Uint64())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %s", err)
+	}
+	response, err := c.tblHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling get table stats: %s", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrTableNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("failed call (status: %d, body: %s)", response.StatusCode, msg)
+	}
+	var stats apiv1.TableStats
+	if err := json.NewDecoder(response.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("unmarshaling result: %s", err)
+	}
+
+	return &stats, nil
+}
+
+// ListTables returns a page of the tables owned by the provided address. The cursor should be empty
+// to get the first page, or the NextCursor value of the previous page to continue listing. An empty
+// NextCursor in the returned page means there are no more tables.
+func (c *Client) ListTables(ctx context.Context, owner common.Address, cursor string) (*apiv1.TablesPage, error) {
+	query := url.Values{}
+	query.Set("owner", owner.Hex())
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	url := fmt.Sprintf("%s/api/v1/tables/%d?%s", c.baseURL, c.chain.ID, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %s", err)
+	}
+	response, err := c.tblHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling list tables: %s", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("failed call (status: %d, body: %s)", response.StatusCode, msg)
+	}
+	var page apiv1.TablesPage
+	if err := json.NewDecoder(response.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("unmarshaling result: %s", err)
+	}
+
+	return &page, nil
+}
+
+// GetTableEvents returns a page of the history of a table, which are the events of the table ordered by execution.
+// The cursor is the NextCursor of the previous page, or empty for the first page. The validator must persist the
+// events of the chain, otherwise the history is empty.
+func (c *Client) GetTableEvents(ctx context.Context, tableID TableID, cursor string) (*apiv1.TableEventsPage, error) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	url := fmt.Sprintf(
+		"%s/api/v1/tables/%d/%d/events?%s", c.baseURL, c.chain.ID, tableID.ToBigInt().Uint64(), query.Encode(),
+	)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %s", err)
+	}
+	response, err := c.tblHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling get table events: %s", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("failed call (status: %d, body: %s)", response.StatusCode, msg)
+	}
+	var page apiv1.TableEventsPage
+	if err := json.NewDecoder(response.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("unmarshaling result: %s", err)
+	}
+
+	return &page, nil
+}
@@ -1,13 +1,21 @@
 package v1
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"net/http"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/textileio/go-tableland/internal/router/controllers/apiv1"
 	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/relay"
 	"github.com/textileio/go-tableland/pkg/tables"
 )
 
@@ -134,3 +142,63 @@ func WithEstimatedGasLimitMultiplier(m float64) WriteOption {
 		return nil
 	}
 }
+
+// relayWriteDeadline is how long a validator can take to relay a write before it expires.
+const relayWriteDeadline = 10 * time.Minute
+
+// RelayWrite signs a write query and sends it to the validator, which submits it with its own wallet, returning the
+// txn hash. The validator wallet must also have privileges on the table.
+func (c *Client) RelayWrite(ctx context.Context, query string) (string, error) {
+	tableID, err := c.Validate(query)
+	if err != nil {
+		return "", fmt.Errorf("calling Validate: %v", err)
+	}
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", fmt.Errorf("generating nonce: %s", err)
+	}
+	sw, err := relay.Sign(c.wallet, c.chain.ContractAddr, relay.Write{
+		ChainID:   tableland.ChainID(c.chain.ID),
+		TableID:   tables.TableID(tableID),
+		Statement: query,
+		Nonce:     nonce,
+		Deadline:  time.Now().Add(relayWriteDeadline).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("signing write: %s", err)
+	}
+
+	body, err := json.Marshal(apiv1.SignedWrite{
+		TableId:   sw.TableID.String(),
+		Statement: sw.Statement,
+		Nonce:     sw.Nonce.String(),
+		Deadline:  sw.Deadline,
+		Signature: hexutil.Encode(sw.Signature),
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshaling signed write: %s", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/relay/%d", c.baseURL, c.chain.ID)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("creating request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	response, err := c.tblHTTP.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("calling relay: %s", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(response.Body)
+		return "", fmt.Errorf("the response wasn't successful (status: %d, body: %s)", response.StatusCode, msg)
+	}
+
+	var relayed apiv1.RelayedWrite
+	if err := json.NewDecoder(response.Body).Decode(&relayed); err != nil {
+		return "", fmt.Errorf("unmarshaling result: %s", err)
+	}
+	return relayed.TransactionHash, nil
+}
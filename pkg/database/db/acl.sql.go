@@ -32,3 +32,58 @@ func (q *Queries) GetAclByTableAndController(ctx context.Context, arg GetAclByTa
 	)
 	return i, err
 }
+
+const getController = `-- name: GetController :one
+SELECT controller FROM system_controller WHERE chain_id = ?1 AND table_id = ?2
+`
+
+type GetControllerParams struct {
+	ChainID int64
+	TableID int64
+}
+
+func (q *Queries) GetController(ctx context.Context, arg GetControllerParams) (string, error) {
+	row := q.queryRow(ctx, q.getControllerStmt, getController, arg.ChainID, arg.TableID)
+	var controller string
+	err := row.Scan(&controller)
+	return controller, err
+}
+
+const listAclByTable = `-- name: ListAclByTable :many
+SELECT table_id, controller, privileges, chain_id, created_at, updated_at FROM system_acl WHERE chain_id = ?1 AND table_id = ?2 ORDER BY created_at, controller
+`
+
+type ListAclByTableParams struct {
+	ChainID int64
+	TableID int64
+}
+
+func (q *Queries) ListAclByTable(ctx context.Context, arg ListAclByTableParams) ([]SystemAcl, error) {
+	rows, err := q.query(ctx, q.listAclByTableStmt, listAclByTable, arg.ChainID, arg.TableID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SystemAcl
+	for rows.Next() {
+		var i SystemAcl
+		if err := rows.Scan(
+			&i.TableID,
+			&i.Controller,
+			&i.Privileges,
+			&i.ChainID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
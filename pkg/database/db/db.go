@@ -27,9 +27,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.areEVMEventsPersistedStmt, err = db.PrepareContext(ctx, areEVMEventsPersisted); err != nil {
 		return nil, fmt.Errorf("error preparing query AreEVMEventsPersisted: %w", err)
 	}
+	if q.countRelayWritesSinceStmt, err = db.PrepareContext(ctx, countRelayWritesSince); err != nil {
+		return nil, fmt.Errorf("error preparing query CountRelayWritesSince: %w", err)
+	}
 	if q.deletePendingTxByHashStmt, err = db.PrepareContext(ctx, deletePendingTxByHash); err != nil {
 		return nil, fmt.Errorf("error preparing query DeletePendingTxByHash: %w", err)
 	}
+	if q.deleteRelayWriteStmt, err = db.PrepareContext(ctx, deleteRelayWrite); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteRelayWrite: %w", err)
+	}
 	if q.getAclByTableAndControllerStmt, err = db.PrepareContext(ctx, getAclByTableAndController); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAclByTableAndController: %w", err)
 	}
@@ -42,6 +48,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getBlocksMissingExtraInfoByBlockNumberStmt, err = db.PrepareContext(ctx, getBlocksMissingExtraInfoByBlockNumber); err != nil {
 		return nil, fmt.Errorf("error preparing query GetBlocksMissingExtraInfoByBlockNumber: %w", err)
 	}
+	if q.getChainRelayUsageSinceStmt, err = db.PrepareContext(ctx, getChainRelayUsageSince); err != nil {
+		return nil, fmt.Errorf("error preparing query GetChainRelayUsageSince: %w", err)
+	}
+	if q.getControllerStmt, err = db.PrepareContext(ctx, getController); err != nil {
+		return nil, fmt.Errorf("error preparing query GetController: %w", err)
+	}
 	if q.getEVMEventsStmt, err = db.PrepareContext(ctx, getEVMEvents); err != nil {
 		return nil, fmt.Errorf("error preparing query GetEVMEvents: %w", err)
 	}
@@ -54,6 +66,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getSchemaByTableNameStmt, err = db.PrepareContext(ctx, getSchemaByTableName); err != nil {
 		return nil, fmt.Errorf("error preparing query GetSchemaByTableName: %w", err)
 	}
+	if q.getStateHashStmt, err = db.PrepareContext(ctx, getStateHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStateHash: %w", err)
+	}
 	if q.getTableStmt, err = db.PrepareContext(ctx, getTable); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTable: %w", err)
 	}
@@ -69,12 +84,39 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.insertPendingTxStmt, err = db.PrepareContext(ctx, insertPendingTx); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertPendingTx: %w", err)
 	}
+	if q.insertRelayWriteStmt, err = db.PrepareContext(ctx, insertRelayWrite); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertRelayWrite: %w", err)
+	}
+	if q.listAPIKeysStmt, err = db.PrepareContext(ctx, listAPIKeys); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAPIKeys: %w", err)
+	}
+	if q.listAclByTableStmt, err = db.PrepareContext(ctx, listAclByTable); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAclByTable: %w", err)
+	}
 	if q.listPendingTxStmt, err = db.PrepareContext(ctx, listPendingTx); err != nil {
 		return nil, fmt.Errorf("error preparing query ListPendingTx: %w", err)
 	}
+	if q.listReceiptsAfterStmt, err = db.PrepareContext(ctx, listReceiptsAfter); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReceiptsAfter: %w", err)
+	}
+	if q.listReceiptsInRangeStmt, err = db.PrepareContext(ctx, listReceiptsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListReceiptsInRange: %w", err)
+	}
+	if q.listTableEventsStmt, err = db.PrepareContext(ctx, listTableEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTableEvents: %w", err)
+	}
+	if q.listTableReceiptsInRangeStmt, err = db.PrepareContext(ctx, listTableReceiptsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTableReceiptsInRange: %w", err)
+	}
+	if q.listTablesByControllerStmt, err = db.PrepareContext(ctx, listTablesByController); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTablesByController: %w", err)
+	}
 	if q.replacePendingTxByHashStmt, err = db.PrepareContext(ctx, replacePendingTxByHash); err != nil {
 		return nil, fmt.Errorf("error preparing query ReplacePendingTxByHash: %w", err)
 	}
+	if q.setRelayWriteTxnStmt, err = db.PrepareContext(ctx, setRelayWriteTxn); err != nil {
+		return nil, fmt.Errorf("error preparing query SetRelayWriteTxn: %w", err)
+	}
 	return &q, nil
 }
 
@@ -85,11 +127,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing areEVMEventsPersistedStmt: %w", cerr)
 		}
 	}
+	if q.countRelayWritesSinceStmt != nil {
+		if cerr := q.countRelayWritesSinceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countRelayWritesSinceStmt: %w", cerr)
+		}
+	}
 	if q.deletePendingTxByHashStmt != nil {
 		if cerr := q.deletePendingTxByHashStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deletePendingTxByHashStmt: %w", cerr)
 		}
 	}
+	if q.deleteRelayWriteStmt != nil {
+		if cerr := q.deleteRelayWriteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteRelayWriteStmt: %w", cerr)
+		}
+	}
 	if q.getAclByTableAndControllerStmt != nil {
 		if cerr := q.getAclByTableAndControllerStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAclByTableAndControllerStmt: %w", cerr)
@@ -110,6 +162,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getBlocksMissingExtraInfoByBlockNumberStmt: %w", cerr)
 		}
 	}
+	if q.getChainRelayUsageSinceStmt != nil {
+		if cerr := q.getChainRelayUsageSinceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getChainRelayUsageSinceStmt: %w", cerr)
+		}
+	}
+	if q.getControllerStmt != nil {
+		if cerr := q.getControllerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getControllerStmt: %w", cerr)
+		}
+	}
 	if q.getEVMEventsStmt != nil {
 		if cerr := q.getEVMEventsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getEVMEventsStmt: %w", cerr)
@@ -130,6 +192,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getSchemaByTableNameStmt: %w", cerr)
 		}
 	}
+	if q.getStateHashStmt != nil {
+		if cerr := q.getStateHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStateHashStmt: %w", cerr)
+		}
+	}
 	if q.getTableStmt != nil {
 		if cerr := q.getTableStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTableStmt: %w", cerr)
@@ -155,16 +222,61 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing insertPendingTxStmt: %w", cerr)
 		}
 	}
+	if q.insertRelayWriteStmt != nil {
+		if cerr := q.insertRelayWriteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertRelayWriteStmt: %w", cerr)
+		}
+	}
+	if q.listAPIKeysStmt != nil {
+		if cerr := q.listAPIKeysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAPIKeysStmt: %w", cerr)
+		}
+	}
+	if q.listAclByTableStmt != nil {
+		if cerr := q.listAclByTableStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAclByTableStmt: %w", cerr)
+		}
+	}
 	if q.listPendingTxStmt != nil {
 		if cerr := q.listPendingTxStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listPendingTxStmt: %w", cerr)
 		}
 	}
+	if q.listReceiptsAfterStmt != nil {
+		if cerr := q.listReceiptsAfterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReceiptsAfterStmt: %w", cerr)
+		}
+	}
+	if q.listReceiptsInRangeStmt != nil {
+		if cerr := q.listReceiptsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listReceiptsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.listTableEventsStmt != nil {
+		if cerr := q.listTableEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTableEventsStmt: %w", cerr)
+		}
+	}
+	if q.listTableReceiptsInRangeStmt != nil {
+		if cerr := q.listTableReceiptsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTableReceiptsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.listTablesByControllerStmt != nil {
+		if cerr := q.listTablesByControllerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTablesByControllerStmt: %w", cerr)
+		}
+	}
 	if q.replacePendingTxByHashStmt != nil {
 		if cerr := q.replacePendingTxByHashStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing replacePendingTxByHashStmt: %w", cerr)
 		}
 	}
+	if q.setRelayWriteTxnStmt != nil {
+		if cerr := q.setRelayWriteTxnStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setRelayWriteTxnStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -205,22 +317,36 @@ type Queries struct {
 	db                                         DBTX
 	tx                                         *sql.Tx
 	areEVMEventsPersistedStmt                  *sql.Stmt
+	countRelayWritesSinceStmt                  *sql.Stmt
 	deletePendingTxByHashStmt                  *sql.Stmt
+	deleteRelayWriteStmt                       *sql.Stmt
 	getAclByTableAndControllerStmt             *sql.Stmt
 	getBlockExtraInfoStmt                      *sql.Stmt
 	getBlocksMissingExtraInfoStmt              *sql.Stmt
 	getBlocksMissingExtraInfoByBlockNumberStmt *sql.Stmt
+	getChainRelayUsageSinceStmt                *sql.Stmt
+	getControllerStmt                          *sql.Stmt
 	getEVMEventsStmt                           *sql.Stmt
 	getIdStmt                                  *sql.Stmt
 	getReceiptStmt                             *sql.Stmt
 	getSchemaByTableNameStmt                   *sql.Stmt
+	getStateHashStmt                           *sql.Stmt
 	getTableStmt                               *sql.Stmt
 	insertBlockExtraInfoStmt                   *sql.Stmt
 	insertEVMEventStmt                         *sql.Stmt
 	insertIdStmt                               *sql.Stmt
 	insertPendingTxStmt                        *sql.Stmt
+	insertRelayWriteStmt                       *sql.Stmt
+	listAPIKeysStmt                            *sql.Stmt
+	listAclByTableStmt                         *sql.Stmt
 	listPendingTxStmt                          *sql.Stmt
+	listReceiptsAfterStmt                      *sql.Stmt
+	listReceiptsInRangeStmt                    *sql.Stmt
+	listTableEventsStmt                        *sql.Stmt
+	listTableReceiptsInRangeStmt               *sql.Stmt
+	listTablesByControllerStmt                 *sql.Stmt
 	replacePendingTxByHashStmt                 *sql.Stmt
+	setRelayWriteTxnStmt                       *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
@@ -228,21 +354,35 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		db:                             tx,
 		tx:                             tx,
 		areEVMEventsPersistedStmt:      q.areEVMEventsPersistedStmt,
+		countRelayWritesSinceStmt:      q.countRelayWritesSinceStmt,
 		deletePendingTxByHashStmt:      q.deletePendingTxByHashStmt,
+		deleteRelayWriteStmt:           q.deleteRelayWriteStmt,
 		getAclByTableAndControllerStmt: q.getAclByTableAndControllerStmt,
 		getBlockExtraInfoStmt:          q.getBlockExtraInfoStmt,
 		getBlocksMissingExtraInfoStmt:  q.getBlocksMissingExtraInfoStmt,
 		getBlocksMissingExtraInfoByBlockNumberStmt: q.getBlocksMissingExtraInfoByBlockNumberStmt,
-		getEVMEventsStmt:           q.getEVMEventsStmt,
-		getIdStmt:                  q.getIdStmt,
-		getReceiptStmt:             q.getReceiptStmt,
-		getSchemaByTableNameStmt:   q.getSchemaByTableNameStmt,
-		getTableStmt:               q.getTableStmt,
-		insertBlockExtraInfoStmt:   q.insertBlockExtraInfoStmt,
-		insertEVMEventStmt:         q.insertEVMEventStmt,
-		insertIdStmt:               q.insertIdStmt,
-		insertPendingTxStmt:        q.insertPendingTxStmt,
-		listPendingTxStmt:          q.listPendingTxStmt,
-		replacePendingTxByHashStmt: q.replacePendingTxByHashStmt,
+		getChainRelayUsageSinceStmt:                q.getChainRelayUsageSinceStmt,
+		getControllerStmt:                          q.getControllerStmt,
+		getEVMEventsStmt:                           q.getEVMEventsStmt,
+		getIdStmt:                                  q.getIdStmt,
+		getReceiptStmt:                             q.getReceiptStmt,
+		getSchemaByTableNameStmt:                   q.getSchemaByTableNameStmt,
+		getStateHashStmt:                           q.getStateHashStmt,
+		getTableStmt:                               q.getTableStmt,
+		insertBlockExtraInfoStmt:                   q.insertBlockExtraInfoStmt,
+		insertEVMEventStmt:                         q.insertEVMEventStmt,
+		insertIdStmt:                               q.insertIdStmt,
+		insertPendingTxStmt:                        q.insertPendingTxStmt,
+		insertRelayWriteStmt:                       q.insertRelayWriteStmt,
+		listAPIKeysStmt:                            q.listAPIKeysStmt,
+		listAclByTableStmt:                         q.listAclByTableStmt,
+		listPendingTxStmt:                          q.listPendingTxStmt,
+		listReceiptsAfterStmt:                      q.listReceiptsAfterStmt,
+		listReceiptsInRangeStmt:                    q.listReceiptsInRangeStmt,
+		listTableEventsStmt:                        q.listTableEventsStmt,
+		listTableReceiptsInRangeStmt:               q.listTableReceiptsInRangeStmt,
+		listTablesByControllerStmt:                 q.listTablesByControllerStmt,
+		replacePendingTxByHashStmt:                 q.replacePendingTxByHashStmt,
+		setRelayWriteTxnStmt:                       q.setRelayWriteTxnStmt,
 	}
 }
@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const areEVMEventsPersisted = `-- name: AreEVMEventsPersisted :one
@@ -108,7 +109,7 @@ func (q *Queries) GetBlocksMissingExtraInfoByBlockNumber(ctx context.Context, ar
 }
 
 const getEVMEvents = `-- name: GetEVMEvents :many
-SELECT chain_id, event_json, event_type, address, topics, data, block_number, tx_hash, tx_index, block_hash, event_index FROM system_evm_events WHERE chain_id=?1 AND tx_hash=?2
+SELECT chain_id, event_json, event_type, address, topics, data, block_number, tx_hash, tx_index, block_hash, event_index, table_id FROM system_evm_events WHERE chain_id=?1 AND tx_hash=?2
 `
 
 type GetEVMEventsParams struct {
@@ -137,6 +138,7 @@ func (q *Queries) GetEVMEvents(ctx context.Context, arg GetEVMEventsParams) ([]S
 			&i.TxIndex,
 			&i.BlockHash,
 			&i.EventIndex,
+			&i.TableID,
 		); err != nil {
 			return nil, err
 		}
@@ -167,8 +169,8 @@ func (q *Queries) InsertBlockExtraInfo(ctx context.Context, arg InsertBlockExtra
 }
 
 const insertEVMEvent = `-- name: InsertEVMEvent :exec
-INSERT INTO system_evm_events (chain_id, event_json, event_type, address, topics, data, block_number, tx_hash, tx_index, block_hash, event_index)
-VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11)
+INSERT INTO system_evm_events (chain_id, event_json, event_type, address, topics, data, block_number, tx_hash, tx_index, block_hash, event_index, table_id)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12)
 `
 
 type InsertEVMEventParams struct {
@@ -183,6 +185,7 @@ type InsertEVMEventParams struct {
 	TxIndex     uint
 	BlockHash   string
 	EventIndex  uint
+	TableID     sql.NullInt64
 }
 
 func (q *Queries) InsertEVMEvent(ctx context.Context, arg InsertEVMEventParams) error {
@@ -198,6 +201,78 @@ func (q *Queries) InsertEVMEvent(ctx context.Context, arg InsertEVMEventParams)
 		arg.TxIndex,
 		arg.BlockHash,
 		arg.EventIndex,
+		arg.TableID,
 	)
 	return err
 }
+
+const listTableEvents = `-- name: ListTableEvents :many
+SELECT e.block_number, e.tx_hash, e.tx_index, e.event_index, e.event_type, e.event_json, b.timestamp, r.txn_hash, r.error, r.error_event_idx
+FROM system_evm_events e
+LEFT JOIN system_evm_blocks b ON b.chain_id = e.chain_id AND b.block_number = e.block_number
+LEFT JOIN system_txn_receipts r ON r.chain_id = e.chain_id AND r.txn_hash = e.tx_hash
+WHERE e.chain_id = ?1 AND e.table_id = ?2 AND (e.block_number > ?3 OR (e.block_number = ?3 AND e.event_index > ?4))
+ORDER BY e.block_number, e.event_index
+LIMIT ?5
+`
+
+type ListTableEventsParams struct {
+	ChainID     int64
+	TableID     sql.NullInt64
+	BlockNumber int64
+	EventIndex  uint
+	Limit       int64
+}
+
+type ListTableEventsRow struct {
+	BlockNumber   int64
+	TxHash        string
+	TxIndex       uint
+	EventIndex    uint
+	EventType     string
+	EventJson     string
+	Timestamp     sql.NullInt64
+	TxnHash       sql.NullString
+	Error         sql.NullString
+	ErrorEventIdx sql.NullInt64
+}
+
+func (q *Queries) ListTableEvents(ctx context.Context, arg ListTableEventsParams) ([]ListTableEventsRow, error) {
+	rows, err := q.query(ctx, q.listTableEventsStmt, listTableEvents,
+		arg.ChainID,
+		arg.TableID,
+		arg.BlockNumber,
+		arg.EventIndex,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTableEventsRow
+	for rows.Next() {
+		var i ListTableEventsRow
+		if err := rows.Scan(
+			&i.BlockNumber,
+			&i.TxHash,
+			&i.TxIndex,
+			&i.EventIndex,
+			&i.EventType,
+			&i.EventJson,
+			&i.Timestamp,
+			&i.TxnHash,
+			&i.Error,
+			&i.ErrorEventIdx,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
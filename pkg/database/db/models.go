@@ -31,6 +31,12 @@ type SystemAcl struct {
 	UpdatedAt  sql.NullInt64
 }
 
+type SystemApiKey struct {
+	ApiKey     string
+	MaxRpi     int64
+	IntervalMs int64
+}
+
 type SystemController struct {
 	ChainID    int64
 	TableID    int64
@@ -55,6 +61,7 @@ type SystemEvmEvent struct {
 	TxIndex     uint
 	BlockHash   string
 	EventIndex  uint
+	TableID     sql.NullInt64
 }
 
 type SystemID struct {
@@ -71,6 +78,22 @@ type SystemPendingTx struct {
 	UpdatedAt      sql.NullInt64
 }
 
+type SystemRelayWrite struct {
+	ChainID   int64
+	Signer    string
+	Nonce     string
+	TxnHash   sql.NullString
+	CreatedAt int64
+	Gas       int64
+}
+
+type SystemStateHash struct {
+	ChainID     int64
+	BlockNumber int64
+	Hash        string
+	CreatedAt   int64
+}
+
 type SystemTxnProcessor struct {
 	ChainID     int64
 	BlockNumber int64
@@ -86,3 +109,10 @@ type SystemTxnReceipt struct {
 	ErrorEventIdx sql.NullInt64
 	TableIds      sql.NullString
 }
+
+type SystemTxnReceiptTable struct {
+	ChainID      int64
+	TableID      int64
+	BlockNumber  int64
+	IndexInBlock int64
+}
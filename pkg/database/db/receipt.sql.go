@@ -33,3 +33,153 @@ func (q *Queries) GetReceipt(ctx context.Context, arg GetReceiptParams) (SystemT
 	)
 	return i, err
 }
+
+const listReceiptsAfter = `-- name: ListReceiptsAfter :many
+SELECT chain_id, block_number, index_in_block, txn_hash, error, table_id, error_event_idx, table_ids FROM system_txn_receipts WHERE chain_id = ?1 AND (block_number > ?2 OR (block_number = ?2 AND index_in_block > ?3)) ORDER BY block_number, index_in_block LIMIT ?4
+`
+
+type ListReceiptsAfterParams struct {
+	ChainID      int64
+	BlockNumber  int64
+	IndexInBlock int64
+	Limit        int64
+}
+
+func (q *Queries) ListReceiptsAfter(ctx context.Context, arg ListReceiptsAfterParams) ([]SystemTxnReceipt, error) {
+	rows, err := q.query(ctx, q.listReceiptsAfterStmt, listReceiptsAfter,
+		arg.ChainID,
+		arg.BlockNumber,
+		arg.IndexInBlock,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SystemTxnReceipt
+	for rows.Next() {
+		var i SystemTxnReceipt
+		if err := rows.Scan(
+			&i.ChainID,
+			&i.BlockNumber,
+			&i.IndexInBlock,
+			&i.TxnHash,
+			&i.Error,
+			&i.TableID,
+			&i.ErrorEventIdx,
+			&i.TableIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReceiptsInRange = `-- name: ListReceiptsInRange :many
+SELECT chain_id, block_number, index_in_block, txn_hash, error, table_id, error_event_idx, table_ids FROM system_txn_receipts WHERE chain_id = ?1 AND (block_number > ?2 OR (block_number = ?2 AND index_in_block > ?3)) AND block_number <= ?4 ORDER BY block_number, index_in_block LIMIT ?5
+`
+
+type ListReceiptsInRangeParams struct {
+	ChainID       int64
+	BlockNumber   int64
+	IndexInBlock  int64
+	BlockNumber_2 int64
+	Limit         int64
+}
+
+func (q *Queries) ListReceiptsInRange(ctx context.Context, arg ListReceiptsInRangeParams) ([]SystemTxnReceipt, error) {
+	rows, err := q.query(ctx, q.listReceiptsInRangeStmt, listReceiptsInRange,
+		arg.ChainID,
+		arg.BlockNumber,
+		arg.IndexInBlock,
+		arg.BlockNumber_2,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SystemTxnReceipt
+	for rows.Next() {
+		var i SystemTxnReceipt
+		if err := rows.Scan(
+			&i.ChainID,
+			&i.BlockNumber,
+			&i.IndexInBlock,
+			&i.TxnHash,
+			&i.Error,
+			&i.TableID,
+			&i.ErrorEventIdx,
+			&i.TableIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTableReceiptsInRange = `-- name: ListTableReceiptsInRange :many
+SELECT r.chain_id, r.block_number, r.index_in_block, r.txn_hash, r.error, r.table_id, r.error_event_idx, r.table_ids FROM system_txn_receipt_tables t JOIN system_txn_receipts r ON r.chain_id = t.chain_id AND r.block_number = t.block_number AND r.index_in_block = t.index_in_block WHERE t.chain_id = ?1 AND t.table_id = ?2 AND (t.block_number > ?3 OR (t.block_number = ?3 AND t.index_in_block > ?4)) AND t.block_number <= ?5 ORDER BY t.block_number, t.index_in_block LIMIT ?6
+`
+
+type ListTableReceiptsInRangeParams struct {
+	ChainID       int64
+	TableID       int64
+	BlockNumber   int64
+	IndexInBlock  int64
+	BlockNumber_2 int64
+	Limit         int64
+}
+
+func (q *Queries) ListTableReceiptsInRange(ctx context.Context, arg ListTableReceiptsInRangeParams) ([]SystemTxnReceipt, error) {
+	rows, err := q.query(ctx, q.listTableReceiptsInRangeStmt, listTableReceiptsInRange,
+		arg.ChainID,
+		arg.TableID,
+		arg.BlockNumber,
+		arg.IndexInBlock,
+		arg.BlockNumber_2,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SystemTxnReceipt
+	for rows.Next() {
+		var i SystemTxnReceipt
+		if err := rows.Scan(
+			&i.ChainID,
+			&i.BlockNumber,
+			&i.IndexInBlock,
+			&i.TxnHash,
+			&i.Error,
+			&i.TableID,
+			&i.ErrorEventIdx,
+			&i.TableIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
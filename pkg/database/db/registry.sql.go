@@ -31,3 +31,49 @@ func (q *Queries) GetTable(ctx context.Context, arg GetTableParams) (Registry, e
 	)
 	return i, err
 }
+
+const listTablesByController = `-- name: ListTablesByController :many
+SELECT id, structure, controller, prefix, created_at, chain_id FROM registry WHERE chain_id = ?1 AND controller = ?2 AND id > ?3 ORDER BY id LIMIT ?4
+`
+
+type ListTablesByControllerParams struct {
+	ChainID    int64
+	Controller string
+	ID         int64
+	Limit      int64
+}
+
+func (q *Queries) ListTablesByController(ctx context.Context, arg ListTablesByControllerParams) ([]Registry, error) {
+	rows, err := q.query(ctx, q.listTablesByControllerStmt, listTablesByController,
+		arg.ChainID,
+		arg.Controller,
+		arg.ID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Registry
+	for rows.Next() {
+		var i Registry
+		if err := rows.Scan(
+			&i.ID,
+			&i.Structure,
+			&i.Controller,
+			&i.Prefix,
+			&i.CreatedAt,
+			&i.ChainID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,2 @@
+DROP TABLE system_row_changes_retention;
+DROP TABLE system_row_changes;
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS system_row_changes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chain_id INTEGER NOT NULL,
+    table_id INTEGER NOT NULL,
+    block_number INTEGER NOT NULL,
+    columns TEXT NOT NULL,
+    before_row_id INTEGER,
+    before TEXT,
+    after_row_id INTEGER,
+    after TEXT
+);
+CREATE INDEX system_row_changes_chain_id_table_id_block_number on system_row_changes(chain_id, table_id, block_number);
+
+CREATE TABLE IF NOT EXISTS system_row_changes_retention (
+    chain_id INTEGER NOT NULL,
+    since_block INTEGER NOT NULL,
+
+    PRIMARY KEY(chain_id)
+);
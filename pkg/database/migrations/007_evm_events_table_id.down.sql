@@ -0,0 +1,3 @@
+DROP INDEX system_evm_events_chain_id_table_id;
+
+ALTER TABLE system_evm_events DROP COLUMN table_id;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE system_evm_events ADD table_id INTEGER;
+
+UPDATE system_evm_events SET table_id=json_extract(event_json, '$.TableId') WHERE json_valid(event_json);
+
+CREATE INDEX system_evm_events_chain_id_table_id on system_evm_events(chain_id, table_id, block_number, event_index);
\ No newline at end of file
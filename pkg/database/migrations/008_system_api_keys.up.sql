@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS system_api_keys (
+    api_key TEXT NOT NULL,
+    max_rpi INTEGER NOT NULL,
+    interval_ms INTEGER NOT NULL,
+
+    PRIMARY KEY(api_key)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS system_relay_writes (
+    chain_id INTEGER NOT NULL,
+    signer TEXT NOT NULL,
+    nonce TEXT NOT NULL,
+    txn_hash TEXT,
+    created_at INTEGER NOT NULL,
+
+    PRIMARY KEY(chain_id, signer, nonce)
+);
+CREATE INDEX system_relay_writes_signer_created_at on system_relay_writes(signer, created_at);
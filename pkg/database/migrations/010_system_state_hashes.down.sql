@@ -0,0 +1 @@
+DROP TABLE system_state_hashes;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS system_state_hashes (
+    chain_id INTEGER NOT NULL,
+    block_number INTEGER NOT NULL,
+    hash TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+
+    PRIMARY KEY(chain_id, block_number)
+);
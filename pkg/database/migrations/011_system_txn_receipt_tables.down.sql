@@ -0,0 +1 @@
+DROP TABLE system_txn_receipt_tables;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS system_txn_receipt_tables (
+    chain_id INTEGER NOT NULL,
+    table_id INTEGER NOT NULL,
+    block_number INTEGER NOT NULL,
+    index_in_block INTEGER NOT NULL,
+
+    PRIMARY KEY(chain_id, table_id, block_number, index_in_block)
+);
+
+INSERT OR IGNORE INTO system_txn_receipt_tables
+    SELECT chain_id, table_id, block_number, index_in_block FROM system_txn_receipts WHERE table_id IS NOT NULL;
+
+INSERT OR IGNORE INTO system_txn_receipt_tables
+    SELECT r.chain_id, cast(j.value as integer), r.block_number, r.index_in_block
+    FROM system_txn_receipts r, json_each('[' || r.table_ids || ']') j
+    WHERE r.table_ids IS NOT NULL AND r.table_ids != '';
@@ -0,0 +1,3 @@
+DROP INDEX system_relay_writes_chain_id_created_at;
+
+ALTER TABLE system_relay_writes DROP COLUMN gas;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE system_relay_writes ADD gas INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX system_relay_writes_chain_id_created_at on system_relay_writes(chain_id, created_at);
\ No newline at end of file
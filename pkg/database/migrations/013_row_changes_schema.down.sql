@@ -0,0 +1,3 @@
+DROP INDEX system_row_changes_chain_id_block_number;
+
+ALTER TABLE system_row_changes DROP COLUMN schema;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE system_row_changes ADD schema TEXT;
+
+CREATE INDEX system_row_changes_chain_id_block_number on system_row_changes(chain_id, block_number);
\ No newline at end of file
@@ -11,6 +11,22 @@
 // migrations/004_system_id.up.sql
 // migrations/005_receipttableids.down.sql
 // migrations/005_receipttableids.up.sql
+// migrations/006_row_changes.down.sql
+// migrations/006_row_changes.up.sql
+// migrations/007_evm_events_table_id.down.sql
+// migrations/007_evm_events_table_id.up.sql
+// migrations/008_system_api_keys.down.sql
+// migrations/008_system_api_keys.up.sql
+// migrations/009_system_relay_writes.down.sql
+// migrations/009_system_relay_writes.up.sql
+// migrations/010_system_state_hashes.down.sql
+// migrations/010_system_state_hashes.up.sql
+// migrations/011_system_txn_receipt_tables.down.sql
+// migrations/011_system_txn_receipt_tables.up.sql
+// migrations/012_system_relay_writes_gas.down.sql
+// migrations/012_system_relay_writes_gas.up.sql
+// migrations/013_row_changes_schema.down.sql
+// migrations/013_row_changes_schema.up.sql
 package migrations
 
 import (
@@ -287,6 +303,326 @@ func _005_receipttableidsUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __006_row_changesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x47\x00\xb8\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x73\x79\x73\x74\x65\x6d\x5f\x72\x6f\x77\x5f\x63\x68\x61\x6e\x67\x65\x73\x5f\x72\x65\x74\x65\x6e\x74\x69\x6f\x6e\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x73\x79\x73\x74\x65\x6d\x5f\x72\x6f\x77\x5f\x63\x68\x61\x6e\x67\x65\x73\x3b\x03\x00\x15\xfa\x9b\x0e\x47\x00\x00\x00")
+
+func _006_row_changesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__006_row_changesDownSql,
+		"006_row_changes.down.sql",
+	)
+}
+
+func _006_row_changesDownSql() (*asset, error) {
+	bytes, err := _006_row_changesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "006_row_changes.down.sql", size: 71, mode: os.FileMode(420), modTime: time.Unix(1792155769, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __006_row_changesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\xc1\x6e\xf2\x30\x10\x84\xef\x7e\x8a\x3d\x12\x29\x6f\x90\x53\x7e\xfe\x6d\x65\x35\x98\x2a\x18\x29\x9c\xac\xc4\x5d\x4a\xd4\xc4\x96\x6c\xa3\xaa\x6f\x5f\xd5\xc1\x34\xa0\x70\xe8\xd5\xf3\x8d\x77\x67\x76\x5d\x63\x29\x11\x64\xf9\xaf\x42\xe0\x4f\x20\xb6\x12\xb0\xe1\x3b\xb9\x03\xff\xe5\x03\x8d\xca\xd9\x4f\xa5\x4f\xad\x79\x27\x0f\x2b\x06\x00\xd0\xbf\x01\x17\x12\x9f\xb1\x86\xd7\x9a\x6f\xca\xfa\x00\x2f\x78\x80\x72\x2f\xb7\x5c\xac\x6b\xdc\xa0\x90\x79\x24\xf5\xa9\xed\x8d\x9a\xf1\x3f\xdf\x8b\x7d\x55\x4d\x72\x68\xbb\x81\x1e\xcb\xdd\x60\xf5\x87\x32\xe7\xb1\x23\xf7\x00\xd1\x76\x38\x8f\xc6\x83\xc4\x46\xde\xbb\xe9\x68\x1d\xc5\xf5\x7f\x27\xcc\xa5\x68\x9a\x1e\xda\x63\x20\xb7\x88\x46\x25\x92\x2c\x2b\xd8\xa5\x2d\x2e\xfe\x63\xb3\xd0\x8f\x4a\x79\x55\x4a\xa6\x6e\x32\x58\xb3\x60\x5a\x25\x53\x7e\xed\x23\xbf\x89\x9e\x15\x8c\xfd\xe9\x4c\xca\x51\x20\x13\x7a\x6b\x2e\x07\x4b\x13\x52\xb4\xbb\xa6\x7c\x6f\x34\x4d\x9b\x2e\x10\x11\x99\xdd\xf9\xba\x6f\xc6\xb2\x82\x7d\x0f\x00\xcf\xf9\xb5\x50\x40\x02\x00\x00")
+
+func _006_row_changesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__006_row_changesUpSql,
+		"006_row_changes.up.sql",
+	)
+}
+
+func _006_row_changesUpSql() (*asset, error) {
+	bytes, err := _006_row_changesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "006_row_changes.up.sql", size: 576, mode: os.FileMode(420), modTime: time.Unix(1792155769, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __007_evm_events_table_idDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x64\x00\x9b\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x73\x79\x73\x74\x65\x6d\x5f\x65\x76\x6d\x5f\x65\x76\x65\x6e\x74\x73\x5f\x63\x68\x61\x69\x6e\x5f\x69\x64\x5f\x74\x61\x62\x6c\x65\x5f\x69\x64\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x73\x79\x73\x74\x65\x6d\x5f\x65\x76\x6d\x5f\x65\x76\x65\x6e\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x74\x61\x62\x6c\x65\x5f\x69\x64\x3b\x03\x00\xd0\x54\x63\xd6\x64\x00\x00\x00")
+
+func _007_evm_events_table_idDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__007_evm_events_table_idDownSql,
+		"007_evm_events_table_id.down.sql",
+	)
+}
+
+func _007_evm_events_table_idDownSql() (*asset, error) {
+	bytes, err := _007_evm_events_table_idDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "007_evm_events_table_id.down.sql", size: 100, mode: os.FileMode(420), modTime: time.Unix(1792158392, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __007_evm_events_table_idUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xce\x41\x4a\xc5\x30\x10\x06\xe0\x7d\x4e\x31\x0b\xa1\x2d\x04\x2f\x50\x5c\x44\x33\x68\xa1\x14\x89\x11\xdd\x0d\x69\x13\x30\xda\xa6\xd0\xc4\x52\x6f\x2f\x29\xbc\xf2\xa0\x6f\x31\x9b\x99\x8f\xf9\x7f\xd1\x6a\x54\xa0\xc5\x63\x8b\x10\xff\x62\x72\x13\xb9\x35\x8f\x0b\x29\x82\x90\x12\x92\xe9\x47\x47\xde\x42\xd3\x69\x7c\x46\x55\x33\xf6\xfe\x2a\x85\xbe\xe5\xdf\x50\x1f\xfe\xe1\x3b\xce\x81\xdc\x96\x16\x33\xa4\x72\x7f\x48\x79\xc5\xa1\xb8\xbb\xd7\x19\x35\xb6\xa8\xe0\xe3\x05\x15\x42\x3e\xd0\x6a\x46\x6f\xaf\x64\x55\x33\xf6\xa4\x30\x47\x35\x9d\xc4\xcf\x73\x20\x0d\x5f\xc6\x07\xf2\x96\x8e\x96\x73\x38\xb3\xf2\xc2\xf8\xd1\x8e\x43\x3f\xce\xc3\x0f\x85\xdf\xa9\x77\x0b\x87\x1d\x92\x0f\xd6\x6d\x55\xfd\x3f\x00\x71\xe8\x78\xf6\x15\x01\x00\x00")
+
+func _007_evm_events_table_idUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__007_evm_events_table_idUpSql,
+		"007_evm_events_table_id.up.sql",
+	)
+}
+
+func _007_evm_events_table_idUpSql() (*asset, error) {
+	bytes, err := _007_evm_events_table_idUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "007_evm_events_table_id.up.sql", size: 277, mode: os.FileMode(420), modTime: time.Unix(1792158392, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __008_system_api_keysDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x1c\x00\xe3\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x73\x79\x73\x74\x65\x6d\x5f\x61\x70\x69\x5f\x6b\x65\x79\x73\x3b\x0a\x03\x00\x4a\xcf\x69\x7b\x1c\x00\x00\x00")
+
+func _008_system_api_keysDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__008_system_api_keysDownSql,
+		"008_system_api_keys.down.sql",
+	)
+}
+
+func _008_system_api_keysDownSql() (*asset, error) {
+	bytes, err := _008_system_api_keysDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "008_system_api_keys.down.sql", size: 28, mode: os.FileMode(420), modTime: time.Unix(1792159211, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __008_system_api_keysUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x0e\x72\x75\x0c\x71\x55\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\xf0\xf3\x0f\x51\x70\x8d\xf0\x0c\x0e\x09\x56\x28\xae\x2c\x2e\x49\xcd\x8d\x4f\x2c\xc8\x8c\xcf\x4e\xad\x2c\x56\xd0\xe0\x52\x50\x50\x50\x80\x72\x15\x42\x5c\x23\x42\xc0\xaa\xfd\x42\x7d\x7c\x74\xc0\x52\xb9\x89\x15\xf1\x45\x05\x99\x0a\x9e\x7e\x21\xae\xee\xae\x41\x68\xb2\x99\x79\x25\xa9\x45\x65\x89\x39\xf1\xb9\xc5\x58\x54\x80\x95\x04\x04\x79\xfa\x3a\x06\x45\x2a\x78\xbb\x46\x6a\x40\xed\xd1\xe4\xd2\xb4\xe6\x02\x0c\x00\x19\x01\x96\x5b\xa5\x00\x00\x00")
+
+func _008_system_api_keysUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__008_system_api_keysUpSql,
+		"008_system_api_keys.up.sql",
+	)
+}
+
+func _008_system_api_keysUpSql() (*asset, error) {
+	bytes, err := _008_system_api_keysUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "008_system_api_keys.up.sql", size: 165, mode: os.FileMode(420), modTime: time.Unix(1792159211, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __009_system_relay_writesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x20\x00\xdf\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x73\x79\x73\x74\x65\x6d\x5f\x72\x65\x6c\x61\x79\x5f\x77\x72\x69\x74\x65\x73\x3b\x0a\x03\x00\x45\xf7\x87\xc7\x20\x00\x00\x00")
+
+func _009_system_relay_writesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__009_system_relay_writesDownSql,
+		"009_system_relay_writes.down.sql",
+	)
+}
+
+func _009_system_relay_writesDownSql() (*asset, error) {
+	bytes, err := _009_system_relay_writesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "009_system_relay_writes.down.sql", size: 32, mode: os.FileMode(420), modTime: time.Unix(1792163700, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __009_system_relay_writesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x8f\x41\xaa\xc2\x30\x14\x45\xe7\x59\xc5\x1b\xb6\xd0\x1d\x74\xd4\xff\x7d\x4a\xb0\x46\x49\x23\xb4\xa3\x10\xda\x60\x03\x9a\x42\x12\xd0\xee\x5e\x48\x5b\x14\xc9\x34\xe7\xde\x9c\x77\xff\x39\x56\x02\x41\x54\x7f\x35\x02\xdd\x03\x3b\x0b\xc0\x96\x36\xa2\x01\x3f\xfb\xa0\x1f\xd2\xe9\xbb\x9a\xe5\xd3\x99\xa0\x3d\x64\x04\x00\xa0\x1f\x95\xb1\xd2\x0c\x40\x99\xc0\x03\xf2\xd8\x62\xd7\xba\x2e\x22\xf6\xe6\x66\xb5\x03\x81\xad\xf8\x21\x76\xb2\xbd\x4e\x81\xf0\xb2\x72\x54\x7e\x8c\x6c\x79\xea\x9d\x56\x41\x0f\x52\x85\x84\x26\x26\x2e\x9c\x9e\x2a\xde\xc1\x11\xbb\x6c\x3b\xa9\x58\xed\xc5\xe2\xca\x49\x5e\x92\x75\x23\x65\x3b\x6c\x53\xab\xe4\x52\x91\x5f\xc6\xc9\xa6\x82\xd9\xf6\xf7\x27\x99\x97\xe4\x3d\x00\xcf\x28\x2e\xce\x43\x01\x00\x00")
+
+func _009_system_relay_writesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__009_system_relay_writesUpSql,
+		"009_system_relay_writes.up.sql",
+	)
+}
+
+func _009_system_relay_writesUpSql() (*asset, error) {
+	bytes, err := _009_system_relay_writesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "009_system_relay_writes.up.sql", size: 323, mode: os.FileMode(420), modTime: time.Unix(1792163700, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __010_system_state_hashesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x1f\x00\xe0\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x73\x79\x73\x74\x65\x6d\x5f\x73\x74\x61\x74\x65\x5f\x68\x61\x73\x68\x65\x73\x3b\x03\x00\x99\xb4\xb2\x71\x1f\x00\x00\x00")
+
+func _010_system_state_hashesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__010_system_state_hashesDownSql,
+		"010_system_state_hashes.down.sql",
+	)
+}
+
+func _010_system_state_hashesDownSql() (*asset, error) {
+	bytes, err := _010_system_state_hashesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "010_system_state_hashes.down.sql", size: 31, mode: os.FileMode(420), modTime: time.Unix(1792165429, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __010_system_state_hashesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x8e\x41\x0b\x82\x40\x10\x85\xef\xfe\x8a\x77\x54\xf0\x1f\x74\xb2\x98\x62\xc9\x2c\xd6\x09\xf4\xb4\xac\x3a\xb0\x52\x1a\xb8\xdb\xa1\x7f\x1f\x2d\x74\x28\xea\x3a\xf3\x7d\xef\xbd\x8d\xa6\x82\x09\x5c\xac\x4b\x82\xda\xa2\x3a\x32\xa8\x51\x35\xd7\xf0\x0f\x1f\x64\x32\x3e\xd8\x20\xc6\x59\xef\xc4\x23\x4d\x00\xa0\x77\x76\x9c\xcd\x38\x40\x55\x4c\x3b\xd2\xd1\xaa\xce\x65\x99\xc7\x77\x77\xbd\xf5\x17\x33\xdf\xa7\x4e\x96\x3f\xc8\x2b\x0e\x4c\x0d\x7f\xdd\xfb\x45\x6c\x90\xc1\xd8\xf0\x43\x8c\xc4\x49\xab\x43\xa1\x5b\xec\xa9\x4d\xdf\x3b\xf2\x8f\xca\x2c\xc9\x56\xc9\x73\x00\x2c\x06\xc2\xbd\xd8\x00\x00\x00")
+
+func _010_system_state_hashesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__010_system_state_hashesUpSql,
+		"010_system_state_hashes.up.sql",
+	)
+}
+
+func _010_system_state_hashesUpSql() (*asset, error) {
+	bytes, err := _010_system_state_hashesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "010_system_state_hashes.up.sql", size: 216, mode: os.FileMode(420), modTime: time.Unix(1792165429, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __011_system_txn_receipt_tablesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x25\x00\xda\xff\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x73\x79\x73\x74\x65\x6d\x5f\x74\x78\x6e\x5f\x72\x65\x63\x65\x69\x70\x74\x5f\x74\x61\x62\x6c\x65\x73\x3b\x03\x00\x23\xd4\xe6\x66\x25\x00\x00\x00")
+
+func _011_system_txn_receipt_tablesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__011_system_txn_receipt_tablesDownSql,
+		"011_system_txn_receipt_tables.down.sql",
+	)
+}
+
+func _011_system_txn_receipt_tablesDownSql() (*asset, error) {
+	bytes, err := _011_system_txn_receipt_tablesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "011_system_txn_receipt_tables.down.sql", size: 37, mode: os.FileMode(420), modTime: time.Unix(1792167235, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __011_system_txn_receipt_tablesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x8f\xd1\x4e\xf2\x40\x14\x84\xef\xfb\x14\xf3\x5f\xb5\x4d\x9a\xbe\x00\xf9\x2f\x2a\x1e\x70\x63\x69\xcd\x76\x8d\x12\x63\x36\xa5\x6c\xa4\x08\x8b\xd9\x5d\x0c\x26\x3c\xbc\x61\x0d\x48\x0d\xbd\x30\x5e\x9e\xcc\x9c\x99\x6f\x86\x9c\x32\x41\x10\xd9\x55\x4e\x60\x23\x14\xa5\x00\x3d\xb2\x4a\x54\xb0\x1f\xd6\xa9\xb5\x74\x3b\x2d\x8d\x6a\x54\xfb\xe6\xa4\xab\x67\x2b\x65\x11\x05\x00\xd0\x2c\xea\x56\xcb\x76\x0e\x56\x08\x1a\x13\xf7\xbf\xc5\x7d\x9e\x27\x5e\xf6\xde\x7e\x79\xb6\xda\x34\xaf\x52\x6f\xd7\x33\x65\x7a\x2c\xad\x9e\xab\x9d\x6c\xb5\xf4\xde\x0b\x26\xef\xba\xe3\x6c\x92\xf1\x29\x6e\x69\x1a\x1d\x91\x92\x53\x7b\xd2\x29\x4a\x7e\x64\xc6\x41\x3c\x08\x02\x56\x54\xc4\x05\x4a\x0e\x36\x2e\x4a\x4e\x87\xa6\xb2\x7f\xbe\x6f\xad\x28\xa7\xa1\xc0\x6f\x0b\x31\xe2\xe5\xe4\x42\xb4\xc5\xc3\x0d\x71\x3a\xa5\x80\x55\xa7\x9d\x7f\x23\x34\xe9\x37\x63\x53\x5b\x17\x2d\xd3\xf7\x7a\xb5\x55\xa8\x2d\x5a\xed\xd4\x8b\x32\x71\x02\x93\x76\xa9\x4d\xda\xe5\xf6\x89\xbd\xec\x26\xc1\xd2\x6e\xb4\x54\x75\xb3\x88\xc2\xa7\x10\xfb\x3d\x4c\x7a\xdc\x62\x0f\x67\xf8\x1c\xc6\x58\xfa\x98\xaf\xa5\xe7\xfa\xd9\x58\x64\xc5\x75\x47\xfb\xf7\x1f\x61\x38\x08\x3e\x07\x00\xe1\xce\x5e\xc3\xa8\x02\x00\x00")
+
+func _011_system_txn_receipt_tablesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__011_system_txn_receipt_tablesUpSql,
+		"011_system_txn_receipt_tables.up.sql",
+	)
+}
+
+func _011_system_txn_receipt_tablesUpSql() (*asset, error) {
+	bytes, err := _011_system_txn_receipt_tablesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "011_system_txn_receipt_tables.up.sql", size: 680, mode: os.FileMode(420), modTime: time.Unix(1792167235, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __012_system_relay_writes_gasDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x65\x00\x9a\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x73\x79\x73\x74\x65\x6d\x5f\x72\x65\x6c\x61\x79\x5f\x77\x72\x69\x74\x65\x73\x5f\x63\x68\x61\x69\x6e\x5f\x69\x64\x5f\x63\x72\x65\x61\x74\x65\x64\x5f\x61\x74\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x73\x79\x73\x74\x65\x6d\x5f\x72\x65\x6c\x61\x79\x5f\x77\x72\x69\x74\x65\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x67\x61\x73\x3b\x03\x00\xb7\xaf\x28\xac\x65\x00\x00\x00")
+
+func _012_system_relay_writes_gasDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__012_system_relay_writes_gasDownSql,
+		"012_system_relay_writes_gas.down.sql",
+	)
+}
+
+func _012_system_relay_writes_gasDownSql() (*asset, error) {
+	bytes, err := _012_system_relay_writes_gasDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "012_system_relay_writes_gas.down.sql", size: 101, mode: os.FileMode(420), modTime: time.Unix(1792169464, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __012_system_relay_writes_gasUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xcc\x31\xcb\xc2\x30\x10\x06\xe0\xbd\xbf\xe2\x1d\xbf\x0f\x1c\xdc\x3b\x45\x73\x4a\x21\x44\x08\x57\x70\x3b\x8e\xf6\xd0\x80\x56\x48\x02\xd2\x7f\xef\x24\x2e\xdd\x1f\x1e\x17\x98\x12\xd8\x1d\x02\xa1\xae\xb5\xd9\x53\x8a\x3d\x74\x95\x77\xc9\xcd\x2a\x9c\xf7\xb8\x69\xc5\x10\x99\xce\x94\x10\x2f\x8c\x38\x86\x00\x4f\x27\x37\x06\xc6\xbe\xef\xba\x63\x22\xc7\x84\x21\x7a\xba\x6e\x2d\x32\xdd\x35\x2f\x92\x67\x99\x8a\x69\xb3\x59\xb4\xe1\xb5\x6c\xd1\xbf\x2f\xdd\xe1\x67\xff\xfb\xcf\x00\x5a\x18\x35\x9b\xa7\x00\x00\x00")
+
+func _012_system_relay_writes_gasUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__012_system_relay_writes_gasUpSql,
+		"012_system_relay_writes_gas.up.sql",
+	)
+}
+
+func _012_system_relay_writes_gasUpSql() (*asset, error) {
+	bytes, err := _012_system_relay_writes_gasUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "012_system_relay_writes_gas.up.sql", size: 167, mode: os.FileMode(420), modTime: time.Unix(1792169464, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __013_row_changes_schemaDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x68\x00\x97\xff\x44\x52\x4f\x50\x20\x49\x4e\x44\x45\x58\x20\x73\x79\x73\x74\x65\x6d\x5f\x72\x6f\x77\x5f\x63\x68\x61\x6e\x67\x65\x73\x5f\x63\x68\x61\x69\x6e\x5f\x69\x64\x5f\x62\x6c\x6f\x63\x6b\x5f\x6e\x75\x6d\x62\x65\x72\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x73\x79\x73\x74\x65\x6d\x5f\x72\x6f\x77\x5f\x63\x68\x61\x6e\x67\x65\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x73\x63\x68\x65\x6d\x61\x3b\x03\x00\xa6\x5c\xc0\x67\x68\x00\x00\x00")
+
+func _013_row_changes_schemaDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__013_row_changes_schemaDownSql,
+		"013_row_changes_schema.down.sql",
+	)
+}
+
+func _013_row_changes_schemaDownSql() (*asset, error) {
+	bytes, err := _013_row_changes_schemaDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "013_row_changes_schema.down.sql", size: 104, mode: os.FileMode(420), modTime: time.Unix(1792171658, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __013_row_changes_schemaUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xae\x2c\x2e\x49\xcd\x8d\x2f\xca\x2f\x8f\x4f\xce\x48\xcc\x4b\x4f\x2d\x56\x70\x74\x71\x51\x28\x4e\xce\x48\xcd\x4d\x54\x08\x71\x8d\x08\xb1\xe6\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\xc0\xa2\x03\xa4\x33\x33\x2f\x3e\x33\x25\x3e\x29\x27\x3f\x39\x3b\x3e\xaf\x34\x37\x29\xb5\x48\x21\x3f\x0f\x8b\x5a\x0d\x98\x5a\x1d\x05\x64\xc5\x9a\xd6\x80\x01\x00\x08\xc3\xbb\x49\x95\x00\x00\x00")
+
+func _013_row_changes_schemaUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__013_row_changes_schemaUpSql,
+		"013_row_changes_schema.up.sql",
+	)
+}
+
+func _013_row_changes_schemaUpSql() (*asset, error) {
+	bytes, err := _013_row_changes_schemaUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "013_row_changes_schema.up.sql", size: 149, mode: os.FileMode(420), modTime: time.Unix(1792171658, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -339,16 +675,32 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"001_init.down.sql":            _001_initDownSql,
-	"001_init.up.sql":              _001_initUpSql,
-	"002_receipterroridx.down.sql": _002_receipterroridxDownSql,
-	"002_receipterroridx.up.sql":   _002_receipterroridxUpSql,
-	"003_evm_events.down.sql":      _003_evm_eventsDownSql,
-	"003_evm_events.up.sql":        _003_evm_eventsUpSql,
-	"004_system_id.down.sql":       _004_system_idDownSql,
-	"004_system_id.up.sql":         _004_system_idUpSql,
-	"005_receipttableids.down.sql": _005_receipttableidsDownSql,
-	"005_receipttableids.up.sql":   _005_receipttableidsUpSql,
+	"001_init.down.sql":                      _001_initDownSql,
+	"001_init.up.sql":                        _001_initUpSql,
+	"002_receipterroridx.down.sql":           _002_receipterroridxDownSql,
+	"002_receipterroridx.up.sql":             _002_receipterroridxUpSql,
+	"003_evm_events.down.sql":                _003_evm_eventsDownSql,
+	"003_evm_events.up.sql":                  _003_evm_eventsUpSql,
+	"004_system_id.down.sql":                 _004_system_idDownSql,
+	"004_system_id.up.sql":                   _004_system_idUpSql,
+	"005_receipttableids.down.sql":           _005_receipttableidsDownSql,
+	"005_receipttableids.up.sql":             _005_receipttableidsUpSql,
+	"006_row_changes.down.sql":               _006_row_changesDownSql,
+	"006_row_changes.up.sql":                 _006_row_changesUpSql,
+	"007_evm_events_table_id.down.sql":       _007_evm_events_table_idDownSql,
+	"007_evm_events_table_id.up.sql":         _007_evm_events_table_idUpSql,
+	"008_system_api_keys.down.sql":           _008_system_api_keysDownSql,
+	"008_system_api_keys.up.sql":             _008_system_api_keysUpSql,
+	"009_system_relay_writes.down.sql":       _009_system_relay_writesDownSql,
+	"009_system_relay_writes.up.sql":         _009_system_relay_writesUpSql,
+	"010_system_state_hashes.down.sql":       _010_system_state_hashesDownSql,
+	"010_system_state_hashes.up.sql":         _010_system_state_hashesUpSql,
+	"011_system_txn_receipt_tables.down.sql": _011_system_txn_receipt_tablesDownSql,
+	"011_system_txn_receipt_tables.up.sql":   _011_system_txn_receipt_tablesUpSql,
+	"012_system_relay_writes_gas.down.sql":   _012_system_relay_writes_gasDownSql,
+	"012_system_relay_writes_gas.up.sql":     _012_system_relay_writes_gasUpSql,
+	"013_row_changes_schema.down.sql":        _013_row_changes_schemaDownSql,
+	"013_row_changes_schema.up.sql":          _013_row_changes_schemaUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -392,16 +744,32 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"001_init.down.sql":            &bintree{_001_initDownSql, map[string]*bintree{}},
-	"001_init.up.sql":              &bintree{_001_initUpSql, map[string]*bintree{}},
-	"002_receipterroridx.down.sql": &bintree{_002_receipterroridxDownSql, map[string]*bintree{}},
-	"002_receipterroridx.up.sql":   &bintree{_002_receipterroridxUpSql, map[string]*bintree{}},
-	"003_evm_events.down.sql":      &bintree{_003_evm_eventsDownSql, map[string]*bintree{}},
-	"003_evm_events.up.sql":        &bintree{_003_evm_eventsUpSql, map[string]*bintree{}},
-	"004_system_id.down.sql":       &bintree{_004_system_idDownSql, map[string]*bintree{}},
-	"004_system_id.up.sql":         &bintree{_004_system_idUpSql, map[string]*bintree{}},
-	"005_receipttableids.down.sql": &bintree{_005_receipttableidsDownSql, map[string]*bintree{}},
-	"005_receipttableids.up.sql":   &bintree{_005_receipttableidsUpSql, map[string]*bintree{}},
+	"001_init.down.sql":                      &bintree{_001_initDownSql, map[string]*bintree{}},
+	"001_init.up.sql":                        &bintree{_001_initUpSql, map[string]*bintree{}},
+	"002_receipterroridx.down.sql":           &bintree{_002_receipterroridxDownSql, map[string]*bintree{}},
+	"002_receipterroridx.up.sql":             &bintree{_002_receipterroridxUpSql, map[string]*bintree{}},
+	"003_evm_events.down.sql":                &bintree{_003_evm_eventsDownSql, map[string]*bintree{}},
+	"003_evm_events.up.sql":                  &bintree{_003_evm_eventsUpSql, map[string]*bintree{}},
+	"004_system_id.down.sql":                 &bintree{_004_system_idDownSql, map[string]*bintree{}},
+	"004_system_id.up.sql":                   &bintree{_004_system_idUpSql, map[string]*bintree{}},
+	"005_receipttableids.down.sql":           &bintree{_005_receipttableidsDownSql, map[string]*bintree{}},
+	"005_receipttableids.up.sql":             &bintree{_005_receipttableidsUpSql, map[string]*bintree{}},
+	"006_row_changes.down.sql":               &bintree{_006_row_changesDownSql, map[string]*bintree{}},
+	"006_row_changes.up.sql":                 &bintree{_006_row_changesUpSql, map[string]*bintree{}},
+	"007_evm_events_table_id.down.sql":       &bintree{_007_evm_events_table_idDownSql, map[string]*bintree{}},
+	"007_evm_events_table_id.up.sql":         &bintree{_007_evm_events_table_idUpSql, map[string]*bintree{}},
+	"008_system_api_keys.down.sql":           &bintree{_008_system_api_keysDownSql, map[string]*bintree{}},
+	"008_system_api_keys.up.sql":             &bintree{_008_system_api_keysUpSql, map[string]*bintree{}},
+	"009_system_relay_writes.down.sql":       &bintree{_009_system_relay_writesDownSql, map[string]*bintree{}},
+	"009_system_relay_writes.up.sql":         &bintree{_009_system_relay_writesUpSql, map[string]*bintree{}},
+	"010_system_state_hashes.down.sql":       &bintree{_010_system_state_hashesDownSql, map[string]*bintree{}},
+	"010_system_state_hashes.up.sql":         &bintree{_010_system_state_hashesUpSql, map[string]*bintree{}},
+	"011_system_txn_receipt_tables.down.sql": &bintree{_011_system_txn_receipt_tablesDownSql, map[string]*bintree{}},
+	"011_system_txn_receipt_tables.up.sql":   &bintree{_011_system_txn_receipt_tablesUpSql, map[string]*bintree{}},
+	"012_system_relay_writes_gas.down.sql":   &bintree{_012_system_relay_writes_gasDownSql, map[string]*bintree{}},
+	"012_system_relay_writes_gas.up.sql":     &bintree{_012_system_relay_writes_gasUpSql, map[string]*bintree{}},
+	"013_row_changes_schema.down.sql":        &bintree{_013_row_changes_schemaDownSql, map[string]*bintree{}},
+	"013_row_changes_schema.up.sql":          &bintree{_013_row_changes_schemaUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
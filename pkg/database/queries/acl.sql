@@ -1,2 +1,8 @@
 -- name: GetAclByTableAndController :one
-SELECT * FROM system_acl WHERE chain_id = ?1 AND table_id = ?2 AND upper(controller) LIKE upper(?3);
\ No newline at end of file
+SELECT * FROM system_acl WHERE chain_id = ?1 AND table_id = ?2 AND upper(controller) LIKE upper(?3);
+
+-- name: ListAclByTable :many
+SELECT * FROM system_acl WHERE chain_id = ?1 AND table_id = ?2 ORDER BY created_at, controller;
+
+-- name: GetController :one
+SELECT controller FROM system_controller WHERE chain_id = ?1 AND table_id = ?2;
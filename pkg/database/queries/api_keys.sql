@@ -0,0 +1,2 @@
+-- name: ListAPIKeys :many
+SELECT * FROM system_api_keys ORDER BY api_key;
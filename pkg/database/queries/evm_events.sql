@@ -1,6 +1,6 @@
 -- name: InsertEVMEvent :exec
-INSERT INTO system_evm_events (chain_id, event_json, event_type, address, topics, data, block_number, tx_hash, tx_index, block_hash, event_index)
-VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11);
+INSERT INTO system_evm_events (chain_id, event_json, event_type, address, topics, data, block_number, tx_hash, tx_index, block_hash, event_index, table_id)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12);
 
 -- name: GetEVMEvents :many
 SELECT * FROM system_evm_events WHERE chain_id=?1 AND tx_hash=?2;
@@ -25,4 +25,13 @@ ORDER BY e.block_number;
 SELECT * FROM system_evm_blocks WHERE chain_id=?1 and block_number=?2;
 
 -- name: InsertBlockExtraInfo :exec
-INSERT INTO system_evm_blocks (chain_id, block_number, timestamp) VALUES (?1, ?2, ?3);
\ No newline at end of file
+INSERT INTO system_evm_blocks (chain_id, block_number, timestamp) VALUES (?1, ?2, ?3);
+
+-- name: ListTableEvents :many
+SELECT e.block_number, e.tx_hash, e.tx_index, e.event_index, e.event_type, e.event_json, b.timestamp, r.txn_hash, r.error, r.error_event_idx
+FROM system_evm_events e
+LEFT JOIN system_evm_blocks b ON b.chain_id = e.chain_id AND b.block_number = e.block_number
+LEFT JOIN system_txn_receipts r ON r.chain_id = e.chain_id AND r.txn_hash = e.tx_hash
+WHERE e.chain_id = ?1 AND e.table_id = ?2 AND (e.block_number > ?3 OR (e.block_number = ?3 AND e.event_index > ?4))
+ORDER BY e.block_number, e.event_index
+LIMIT ?5;
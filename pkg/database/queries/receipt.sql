@@ -1,2 +1,11 @@
 -- name: GetReceipt :one
-SELECT * from system_txn_receipts WHERE chain_id=?1 and txn_hash=?2;
\ No newline at end of file
+SELECT * from system_txn_receipts WHERE chain_id=?1 and txn_hash=?2;
+
+-- name: ListReceiptsAfter :many
+SELECT * FROM system_txn_receipts WHERE chain_id = ?1 AND (block_number > ?2 OR (block_number = ?2 AND index_in_block > ?3)) ORDER BY block_number, index_in_block LIMIT ?4;
+
+-- name: ListReceiptsInRange :many
+SELECT * FROM system_txn_receipts WHERE chain_id = ?1 AND (block_number > ?2 OR (block_number = ?2 AND index_in_block > ?3)) AND block_number <= ?4 ORDER BY block_number, index_in_block LIMIT ?5;
+
+-- name: ListTableReceiptsInRange :many
+SELECT r.* FROM system_txn_receipt_tables t JOIN system_txn_receipts r ON r.chain_id = t.chain_id AND r.block_number = t.block_number AND r.index_in_block = t.index_in_block WHERE t.chain_id = ?1 AND t.table_id = ?2 AND (t.block_number > ?3 OR (t.block_number = ?3 AND t.index_in_block > ?4)) AND t.block_number <= ?5 ORDER BY t.block_number, t.index_in_block LIMIT ?6;
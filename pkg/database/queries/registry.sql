@@ -1,2 +1,5 @@
 -- name: GetTable :one
 SELECT * FROM registry WHERE chain_id =?1 AND id = ?2;
+
+-- name: ListTablesByController :many
+SELECT * FROM registry WHERE chain_id = ?1 AND controller = ?2 AND id > ?3 ORDER BY id LIMIT ?4;
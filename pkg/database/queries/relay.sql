@@ -0,0 +1,15 @@
+-- name: InsertRelayWrite :exec
+INSERT INTO system_relay_writes (chain_id, signer, nonce, created_at) VALUES (?1, ?2, ?3, ?4);
+
+-- name: CountRelayWritesSince :one
+SELECT count(*) FROM system_relay_writes WHERE signer = ?1 AND created_at >= ?2;
+
+-- name: GetChainRelayUsageSince :one
+SELECT count(*) AS writes, CAST(coalesce(sum(gas), 0) AS INTEGER) AS gas
+FROM system_relay_writes WHERE chain_id = ?1 AND created_at >= ?2;
+
+-- name: SetRelayWriteTxn :exec
+UPDATE system_relay_writes SET txn_hash = ?4, gas = ?5 WHERE chain_id = ?1 AND signer = ?2 AND nonce = ?3;
+
+-- name: DeleteRelayWrite :exec
+DELETE FROM system_relay_writes WHERE chain_id = ?1 AND signer = ?2 AND nonce = ?3;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- name: GetStateHash :one
+SELECT * FROM system_state_hashes WHERE chain_id = ?1 AND block_number <= ?2 ORDER BY block_number DESC LIMIT 1;
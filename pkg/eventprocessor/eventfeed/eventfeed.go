@@ -37,6 +37,8 @@ type EventFeedStore interface {
 // EventFeed provides a stream of on-chain events from a smart contract.
 type EventFeed interface {
 	Start(ctx context.Context, fromHeight int64, ch chan<- BlockEvents, filterEventTypes []EventType) error
+	// GetCurrentHeight returns the next height the feed will fetch events from.
+	GetCurrentHeight() int64
 }
 
 // EVMEvent is a Tableland on-chain event produced by the Registry SC.
@@ -54,6 +56,8 @@ type EVMEvent struct {
 	ChainID   tableland.ChainID
 	EventJSON []byte
 	EventType string
+	// TableID is the id of the table of the event, or nil if the event isn't about a table.
+	TableID *big.Int
 }
 
 // EVMBlockInfo contains information about an EVM block.
@@ -251,6 +251,11 @@ func (ef *EventFeed) Start(
 	return nil
 }
 
+// GetCurrentHeight returns the next height the feed will fetch events from.
+func (ef *EventFeed) GetCurrentHeight() int64 {
+	return ef.mCurrentHeight.Load()
+}
+
 func (ef *EventFeed) filterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
 	ctx, cancel := context.WithTimeout(ctx, 90*time.Second)
 	defer cancel()
@@ -498,6 +503,7 @@ func (ef *EventFeed) persistEvents(ctx context.Context, events []types.Log, pars
 			ChainID:   ef.chainID,
 			EventJSON: eventJSONBytes,
 			EventType: eventType,
+			TableID:   eventTableID(parsedEvents[i]),
 		}
 		tblEvents = append(tblEvents, tblEvent)
 		if err := telemetry.Collect(ctx, toNewTablelandEvent(tblEvent)); err != nil {
@@ -516,6 +522,21 @@ func (ef *EventFeed) persistEvents(ctx context.Context, events []types.Log, pars
 	return nil
 }
 
+// eventTableID returns the id of the table of an event, or nil if the event isn't about a table.
+func eventTableID(event interface{}) *big.Int {
+	switch e := event.(type) {
+	case *tbleth.ContractCreateTable:
+		return e.TableId
+	case *tbleth.ContractRunSQL:
+		return e.TableId
+	case *tbleth.ContractSetController:
+		return e.TableId
+	case *tbleth.ContractTransferTable:
+		return e.TableId
+	}
+	return nil
+}
+
 // Based on https://github.com/json-iterator/go/issues/392
 type omitRawFieldExtension struct {
 	jsoniter.DummyExtension
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -86,6 +87,9 @@ func (s *EventFeedStore) SaveEVMEvents(
 			BlockHash:   e.BlockHash.Hex(),
 			EventIndex:  e.Index,
 		}
+		if e.TableID != nil {
+			args.TableID = sql.NullInt64{Int64: e.TableID.Int64(), Valid: true}
+		}
 		if err := queries.InsertEVMEvent(ctx, args); err != nil {
 			return fmt.Errorf("insert evm event: %s", err)
 		}
@@ -165,6 +169,9 @@ func (s *EventFeedStore) GetEVMEvents(
 			EventJSON:   []byte(event.EventJson),
 			EventType:   event.EventType,
 		}
+		if event.TableID.Valid {
+			ret[i].TableID = big.NewInt(event.TableID.Int64)
+		}
 	}
 
 	return ret, nil
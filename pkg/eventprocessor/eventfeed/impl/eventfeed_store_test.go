@@ -2,6 +2,7 @@ package impl
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -38,6 +39,7 @@ func TestEVMEventPersistence(t *testing.T) {
 			ChainID:     chainID,
 			EventJSON:   []byte("eventjson1"),
 			EventType:   "Type1",
+			TableID:     big.NewInt(1),
 		},
 		{
 			Address:     common.HexToAddress("0x20"),
@@ -85,5 +87,6 @@ func TestEVMEventPersistence(t *testing.T) {
 		require.Equal(t, events[0].ChainID, chainID)
 		require.Equal(t, events[0].EventJSON, event.EventJSON)
 		require.Equal(t, events[0].EventType, event.EventType)
+		require.Equal(t, events[0].TableID, event.TableID)
 	}
 }
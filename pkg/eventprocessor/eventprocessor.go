@@ -14,6 +14,7 @@ type Config struct {
 	DedupExecutedTxns           bool
 	HashCalcStep                int64
 	WebhookURL                  string
+	ReceiptPublisher            ReceiptPublisher
 }
 
 // DefaultConfig returns the default configuration.
@@ -74,9 +75,33 @@ func WithWebhook(url string) Option {
 	}
 }
 
+// WithReceiptPublisher is set when we want to publish the receipts of every
+// executed block to in-process subscribers.
+func WithReceiptPublisher(publisher ReceiptPublisher) Option {
+	return func(c *Config) error {
+		c.ReceiptPublisher = publisher
+		return nil
+	}
+}
+
+// ReceiptPublisher publishes the receipts of a block after it was committed.
+type ReceiptPublisher interface {
+	Publish([]Receipt)
+}
+
 // EventProcessor processes events from a smart-contract.
 type EventProcessor interface {
 	GetLastExecutedBlockNumber() int64
+	// GetExecutionRound returns how many times the execution of the current block failed. It's zero
+	// unless the processor is stuck retrying a block.
+	GetExecutionRound() int64
+	// GetFeedHeight returns the next height the event feed will fetch events from.
+	GetFeedHeight() int64
+	// IsRunning returns true if the processor was started and isn't stopped.
+	IsRunning() bool
+	// ForceStateHash makes the processor calculate the state hash with the next executed block,
+	// independently of the hash calculation step.
+	ForceStateHash()
 	Start() error
 	Stop()
 }
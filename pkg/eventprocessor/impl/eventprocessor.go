@@ -41,6 +41,8 @@ type EventProcessor struct {
 	webhook Webhook
 
 	nextHashCalcBlockNumber int64
+	forceHashCalc           atomic.Bool
+	running                 atomic.Bool
 
 	lock           sync.Mutex
 	daemonCtx      context.Context
@@ -116,8 +118,14 @@ func (ep *EventProcessor) Start() error {
 	ep.daemonCancel = cls
 	ep.daemonCanceled = make(chan struct{})
 	if err := ep.startDaemon(); err != nil {
+		// The daemon goroutines weren't started, so reset the state to allow Start() to be called again.
+		cls()
+		ep.daemonCtx = nil
+		ep.daemonCancel = nil
+		ep.daemonCanceled = nil
 		return fmt.Errorf("background daemon failed starting: %s", err)
 	}
+	ep.running.Store(true)
 	ep.log.Info().Msg("started")
 
 	return nil
@@ -128,6 +136,27 @@ func (ep *EventProcessor) GetLastExecutedBlockNumber() int64 {
 	return ep.mLastProcessedHeight.Load()
 }
 
+// GetExecutionRound returns how many times the execution of the current block failed.
+func (ep *EventProcessor) GetExecutionRound() int64 {
+	return ep.mExecutionRound.Load()
+}
+
+// GetFeedHeight returns the next height the event feed will fetch events from.
+func (ep *EventProcessor) GetFeedHeight() int64 {
+	return ep.ef.GetCurrentHeight()
+}
+
+// IsRunning returns true if the processor was started and isn't stopped.
+func (ep *EventProcessor) IsRunning() bool {
+	return ep.running.Load()
+}
+
+// ForceStateHash makes the processor calculate the state hash with the next executed block. The hash can't
+// be calculated right away since it must happen inside a block scope.
+func (ep *EventProcessor) ForceStateHash() {
+	ep.forceHashCalc.Store(true)
+}
+
 // Stop stops processing new events.
 func (ep *EventProcessor) Stop() {
 	ep.lock.Lock()
@@ -145,6 +174,7 @@ func (ep *EventProcessor) Stop() {
 	ep.daemonCancel = nil
 	ep.daemonCanceled = nil
 	ep.mExecutionRound.Store(0)
+	ep.running.Store(false)
 
 	ep.log.Debug().Msg("syncer stopped")
 }
@@ -227,11 +257,12 @@ func (ep *EventProcessor) executeBlock(ctx context.Context, block eventfeed.Bloc
 		}
 	}()
 
-	if block.BlockNumber >= ep.nextHashCalcBlockNumber {
+	if block.BlockNumber >= ep.nextHashCalcBlockNumber || ep.forceHashCalc.Load() {
 		if err := ep.calculateHash(ctx, bs); err != nil {
 			return fmt.Errorf("calculate hash: %s", err)
 		}
 		ep.nextHashCalcBlockNumber = nextMultipleOf(block.BlockNumber, ep.config.HashCalcStep)
+		ep.forceHashCalc.Store(false)
 	}
 
 	receipts := make([]eventprocessor.Receipt, 0, len(block.Txns))
@@ -297,6 +328,11 @@ func (ep *EventProcessor) executeBlock(ctx context.Context, block eventfeed.Bloc
 		return fmt.Errorf("committing changes: %s", err)
 	}
 
+	// Notify in-process subscribers, if configured.
+	if ep.config.ReceiptPublisher != nil {
+		ep.config.ReceiptPublisher.Publish(receipts)
+	}
+
 	// Send a webhook for each receipt, if enabled for a current chain.
 	if ep.webhook != nil {
 		ep.executeWebhook(ctx, receipts)
@@ -342,6 +378,10 @@ func (ep *EventProcessor) calculateHash(ctx context.Context, bs executor.BlockSc
 
 	ep.mHashCalculationElapsedTime.Store(elapsedTime)
 
+	if err := bs.SaveStateHash(ctx, stateHash); err != nil {
+		return fmt.Errorf("saving state hash: %s", err)
+	}
+
 	if err := telemetry.Collect(ctx, telemetry.StateHashMetric{
 		Version:     telemetry.StateHashMetricV1,
 		ChainID:     int64(stateHash.ChainID),
@@ -213,3 +213,7 @@ func (acl *aclMock) CheckPrivileges(
 ) (bool, error) {
 	return true, nil
 }
+
+func (acl *aclMock) GetTableACL(_ context.Context, _ tableland.ChainID, _ tables.TableID) (tableland.TableACL, error) {
+	return tableland.TableACL{}, nil
+}
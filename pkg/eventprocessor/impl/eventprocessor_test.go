@@ -2,6 +2,7 @@ package impl
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"strconv"
 	"testing"
@@ -15,6 +16,7 @@ import (
 	"github.com/textileio/go-tableland/pkg/eventprocessor"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
 	efimpl "github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed/impl"
+	executorif "github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
 	executor "github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor/impl"
 	"github.com/textileio/go-tableland/pkg/parsing"
 	parserimpl "github.com/textileio/go-tableland/pkg/parsing/impl"
@@ -446,3 +448,28 @@ func setup(t *testing.T) (
 		transfer:      transferFrom,
 	}, checkReceipts, tableReader
 }
+
+func TestStartFailureAllowsRestart(t *testing.T) {
+	t.Parallel()
+
+	ex := &failingExecutor{}
+	ep, err := New(nil, ex, nil, chainID)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, ep.Start(), "database is down")
+	require.False(t, ep.IsRunning())
+
+	// A failed start must not leave the processor looking like it was started.
+	require.ErrorContains(t, ep.Start(), "database is down")
+	require.Equal(t, 2, ex.calls)
+}
+
+type failingExecutor struct {
+	executorif.Executor
+	calls int
+}
+
+func (ex *failingExecutor) GetLastExecutedBlockNumber(context.Context) (int64, error) {
+	ex.calls++
+	return 0, errors.New("database is down")
+}
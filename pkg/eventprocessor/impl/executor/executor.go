@@ -45,6 +45,9 @@ type BlockScope interface {
 	// StateHash calculates the hash of some state of the database.
 	StateHash(ctx context.Context, chainID tableland.ChainID) (StateHash, error)
 
+	// SaveStateHash saves a state hash, so it can be compared with the ones of other validators.
+	SaveStateHash(ctx context.Context, stateHash StateHash) error
+
 	// Commit commits all the changes that happened in  previously successful ExecuteTxnEvents(...) calls.
 	Commit() error
 
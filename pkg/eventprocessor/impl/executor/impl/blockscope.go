@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
@@ -15,6 +16,7 @@ import (
 	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor"
 	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/tables"
 )
 
 type blockScope struct {
@@ -29,9 +31,10 @@ type blockScope struct {
 }
 
 type scopeVars struct {
-	ChainID          tableland.ChainID
-	MaxTableRowCount int
-	BlockNumber      int64
+	ChainID            tableland.ChainID
+	MaxTableRowCount   int
+	ChangeLogRetention int64
+	BlockNumber        int64
 }
 
 func newBlockScope(
@@ -127,6 +130,49 @@ func (bs *blockScope) SetLastProcessedHeight(ctx context.Context, height int64)
 			return fmt.Errorf("inserting first processed height: %s", err)
 		}
 	}
+	if err := bs.pruneRowChanges(ctx, height); err != nil {
+		return fmt.Errorf("pruning row changes: %s", err)
+	}
+	return nil
+}
+
+// pruneRowChanges drops the row changes that aren't needed anymore to rebuild the state of the tables
+// at the blocks within the change log retention, and tracks the oldest block that can be rebuilt.
+// Table creations are kept, so reads at blocks before them know the tables didn't exist.
+// If the change log is disabled, every row change of the chain is dropped, since there would be a gap.
+func (bs *blockScope) pruneRowChanges(ctx context.Context, height int64) error {
+	if bs.scopeVars.ChangeLogRetention <= 0 {
+		if _, err := bs.txn.ExecContext(ctx,
+			"DELETE FROM system_row_changes_retention WHERE chain_id=?1", bs.scopeVars.ChainID,
+		); err != nil {
+			return fmt.Errorf("deleting change log retention: %s", err)
+		}
+		if _, err := bs.txn.ExecContext(ctx,
+			"DELETE FROM system_row_changes WHERE chain_id=?1", bs.scopeVars.ChainID,
+		); err != nil {
+			return fmt.Errorf("deleting row changes: %s", err)
+		}
+		return nil
+	}
+
+	// The changes of the first recorded block are in the log, so the state can be rebuilt from the
+	// previous block on.
+	if _, err := bs.txn.ExecContext(ctx,
+		`INSERT INTO system_row_changes_retention (chain_id, since_block) VALUES (?1, ?2)
+			ON CONFLICT (chain_id) DO UPDATE SET since_block=max(since_block, ?3)`,
+		bs.scopeVars.ChainID, height-1, height-bs.scopeVars.ChangeLogRetention,
+	); err != nil {
+		return fmt.Errorf("updating change log retention: %s", err)
+	}
+	if _, err := bs.txn.ExecContext(ctx,
+		`DELETE FROM system_row_changes
+			WHERE chain_id=?1
+			AND block_number<=(SELECT since_block FROM system_row_changes_retention WHERE chain_id=?1)
+			AND (schema IS NULL OR schema != '')`,
+		bs.scopeVars.ChainID,
+	); err != nil {
+		return fmt.Errorf("deleting row changes: %s", err)
+	}
 	return nil
 }
 
@@ -156,6 +202,21 @@ func (bs *blockScope) SaveTxnReceipts(ctx context.Context, rs []eventprocessor.R
 			r.ChainID, r.TxnHash, r.Error, r.ErrorEventIdx, tableID, r.BlockNumber, r.IndexInBlock, tableIDs); err != nil {
 			return fmt.Errorf("insert txn receipt: %s", err)
 		}
+
+		// Failed receipts only have the table id set, so both are indexed to find the receipts of a table.
+		receiptTables := r.TableIDs
+		if r.TableID != nil {
+			receiptTables = append(tables.TableIDs{*r.TableID}, receiptTables...)
+		}
+		for _, id := range receiptTables {
+			if _, err := bs.txn.ExecContext(
+				ctx,
+				`INSERT OR IGNORE INTO system_txn_receipt_tables (chain_id,table_id,block_number,index_in_block)
+					VALUES (?1,?2,?3,?4)`,
+				r.ChainID, id.ToBigInt().Int64(), r.BlockNumber, r.IndexInBlock); err != nil {
+				return fmt.Errorf("insert txn receipt table: %s", err)
+			}
+		}
 	}
 	return nil
 }
@@ -224,6 +285,21 @@ func (bs *blockScope) StateHash(ctx context.Context, chainID tableland.ChainID)
 	return executor.NewStateHash(chainID, bs.scopeVars.BlockNumber, hash), nil
 }
 
+func (bs *blockScope) SaveStateHash(ctx context.Context, stateHash executor.StateHash) error {
+	if _, err := bs.txn.ExecContext(
+		ctx,
+		`INSERT OR REPLACE INTO system_state_hashes (chain_id, block_number, hash, created_at)
+		 VALUES (?1, ?2, ?3, ?4)`,
+		stateHash.ChainID,
+		stateHash.BlockNumber,
+		stateHash.Hash,
+		time.Now().Unix(),
+	); err != nil {
+		return fmt.Errorf("insert state hash: %s", err)
+	}
+	return nil
+}
+
 // Close closes gracefully the block scope.
 // Clients should *always* `defer Close()` when opening block scopes.
 func (bs *blockScope) Close() error {
@@ -24,8 +24,9 @@ type Executor struct {
 	acl          tableland.ACL
 	chBlockScope chan struct{}
 
-	chainID          tableland.ChainID
-	maxTableRowCount int
+	chainID            tableland.ChainID
+	maxTableRowCount   int
+	changeLogRetention int64
 
 	closeOnce sync.Once
 	closed    chan struct{}
@@ -33,6 +34,18 @@ type Executor struct {
 
 var _ executor.Executor = (*Executor)(nil)
 
+// ExecutorOption modifies the configuration of an Executor.
+type ExecutorOption func(*Executor)
+
+// WithChangeLogRetention records the before and after images of the rows changed by write statements in the
+// system_row_changes table, so the state of the tables at any of the last blocks blocks can be rebuilt.
+// Zero disables the change log, and drops the recorded changes of the chain.
+func WithChangeLogRetention(blocks int64) ExecutorOption {
+	return func(ex *Executor) {
+		ex.changeLogRetention = blocks
+	}
+}
+
 // NewExecutor returns a new Executor.
 func NewExecutor(
 	chainID tableland.ChainID,
@@ -41,6 +54,7 @@ func NewExecutor(
 	parser parsing.SQLValidator,
 	maxTableRowCount int,
 	acl tableland.ACL,
+	opts ...ExecutorOption,
 ) (*Executor, error) {
 	if maxTableRowCount < 0 {
 		return nil, fmt.Errorf("maximum table row count is negative")
@@ -62,6 +76,12 @@ func NewExecutor(
 
 		closed: make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(tblp)
+	}
+	if tblp.changeLogRetention < 0 {
+		return nil, fmt.Errorf("change log retention is negative")
+	}
 	tblp.chBlockScope <- struct{}{}
 
 	return tblp, nil
@@ -96,9 +116,10 @@ func (ex *Executor) NewBlockScope(ctx context.Context, newBlockNum int64) (execu
 	}
 
 	scopeVars := scopeVars{
-		ChainID:          ex.chainID,
-		MaxTableRowCount: ex.maxTableRowCount,
-		BlockNumber:      newBlockNum,
+		ChainID:            ex.chainID,
+		MaxTableRowCount:   ex.maxTableRowCount,
+		ChangeLogRetention: ex.changeLogRetention,
+		BlockNumber:        newBlockNum,
 	}
 	bs := newBlockScope(txn, scopeVars, ex.parser, ex.acl, releaseBlockScope)
 
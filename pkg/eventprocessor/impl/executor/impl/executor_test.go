@@ -63,6 +63,35 @@ func TestReceiptExists(t *testing.T) {
 	require.NoError(t, ex.Close(ctx))
 }
 
+func TestSaveStateHash(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ex, dbURI := newExecutorWithIntegerTable(t, 0)
+
+	bs, err := ex.NewBlockScope(ctx, 100)
+	require.NoError(t, err)
+	stateHash, err := bs.StateHash(ctx, tableland.ChainID(chainID))
+	require.NoError(t, err)
+	require.Equal(t, int64(100), stateHash.BlockNumber)
+	require.NoError(t, bs.SaveStateHash(ctx, stateHash))
+	require.NoError(t, bs.Commit())
+	require.NoError(t, bs.Close())
+
+	hash := tableReadString(t, dbURI, "SELECT hash FROM system_state_hashes WHERE chain_id=1337 AND block_number=100")
+	require.Equal(t, stateHash.Hash, hash)
+
+	// Saved state hashes aren't part of the state.
+	bs, err = ex.NewBlockScope(ctx, 101)
+	require.NoError(t, err)
+	nextStateHash, err := bs.StateHash(ctx, tableland.ChainID(chainID))
+	require.NoError(t, err)
+	require.Equal(t, stateHash.Hash, nextStateHash.Hash)
+	require.NoError(t, bs.Close())
+
+	require.NoError(t, ex.Close(ctx))
+}
+
 func TestMultiEventTxnBlock(t *testing.T) {
 	t.Parallel()
 
@@ -42,6 +42,7 @@ func (ts *txnScope) executeCreateTableEvent(
 // - Registers the table in the system-wide table registry.
 // - Executes the CREATE statement.
 // - Add default privileges in the system_acl table.
+// - Records the creation in the change log, if enabled.
 func (ts *txnScope) insertTable(
 	ctx context.Context,
 	id tables.TableID,
@@ -84,5 +85,11 @@ func (ts *txnScope) insertTable(
 		return fmt.Errorf("exec CREATE statement: %s", err)
 	}
 
+	if ts.scopeVars.ChangeLogRetention > 0 {
+		if err := ts.recordTableCreation(ctx, id); err != nil {
+			return fmt.Errorf("recording table creation: %s", err)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,136 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+// recordRowChanges installs temporary triggers that record the before and after images of every row changed in
+// a table into system_row_changes. Images are the values of the columns, as a list of SQL literals built with
+// quote(), so they can be inserted back to rebuild past states. The returned func removes the triggers.
+func (ts *txnScope) recordRowChanges(
+	ctx context.Context, dbTableName string, tableID tables.TableID,
+) (func(context.Context) error, error) {
+	columns, _, err := ts.tableColumns(ctx, dbTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	columnList := strings.ReplaceAll(strings.Join(columns, ","), "'", "''")
+	triggers := map[string]string{
+		"system_row_changes_insert": fmt.Sprintf(
+			"AFTER INSERT ON main.%s BEGIN "+
+				"INSERT INTO system_row_changes (chain_id, table_id, block_number, columns, after_row_id, after) "+
+				"VALUES (%d, %d, %d, '%s', NEW.rowid, %s); END",
+			parsing.QuoteIdentifier(dbTableName), ts.scopeVars.ChainID, tableID.ToBigInt().Int64(),
+			ts.scopeVars.BlockNumber, columnList, rowImage(columns, "NEW")),
+		"system_row_changes_update": fmt.Sprintf(
+			"AFTER UPDATE ON main.%s BEGIN "+
+				"INSERT INTO system_row_changes "+
+				"(chain_id, table_id, block_number, columns, before_row_id, before, after_row_id, after) "+
+				"VALUES (%d, %d, %d, '%s', OLD.rowid, %s, NEW.rowid, %s); END",
+			parsing.QuoteIdentifier(dbTableName), ts.scopeVars.ChainID, tableID.ToBigInt().Int64(),
+			ts.scopeVars.BlockNumber, columnList, rowImage(columns, "OLD"), rowImage(columns, "NEW")),
+		"system_row_changes_delete": fmt.Sprintf(
+			"AFTER DELETE ON main.%s BEGIN "+
+				"INSERT INTO system_row_changes (chain_id, table_id, block_number, columns, before_row_id, before) "+
+				"VALUES (%d, %d, %d, '%s', OLD.rowid, %s); END",
+			parsing.QuoteIdentifier(dbTableName), ts.scopeVars.ChainID, tableID.ToBigInt().Int64(),
+			ts.scopeVars.BlockNumber, columnList, rowImage(columns, "OLD")),
+	}
+
+	drop := func(ctx context.Context) error {
+		for name := range triggers {
+			if _, err := ts.txn.ExecContext(ctx, "DROP TRIGGER IF EXISTS temp."+name); err != nil {
+				return fmt.Errorf("dropping trigger %s: %s", name, err)
+			}
+		}
+		return nil
+	}
+	for name, body := range triggers {
+		if _, err := ts.txn.ExecContext(ctx, "CREATE TEMP TRIGGER "+name+" "+body); err != nil {
+			_ = drop(ctx)
+			return nil, fmt.Errorf("creating trigger %s: %s", name, err)
+		}
+	}
+
+	return drop, nil
+}
+
+// recordSchemaChange records a change of the schema of a table that is about to be altered. ALTER statements
+// don't fire triggers, so the before image of every row is recorded, followed by a schema change holding the
+// column definitions the table had. Undoing the schema change recreates the table with those columns, and then
+// the rows are inserted back. Recording an ALTER costs a row change per row of the table.
+func (ts *txnScope) recordSchemaChange(ctx context.Context, dbTableName string, tableID tables.TableID) error {
+	columns, definitions, err := ts.tableColumns(ctx, dbTableName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ts.txn.ExecContext(ctx, fmt.Sprintf(
+		"INSERT INTO system_row_changes (chain_id, table_id, block_number, columns, before_row_id, before) "+
+			"SELECT ?1, ?2, ?3, ?4, rowid, %s FROM main.%s",
+		rowImage(columns, parsing.QuoteIdentifier(dbTableName)), parsing.QuoteIdentifier(dbTableName)),
+		ts.scopeVars.ChainID, tableID.ToBigInt().Int64(), ts.scopeVars.BlockNumber, strings.Join(columns, ","),
+	); err != nil {
+		return fmt.Errorf("recording rows: %s", err)
+	}
+	if err := ts.insertSchemaChange(ctx, tableID, strings.Join(definitions, ",")); err != nil {
+		return fmt.Errorf("recording schema: %s", err)
+	}
+	return nil
+}
+
+// recordTableCreation records the creation of a table as a schema change with empty column definitions, so reads
+// at blocks before the creation know the table didn't exist.
+func (ts *txnScope) recordTableCreation(ctx context.Context, tableID tables.TableID) error {
+	return ts.insertSchemaChange(ctx, tableID, "")
+}
+
+func (ts *txnScope) insertSchemaChange(ctx context.Context, tableID tables.TableID, schema string) error {
+	if _, err := ts.txn.ExecContext(ctx,
+		`INSERT INTO system_row_changes (chain_id, table_id, block_number, columns, schema) VALUES (?1, ?2, ?3, '', ?4)`,
+		ts.scopeVars.ChainID, tableID.ToBigInt().Int64(), ts.scopeVars.BlockNumber, schema,
+	); err != nil {
+		return fmt.Errorf("inserting schema change: %s", err)
+	}
+	return nil
+}
+
+// tableColumns returns the quoted names of the columns of a table, and their definitions.
+func (ts *txnScope) tableColumns(ctx context.Context, dbTableName string) ([]string, []string, error) {
+	rows, err := ts.txn.QueryContext(ctx, "SELECT name, type FROM pragma_table_info(?1, 'main')", dbTableName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting table columns: %s", err)
+	}
+	var columns, definitions []string
+	for rows.Next() {
+		var column, typ string
+		if err := rows.Scan(&column, &typ); err != nil {
+			_ = rows.Close()
+			return nil, nil, fmt.Errorf("scan column: %s", err)
+		}
+		columns = append(columns, parsing.QuoteIdentifier(column))
+		definitions = append(definitions, strings.TrimSpace(parsing.QuoteIdentifier(column)+" "+typ))
+	}
+	if err := rows.Close(); err != nil {
+		return nil, nil, fmt.Errorf("closing rows: %s", err)
+	}
+	if len(columns) == 0 {
+		return nil, nil, fmt.Errorf("table %s has no columns", dbTableName)
+	}
+	return columns, definitions, nil
+}
+
+// rowImage returns the expression building the image of a row, as a list of SQL literals.
+func rowImage(columns []string, row string) string {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = fmt.Sprintf("quote(%s.%s)", row, column)
+	}
+	return strings.Join(values, "||','||")
+}
@@ -0,0 +1,69 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowChanges(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ex, dbURI := newExecutorWithStringTable(t, 0)
+	ex.changeLogRetention = 2
+
+	executeBlock := func(height int64, stmts ...string) {
+		bs, err := ex.NewBlockScope(ctx, height)
+		require.NoError(t, err)
+		for _, stmt := range stmts {
+			assertExecTxnWithRunSQLEvents(t, bs, []string{stmt})
+		}
+		require.NoError(t, bs.SetLastProcessedHeight(ctx, height))
+		require.NoError(t, bs.Commit())
+		require.NoError(t, bs.Close())
+	}
+
+	executeBlock(1, `insert into foo_1337_100 values ('one')`, `insert into foo_1337_100 values ('it''s')`)
+	executeBlock(2, `update foo_1337_100 set zar='two' where zar='one'`)
+	executeBlock(3, `delete from foo_1337_100 where zar='two'`)
+
+	db, err := sql.Open("sqlite3", dbURI)
+	require.NoError(t, err)
+	type change struct {
+		blockNumber int64
+		before      sql.NullString
+		after       sql.NullString
+	}
+	rows, err := db.Query("SELECT block_number, before, after FROM system_row_changes ORDER BY id")
+	require.NoError(t, err)
+	var changes []change
+	for rows.Next() {
+		var c change
+		require.NoError(t, rows.Scan(&c.blockNumber, &c.before, &c.after))
+		changes = append(changes, c)
+	}
+	require.NoError(t, rows.Close())
+
+	// The changes of block 1 were pruned, since the state can be rebuilt from block 1 on.
+	require.Equal(t, []change{
+		{
+			blockNumber: 2,
+			before:      sql.NullString{String: "'one'", Valid: true},
+			after:       sql.NullString{String: "'two'", Valid: true},
+		},
+		{
+			blockNumber: 3,
+			before:      sql.NullString{String: "'two'", Valid: true},
+		},
+	}, changes)
+	require.Equal(t, 1, tableReadInteger(t, dbURI, "SELECT since_block FROM system_row_changes_retention"))
+
+	// Disabling the change log drops the recorded changes.
+	ex.changeLogRetention = 0
+	executeBlock(4, `insert into foo_1337_100 values ('four')`)
+	require.Equal(t, 0, tableReadInteger(t, dbURI, "SELECT count(*) FROM system_row_changes"))
+	require.Equal(t, 0, tableReadInteger(t, dbURI, "SELECT count(*) FROM system_row_changes_retention"))
+}
@@ -253,6 +253,33 @@ func (ts *txnScope) executeWriteStmt(
 		}
 	}
 
+	if ts.scopeVars.ChangeLogRetention <= 0 {
+		return ts.execWriteStmt(ctx, ws, policy, beforeRowCount)
+	}
+	if ws.Operation() == tableland.OpAlter {
+		if err := ts.recordSchemaChange(ctx, ws.GetDBTableName(), ws.GetTableID()); err != nil {
+			return fmt.Errorf("recording schema change: %s", err)
+		}
+		return ts.execWriteStmt(ctx, ws, policy, beforeRowCount)
+	}
+
+	stopRecording, err := ts.recordRowChanges(ctx, ws.GetDBTableName(), ws.GetTableID())
+	if err != nil {
+		return fmt.Errorf("recording row changes: %s", err)
+	}
+	execErr := ts.execWriteStmt(ctx, ws, policy, beforeRowCount)
+	if err := stopRecording(ctx); err != nil {
+		return fmt.Errorf("stop recording row changes: %s", err)
+	}
+	return execErr
+}
+
+func (ts *txnScope) execWriteStmt(
+	ctx context.Context,
+	ws parsing.WriteStmt,
+	policy tableland.Policy,
+	beforeRowCount int,
+) error {
 	if policy.WithCheck() == "" {
 		query, err := ws.GetQuery(ts.statementResolver)
 		if err != nil {
@@ -0,0 +1,8 @@
+package parsing
+
+import "strings"
+
+// QuoteIdentifier quotes a table or column name, so it can be safely used as an identifier in a SQL statement.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,15 @@
+package parsing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `"foo_1337_1"`, QuoteIdentifier("foo_1337_1"))
+	require.Equal(t, `"a""b"`, QuoteIdentifier(`a"b`))
+	require.Equal(t, `"a'b"`, QuoteIdentifier("a'b"))
+}
@@ -0,0 +1,225 @@
+package impl
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/tablelandnetwork/sqlparser"
+)
+
+// aggregateFunctions are the allowed functions that aggregate rows, so their queries can't be keyed by rowid.
+var aggregateFunctions = map[string]struct{}{
+	"avg":               {},
+	"count":             {},
+	"group_concat":      {},
+	"sum":               {},
+	"total":             {},
+	"json_group_array":  {},
+	"json_group_object": {},
+}
+
+// GetPageQuery implements parsing.ReadStmt. The statement is keyed by its ORDER BY terms followed by the rowid
+// of its table, and the rows after the key are selected with a condition equivalent to comparing the key tuples.
+func (s *readStmt) GetPageQuery(
+	resolver sqlparser.ReadStatementResolver, after []interface{}, limit int,
+) (string, int, bool, error) {
+	sel, ok := s.statement.(*sqlparser.Select)
+	if !ok || !isKeyable(sel) {
+		return "", 0, false, nil
+	}
+	aliased := sel.From.(*sqlparser.AliasedTableExpr)
+	tableRef := aliased.Expr.(*sqlparser.Table)
+	if !aliased.As.IsEmpty() {
+		tableRef = &sqlparser.Table{Name: aliased.As}
+	}
+
+	terms := make(sqlparser.OrderBy, 0, len(sel.OrderBy)+1)
+	for _, term := range sel.OrderBy {
+		expr, ok := orderingExpr(sel, term.Expr)
+		if !ok {
+			return "", 0, false, nil
+		}
+		terms = append(terms, &sqlparser.OrderingTerm{Expr: expr, Direction: term.Direction, Nulls: term.Nulls})
+	}
+	terms = append(terms, &sqlparser.OrderingTerm{
+		Expr:      &sqlparser.Column{Name: sqlparser.Identifier("rowid"), TableRef: tableRef},
+		Direction: sqlparser.AscStr,
+	})
+	if after != nil && len(after) != len(terms) {
+		return "", 0, false, fmt.Errorf("key has %d values, but the statement is keyed by %d", len(after), len(terms))
+	}
+
+	// The statement is modified to resolve it, and restored afterwards.
+	columns, where, orderBy, lim := sel.SelectColumnList, sel.Where, sel.OrderBy, sel.Limit
+	defer func() {
+		sel.SelectColumnList, sel.Where, sel.OrderBy, sel.Limit = columns, where, orderBy, lim
+	}()
+
+	keyColumns := make(sqlparser.SelectColumnList, len(terms))
+	for i, term := range terms {
+		keyColumns[i] = &sqlparser.AliasedSelectColumn{Expr: term.Expr}
+	}
+	sel.SelectColumnList = append(append(sqlparser.SelectColumnList{}, columns...), keyColumns...)
+	if after != nil {
+		cond, err := afterKeyCondition(terms, after)
+		if err != nil {
+			return "", 0, false, err
+		}
+		if where != nil {
+			cond = &sqlparser.AndExpr{Left: &sqlparser.ParenExpr{Expr: where.Expr}, Right: cond}
+		}
+		sel.Where = &sqlparser.Where{Type: sqlparser.WhereStr, Expr: cond}
+	}
+	sel.OrderBy = append(append(sqlparser.OrderBy{}, orderBy...), terms[len(terms)-1])
+	sel.Limit = &sqlparser.Limit{
+		Limit: &sqlparser.Value{Type: sqlparser.IntValue, Value: []byte(strconv.Itoa(limit))},
+	}
+
+	query, err := s.GetQuery(resolver)
+	if err != nil {
+		return "", 0, false, err
+	}
+	return query, len(terms), true, nil
+}
+
+// isKeyable returns true if every row of the statement is a row of a single table, so the rowid of the table
+// identifies it. Statements with parameters in their ORDER BY clause aren't keyable either, since the ordering
+// expressions are repeated in the key columns and parameters are resolved by position.
+func isKeyable(sel *sqlparser.Select) bool {
+	if sel.Distinct != "" || len(sel.GroupBy) > 0 || sel.Having != nil || sel.Limit != nil {
+		return false
+	}
+	aliased, ok := sel.From.(*sqlparser.AliasedTableExpr)
+	if !ok {
+		return false
+	}
+	if _, ok := aliased.Expr.(*sqlparser.Table); !ok {
+		return false
+	}
+
+	keyable := true
+	_ = sqlparser.Walk(func(node sqlparser.Node) (bool, error) {
+		if f, ok := node.(*sqlparser.FuncExpr); ok && f != nil {
+			if _, ok := aggregateFunctions[strings.ToLower(string(f.Name))]; ok || f.Filter != nil {
+				keyable = false
+			}
+		}
+		return !keyable, nil
+	}, sel.SelectColumnList, sel.OrderBy)
+	_ = sqlparser.Walk(func(node sqlparser.Node) (bool, error) {
+		if _, ok := node.(*sqlparser.Param); ok {
+			keyable = false
+		}
+		return !keyable, nil
+	}, sel.OrderBy)
+	return keyable
+}
+
+// orderingExpr returns the expression of an ORDER BY term, resolving column numbers and aliases of the result
+// columns to their expressions.
+func orderingExpr(sel *sqlparser.Select, expr sqlparser.Expr) (sqlparser.Expr, bool) {
+	switch expr := expr.(type) {
+	case *sqlparser.Value:
+		if expr.Type != sqlparser.IntValue {
+			return expr, true
+		}
+		n, err := strconv.Atoi(string(expr.Value))
+		if err != nil || n < 1 || n > len(sel.SelectColumnList) {
+			return nil, false
+		}
+		column, ok := sel.SelectColumnList[n-1].(*sqlparser.AliasedSelectColumn)
+		if !ok {
+			return nil, false
+		}
+		return column.Expr, true
+	case *sqlparser.Column:
+		if expr.TableRef != nil {
+			return expr, true
+		}
+		for _, c := range sel.SelectColumnList {
+			if column, ok := c.(*sqlparser.AliasedSelectColumn); ok && strings.EqualFold(
+				string(column.As), string(expr.Name),
+			) {
+				return column.Expr, true
+			}
+		}
+	}
+	return expr, true
+}
+
+// afterKeyCondition returns a condition that holds for the rows that sort after the key, which has a value for
+// every ordering term. Ties of the previous terms are compared with IS, so NULLs are equal, and NULLs sort first
+// in ascending order and last in descending order, unless NULLS FIRST or NULLS LAST says otherwise.
+func afterKeyCondition(terms sqlparser.OrderBy, key []interface{}) (sqlparser.Expr, error) {
+	var cond, ties sqlparser.Expr
+	for i, term := range terms {
+		value, err := keyLiteral(key[i])
+		if err != nil {
+			return nil, err
+		}
+		desc := strings.EqualFold(term.Direction, sqlparser.DescStr)
+		nullsFirst := term.Nulls == sqlparser.NullsFirst || (term.Nulls == sqlparser.NullsNil && !desc)
+		expr := &sqlparser.ParenExpr{Expr: term.Expr}
+		isNull := &sqlparser.IsExpr{Left: expr, Right: &sqlparser.NullValue{}}
+
+		var after sqlparser.Expr
+		switch {
+		case key[i] == nil && nullsFirst:
+			after = &sqlparser.NotExpr{Expr: &sqlparser.ParenExpr{Expr: isNull}}
+		case key[i] != nil:
+			operator := sqlparser.GreaterThanStr
+			if desc {
+				operator = sqlparser.LessThanStr
+			}
+			after = &sqlparser.CmpExpr{Operator: operator, Left: expr, Right: value}
+			if !nullsFirst {
+				after = &sqlparser.OrExpr{Left: after, Right: isNull}
+			}
+		}
+		if after != nil {
+			if ties != nil {
+				after = &sqlparser.AndExpr{Left: ties, Right: &sqlparser.ParenExpr{Expr: after}}
+			}
+			if cond == nil {
+				cond = &sqlparser.ParenExpr{Expr: after}
+			} else {
+				cond = &sqlparser.OrExpr{Left: cond, Right: &sqlparser.ParenExpr{Expr: after}}
+			}
+		}
+
+		tie := &sqlparser.IsExpr{Left: expr, Right: value}
+		if ties == nil {
+			ties = tie
+		} else {
+			ties = &sqlparser.AndExpr{Left: ties, Right: tie}
+		}
+	}
+	return &sqlparser.ParenExpr{Expr: cond}, nil
+}
+
+// keyLiteral returns the SQL literal of a key value, as scanned from a row.
+func keyLiteral(v interface{}) (sqlparser.Expr, error) {
+	switch v := v.(type) {
+	case nil:
+		return &sqlparser.NullValue{}, nil
+	case int64:
+		return &sqlparser.Value{Type: sqlparser.IntValue, Value: []byte(strconv.FormatInt(v, 10))}, nil
+	case float64:
+		s := strconv.FormatFloat(v, 'e', -1, 64)
+		if math.IsInf(v, 1) {
+			s = "9e999"
+		} else if math.IsInf(v, -1) {
+			s = "-9e999"
+		}
+		return &sqlparser.Value{Type: sqlparser.FloatValue, Value: []byte(s)}, nil
+	case string:
+		return &sqlparser.Value{Type: sqlparser.StrValue, Value: []byte(strings.ReplaceAll(v, "'", "''"))}, nil
+	case []byte:
+		return &sqlparser.Value{Type: sqlparser.BlobValue, Value: []byte(hex.EncodeToString(v))}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key value type %T", v)
+	}
+}
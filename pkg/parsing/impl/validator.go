@@ -369,6 +369,29 @@ func (s *readStmt) GetQuery(resolver sqlparser.ReadStatementResolver) (string, e
 	return query, nil
 }
 
+func (s *readStmt) GetTables() []*sqlparser.ValidatedTable {
+	var tbls []*sqlparser.ValidatedTable
+	seen := map[string]struct{}{}
+	_ = sqlparser.Walk(func(node sqlparser.Node) (bool, error) {
+		table, ok := node.(*sqlparser.Table)
+		if !ok || table == nil || !table.IsTarget {
+			return false, nil
+		}
+		// Names that aren't user tables, such as system tables, are skipped.
+		validTable, err := sqlparser.ValidateTargetTable(table)
+		if err != nil {
+			return false, nil
+		}
+		if _, ok := seen[validTable.Name()]; !ok {
+			seen[validTable.Name()] = struct{}{}
+			tbls = append(tbls, validTable)
+		}
+		return false, nil
+	}, s.statement)
+
+	return tbls
+}
+
 func (pp *QueryValidator) validateWriteQuery(stmt sqlparser.WriteStatement) (*sqlparser.ValidatedTable, error) {
 	if err := checkNoSystemTablesReferencing(stmt, pp.systemTablePrefixes); err != nil {
 		return nil, fmt.Errorf("no system-table reference: %w", err)
@@ -1,6 +1,8 @@
 package impl_test
 
 import (
+	"encoding/json"
+	"math"
 	"math/big"
 	"testing"
 
@@ -130,6 +132,13 @@ func TestReadQueryWithParams(t *testing.T) {
 			expQuery:   "select * from foo where a=1 and b='str' and c='str2' and d=null and e=true and f=false",
 			expErrType: nil,
 		},
+		{
+			name:       "query with quotes and negative numbers",
+			query:      "select * from foo where a = ? and b = ? and c = 1-?",
+			params:     []string{"'it''s'", "\"it's\"", "-1"},
+			expQuery:   "select * from foo where a='it''s' and b='it''s' and c=1-(-1)",
+			expErrType: nil,
+		},
 	}
 
 	for _, it := range tests {
@@ -158,6 +167,24 @@ func TestReadQueryWithParams(t *testing.T) {
 	}
 }
 
+func TestReadQueryWithValues(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+	rs, err := parser.ValidateReadQuery("select * from foo where a = ? and b = ? and c = ? and d = ? and e = ? and f = ?")
+	require.NoError(t, err)
+
+	resolver := parsing.NewReadStatementResolver(nil)
+	err = resolver.PrepareValues([]any{json.Number("10"), json.Number("1.5"), 2.0, "'", []byte{0xca, 0xfe}, nil})
+	require.NoError(t, err)
+	q, err := rs.GetQuery(resolver)
+	require.NoError(t, err)
+	require.Equal(t, "select * from foo where a=10 and b=1.5 and c=2.0 and d='''' and e=X'cafe' and f=null", q)
+
+	require.Error(t, resolver.PrepareValues([]any{map[string]any{}}))
+	require.Error(t, resolver.PrepareValues([]any{math.Inf(1)}))
+}
+
 func TestWriteQuery(t *testing.T) {
 	t.Parallel()
 
@@ -726,6 +753,69 @@ func TestMaxReadQuerySize(t *testing.T) {
 	})
 }
 
+func TestReadStatementGetTables(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+	rs, err := parser.ValidateReadQuery(
+		"SELECT * FROM foo_1337_1 t JOIN bar_1_2 ON t.id = bar_1_2.id WHERE t.id IN (SELECT id FROM foo_1337_1)",
+	)
+	require.NoError(t, err)
+
+	tbls := rs.GetTables()
+	require.Len(t, tbls, 2)
+	require.Equal(t, "foo_1337_1", tbls[0].Name())
+	require.Equal(t, int64(1337), tbls[0].ChainID())
+	require.Equal(t, "bar_1_2", tbls[1].Name())
+	require.Equal(t, int64(2), tbls[1].TokenID())
+}
+
+func TestReadStatementGetPageQuery(t *testing.T) {
+	t.Parallel()
+
+	parser := newParser(t, []string{"system_", "registry"})
+	rs, err := parser.ValidateReadQuery("select a as x, b from foo_1337_1 t where b = ? order by x desc")
+	require.NoError(t, err)
+
+	resolver := parsing.NewReadStatementResolver(nil)
+	require.NoError(t, resolver.PrepareParams([]string{"'it''s'"}))
+	q, keyColumns, ok, err := rs.GetPageQuery(resolver, nil, 3)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 2, keyColumns)
+	require.Equal(t,
+		"select a as x,b,a,t.rowid from foo_1337_1 as t where b='it''s' order by x desc,t.rowid asc limit 3", q)
+
+	require.NoError(t, resolver.PrepareParams([]string{"'it''s'"}))
+	q, _, ok, err = rs.GetPageQuery(resolver, []interface{}{int64(5), int64(10)}, 3)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "select a as x,b,a,t.rowid from foo_1337_1 as t "+
+		"where (b='it''s')and(((a)<5 or(a)is null)or((a)is 5 and((t.rowid)>10)))"+
+		"order by x desc,t.rowid asc limit 3", q)
+
+	// The statement isn't changed.
+	require.NoError(t, resolver.PrepareParams([]string{"'it''s'"}))
+	q, err = rs.GetQuery(resolver)
+	require.NoError(t, err)
+	require.Equal(t, "select a as x,b from foo_1337_1 as t where b='it''s' order by x desc", q)
+
+	for _, query := range []string{
+		"select a, count(*) from foo_1337_1 group by a",
+		"select distinct a from foo_1337_1",
+		"select * from foo_1337_1 limit 10",
+		"select * from foo_1337_1 join bar_1337_2 on foo_1337_1.a = bar_1337_2.a",
+		"select * from foo_1337_1 union select * from bar_1337_2",
+		"select * from foo_1337_1 order by a + ?",
+	} {
+		rs, err := parser.ValidateReadQuery(query)
+		require.NoError(t, err)
+		_, _, ok, err := rs.GetPageQuery(parsing.NewReadStatementResolver(nil), nil, 3)
+		require.NoError(t, err)
+		require.False(t, ok, query)
+	}
+}
+
 func TestMaxWriteQuerySize(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,117 @@
+package parsing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BindNamedParams rewrites the named parameters of a statement, written as :name or @name, into positional
+// parameters, and returns the values to bind to them in order. A name can be used more than once. Every
+// parameter must have a value, every value must be used, and named and positional parameters can't be mixed.
+func BindNamedParams(statement string, params map[string]any) (string, []any, error) {
+	var b strings.Builder
+	b.Grow(len(statement))
+	var values []any
+	used := make(map[string]struct{}, len(params))
+	var missing []string
+	positional := false
+
+	for i := 0; i < len(statement); i++ {
+		c := statement[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			// Copy quoted strings and identifiers as they are.
+			end := quotedEnd(statement, i)
+			b.WriteString(statement[i:end])
+			i = end - 1
+		case strings.HasPrefix(statement[i:], "--") || strings.HasPrefix(statement[i:], "/*"):
+			// Copy comments as they are.
+			end := commentEnd(statement, i)
+			b.WriteString(statement[i:end])
+			i = end - 1
+		case c == '?':
+			positional = true
+			b.WriteByte(c)
+		case (c == ':' || c == '@') && i+1 < len(statement) && isIdentifierStart(statement[i+1]):
+			j := i + 1
+			for j < len(statement) && isIdentifierPart(statement[j]) {
+				j++
+			}
+			name := statement[i+1 : j]
+			value, ok := params[name]
+			if !ok {
+				missing = append(missing, statement[i:j])
+			}
+			used[name] = struct{}{}
+			values = append(values, value)
+			b.WriteByte('?')
+			i = j - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	if positional && len(values) > 0 {
+		return "", nil, fmt.Errorf("named and positional parameters can't be mixed")
+	}
+	if len(missing) > 0 {
+		return "", nil, fmt.Errorf("missing values for parameters %s", strings.Join(missing, ", "))
+	}
+	var extra []string
+	for name := range params {
+		if _, ok := used[name]; !ok {
+			extra = append(extra, name)
+		}
+	}
+	if len(extra) > 0 {
+		sort.Strings(extra)
+		return "", nil, fmt.Errorf("unknown parameters %s", strings.Join(extra, ", "))
+	}
+
+	return b.String(), values, nil
+}
+
+// quotedEnd returns the position after the end of the quoted string or identifier starting at i. Quotes are
+// escaped by doubling them. If the quote isn't closed, the end of the statement is returned, so the parser
+// reports the error.
+func quotedEnd(statement string, i int) int {
+	closing := statement[i]
+	if closing == '[' {
+		closing = ']'
+	}
+	for j := i + 1; j < len(statement); j++ {
+		if statement[j] != closing {
+			continue
+		}
+		if closing != ']' && j+1 < len(statement) && statement[j+1] == closing {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return len(statement)
+}
+
+// commentEnd returns the position after the end of the -- or /* comment starting at i. Line comments end
+// with a newline or the end of the statement, like unclosed block comments.
+func commentEnd(statement string, i int) int {
+	if statement[i] == '-' {
+		if j := strings.IndexByte(statement[i:], '\n'); j >= 0 {
+			return i + j + 1
+		}
+		return len(statement)
+	}
+	if j := strings.Index(statement[i+2:], "*/"); j >= 0 {
+		return i + 2 + j + 2
+	}
+	return len(statement)
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,92 @@
+package parsing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindNamedParams(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name      string
+		statement string
+		params    map[string]any
+		expStmt   string
+		expValues []any
+		expErr    string
+	}
+
+	tests := []testCase{
+		{
+			name:      "colon and at prefixes",
+			statement: "select * from foo_1337_1 where a = :a and b = @b_2 and c = :a",
+			params:    map[string]any{"a": int64(1), "b_2": "x"},
+			expStmt:   "select * from foo_1337_1 where a = ? and b = ? and c = ?",
+			expValues: []any{int64(1), "x", int64(1)},
+		},
+		{
+			name:      "quoted strings and identifiers are skipped",
+			statement: `select ':a', "@b", [:c], '''@d' from foo_1337_1 where a = :a`,
+			params:    map[string]any{"a": nil},
+			expStmt:   `select ':a', "@b", [:c], '''@d' from foo_1337_1 where a = ?`,
+			expValues: []any{nil},
+		},
+		{
+			name:      "line comments are skipped",
+			statement: "select * from foo_1337_1 -- where a = :a or b = ?\nwhere a = :b -- @c",
+			params:    map[string]any{"b": int64(2)},
+			expStmt:   "select * from foo_1337_1 -- where a = :a or b = ?\nwhere a = ? -- @c",
+			expValues: []any{int64(2)},
+		},
+		{
+			name:      "block comments are skipped",
+			statement: "select /* :a, ':b */ * from foo_1337_1 where a = :b /* @c",
+			params:    map[string]any{"b": "x"},
+			expStmt:   "select /* :a, ':b */ * from foo_1337_1 where a = ? /* @c",
+			expValues: []any{"x"},
+		},
+		{
+			name:      "no params",
+			statement: "select * from foo_1337_1",
+			params:    map[string]any{},
+			expStmt:   "select * from foo_1337_1",
+		},
+		{
+			name:      "missing params",
+			statement: "select * from foo_1337_1 where a = :a and b = @b",
+			params:    map[string]any{},
+			expErr:    "missing values for parameters :a, @b",
+		},
+		{
+			name:      "unknown params",
+			statement: "select * from foo_1337_1 where a = :a",
+			params:    map[string]any{"a": 1, "c": 2, "b": 3},
+			expErr:    "unknown parameters b, c",
+		},
+		{
+			name:      "mixed params",
+			statement: "select * from foo_1337_1 where a = :a and b = ?",
+			params:    map[string]any{"a": 1},
+			expErr:    "named and positional parameters can't be mixed",
+		},
+	}
+
+	for _, it := range tests {
+		t.Run(it.name, func(tc testCase) func(t *testing.T) {
+			return func(t *testing.T) {
+				t.Parallel()
+
+				stmt, values, err := BindNamedParams(tc.statement, tc.params)
+				if tc.expErr != "" {
+					require.EqualError(t, err, tc.expErr)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.expStmt, stmt)
+				require.Equal(t, tc.expValues, values)
+			}
+		}(it))
+	}
+}
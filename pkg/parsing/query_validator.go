@@ -46,6 +46,15 @@ type MutatingStmt interface {
 type ReadStmt interface {
 	// GetQuery returns an executable stringification of a mutating statements with resolved custom functions.
 	GetQuery(sqlparser.ReadStatementResolver) (string, error)
+	// GetTables returns the user tables referenced by the statement.
+	GetTables() []*sqlparser.ValidatedTable
+	// GetPageQuery returns an executable query of up to limit rows of the statement that sort after the row with
+	// the provided key, or of its first rows if the key is nil. Every row is followed by keyColumns columns with
+	// its key, which identifies its position in the order of the statement, ties broken by rowid. It returns
+	// false if the rows can't be keyed, e.g. because the statement joins or groups rows.
+	GetPageQuery(
+		resolver sqlparser.ReadStatementResolver, after []interface{}, limit int,
+	) (query string, keyColumns int, ok bool, err error)
 }
 
 // WriteStmt is an already parsed write statement that satisfies all
@@ -1,7 +1,11 @@
 package parsing
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -33,42 +37,113 @@ func (rqr *ReadStatementResolver) GetBindValues() []sqlparser.Expr {
 
 // PrepareParams prepare the params to the correct type.
 func (rqr *ReadStatementResolver) PrepareParams(params []string) error {
-	values := make([]sqlparser.Expr, len(params))
+	values := make([]any, len(params))
 	for i, param := range params {
-		if strings.EqualFold(strings.ToLower(param), "null") {
-			values[i] = &sqlparser.NullValue{}
-			continue
+		value, err := ParseParam(param)
+		if err != nil {
+			return err
 		}
+		values[i] = value
+	}
+	return rqr.PrepareValues(values)
+}
 
-		if strings.EqualFold(strings.ToLower(param), "true") {
-			values[i] = sqlparser.BoolValue(true)
-			continue
+// PrepareValues prepares typed values to be bound to the parameters. Values can be nil, booleans, integers,
+// floats, json.Number, strings and []byte, which is bound as a blob.
+func (rqr *ReadStatementResolver) PrepareValues(params []any) error {
+	values := make([]sqlparser.Expr, len(params))
+	for i, param := range params {
+		value, err := bindValue(param)
+		if err != nil {
+			return fmt.Errorf("binding param %d: %s", i+1, err)
 		}
+		values[i] = value
+	}
 
-		if strings.EqualFold(strings.ToLower(param), "false") {
-			values[i] = sqlparser.BoolValue(false)
-			continue
-		}
+	rqr.values = values
 
-		if strings.HasPrefix(param, "'") && strings.HasSuffix(param, "'") {
-			values[i] = &sqlparser.Value{Type: sqlparser.StrValue, Value: []byte(param[1 : len(param)-1])}
-			continue
-		}
+	return nil
+}
 
-		if strings.HasPrefix(param, "\"") && strings.HasSuffix(param, "\"") {
-			values[i] = &sqlparser.Value{Type: sqlparser.StrValue, Value: []byte(param[1 : len(param)-1])}
-			continue
-		}
+// ParseParam parses the text form of a param, as sent in query strings. It can be null, true, false,
+// an integer or a string surrounded by single or double quotes.
+func ParseParam(param string) (any, error) {
+	if strings.EqualFold(param, "null") {
+		return nil, nil
+	}
+	if strings.EqualFold(param, "true") {
+		return true, nil
+	}
+	if strings.EqualFold(param, "false") {
+		return false, nil
+	}
+	if len(param) >= 2 && strings.HasPrefix(param, "'") && strings.HasSuffix(param, "'") {
+		// Single quoted strings are SQL literals, so quotes are escaped by doubling them.
+		return strings.ReplaceAll(param[1:len(param)-1], "''", "'"), nil
+	}
+	if len(param) >= 2 && strings.HasPrefix(param, "\"") && strings.HasSuffix(param, "\"") {
+		return param[1 : len(param)-1], nil
+	}
+	if v, err := strconv.ParseInt(param, 10, 64); err == nil {
+		return v, nil
+	}
 
-		if _, err := strconv.ParseInt(param, 10, 64); err == nil {
-			values[i] = &sqlparser.Value{Type: sqlparser.IntValue, Value: []byte(param)}
-			continue
-		}
+	return nil, errors.New("unknown param type")
+}
 
-		return errors.New("unknown param type")
+func bindValue(param any) (sqlparser.Expr, error) {
+	switch v := param.(type) {
+	case nil:
+		return &sqlparser.NullValue{}, nil
+	case bool:
+		return sqlparser.BoolValue(v), nil
+	case int:
+		return intValue(int64(v)), nil
+	case int64:
+		return intValue(v), nil
+	case float64:
+		return floatValue(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return intValue(i), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", v)
+		}
+		return floatValue(f)
+	case string:
+		value := strings.ReplaceAll(v, "'", "''")
+		return &sqlparser.Value{Type: sqlparser.StrValue, Value: []byte(value)}, nil
+	case []byte:
+		return &sqlparser.Value{Type: sqlparser.BlobValue, Value: []byte(hex.EncodeToString(v))}, nil
+	default:
+		return nil, fmt.Errorf("invalid type (%T)", v)
 	}
+}
 
-	rqr.values = values
+// intValue returns an integer literal. Negative numbers are parenthesized, since the parser prints binary
+// expressions without spaces, and a minus sign could start a comment.
+func intValue(v int64) sqlparser.Expr {
+	value := &sqlparser.Value{Type: sqlparser.IntValue, Value: []byte(strconv.FormatInt(v, 10))}
+	if v < 0 {
+		return &sqlparser.ParenExpr{Expr: value}
+	}
+	return value
+}
 
-	return nil
+func floatValue(v float64) (sqlparser.Expr, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, fmt.Errorf("invalid number %v", v)
+	}
+	s := strconv.FormatFloat(v, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		// Keep the value a real number in SQLite.
+		s += ".0"
+	}
+	value := &sqlparser.Value{Type: sqlparser.FloatValue, Value: []byte(s)}
+	if v < 0 {
+		return &sqlparser.ParenExpr{Expr: value}, nil
+	}
+	return value, nil
 }
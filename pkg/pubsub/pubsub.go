@@ -0,0 +1,96 @@
+package pubsub
+
+import (
+	"sync"
+
+	logger "github.com/rs/zerolog/log"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
+)
+
+var log = logger.With().Str("component", "pubsub").Logger()
+
+// DefaultBufferSize is the default number of receipts a subscription can hold before
+// being considered too slow.
+const DefaultBufferSize = 1000
+
+// Hub is an in-process thread-safe pub/sub hub of executed receipts. The event processors of
+// every chain publish to it, and the API subscribes to it to stream changes to clients.
+type Hub struct {
+	bufferSize int
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+var _ eventprocessor.ReceiptPublisher = (*Hub)(nil)
+
+// NewHub creates a new Hub. Each subscription can buffer up to bufferSize receipts.
+func NewHub(bufferSize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Hub{
+		bufferSize: bufferSize,
+		subs:       make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe creates a new subscription to all published receipts.
+// The caller must call Close on the subscription when it isn't needed anymore.
+func (h *Hub) Subscribe() *Subscription {
+	sub := &Subscription{
+		hub: h,
+		ch:  make(chan eventprocessor.Receipt, h.bufferSize),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subs[sub] = struct{}{}
+
+	return sub
+}
+
+// Publish sends receipts to all subscriptions. It never blocks: if a subscription can't keep up,
+// it's closed and the subscriber is expected to resume from the last receipt it received.
+func (h *Hub) Publish(receipts []eventprocessor.Receipt) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		for _, r := range receipts {
+			select {
+			case sub.ch <- r:
+			default:
+				log.Warn().Int64("chain_id", int64(r.ChainID)).Msg("closing slow subscription")
+				delete(h.subs, sub)
+				close(sub.ch)
+			}
+			if _, ok := h.subs[sub]; !ok {
+				break
+			}
+		}
+	}
+}
+
+// Subscription is a stream of published receipts.
+type Subscription struct {
+	hub *Hub
+	ch  chan eventprocessor.Receipt
+}
+
+// Receipts returns the channel where receipts are delivered. The channel is closed
+// when the subscription is closed or if it couldn't keep up with published receipts.
+func (s *Subscription) Receipts() <-chan eventprocessor.Receipt {
+	return s.ch
+}
+
+// Close removes the subscription from the hub. It's safe to call it more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	if _, ok := s.hub.subs[s]; ok {
+		delete(s.hub.subs, s)
+		close(s.ch)
+	}
+}
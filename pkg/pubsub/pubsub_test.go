@@ -0,0 +1,67 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
+)
+
+func TestHub(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delivers to every subscription", func(t *testing.T) {
+		t.Parallel()
+
+		hub := NewHub(10)
+		sub1, sub2 := hub.Subscribe(), hub.Subscribe()
+		defer sub1.Close()
+		defer sub2.Close()
+
+		hub.Publish([]eventprocessor.Receipt{
+			{ChainID: 1337, BlockNumber: 1, IndexInBlock: 0},
+			{ChainID: 1337, BlockNumber: 1, IndexInBlock: 1},
+		})
+
+		for _, sub := range []*Subscription{sub1, sub2} {
+			r := <-sub.Receipts()
+			require.Equal(t, int64(0), r.IndexInBlock)
+			r = <-sub.Receipts()
+			require.Equal(t, int64(1), r.IndexInBlock)
+		}
+	})
+
+	t.Run("closes slow subscription", func(t *testing.T) {
+		t.Parallel()
+
+		hub := NewHub(1)
+		sub := hub.Subscribe()
+
+		hub.Publish([]eventprocessor.Receipt{
+			{ChainID: 1337, BlockNumber: 1, IndexInBlock: 0},
+			{ChainID: 1337, BlockNumber: 1, IndexInBlock: 1},
+		})
+
+		r, ok := <-sub.Receipts()
+		require.True(t, ok)
+		require.Equal(t, int64(0), r.IndexInBlock)
+		_, ok = <-sub.Receipts()
+		require.False(t, ok)
+
+		// Closing an already dropped subscription is a noop.
+		sub.Close()
+	})
+
+	t.Run("close", func(t *testing.T) {
+		t.Parallel()
+
+		hub := NewHub(10)
+		sub := hub.Subscribe()
+		sub.Close()
+		sub.Close()
+
+		hub.Publish([]eventprocessor.Receipt{{ChainID: 1337, BlockNumber: 1}})
+		_, ok := <-sub.Receipts()
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,261 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mattn/go-sqlite3"
+	"github.com/rs/zerolog"
+	logger "github.com/rs/zerolog/log"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/database"
+	"github.com/textileio/go-tableland/pkg/database/db"
+	"github.com/textileio/go-tableland/pkg/parsing"
+	"github.com/textileio/go-tableland/pkg/relay"
+	"github.com/textileio/go-tableland/pkg/tables"
+)
+
+// Chain contains what the relay needs to submit writes to a chain.
+type Chain struct {
+	// Registry sends the RunSQL transactions, signed by the relay wallet.
+	Registry tables.TablelandTables
+	// ContractAddress is the address of the registry contract, which is the verifying contract of the
+	// signed writes.
+	ContractAddress common.Address
+}
+
+// Relay submits signed writes through the registry contract of each chain. On-chain, the caller of the
+// relayed writes is the relay wallet, so it must also have privileges on the tables.
+//
+// Privileges are checked against the local state of the validator, which can lag behind the chain. A write
+// allowed locally can still fail on-chain, and it counts for the quota and the budget anyway, since the
+// relay wallet pays for it. The quota of a signer counts its writes on all chains, while the budget is
+// spent per chain.
+type Relay struct {
+	log    zerolog.Logger
+	config *relay.Config
+	db     *database.SQLiteDB
+	parser parsing.SQLValidator
+	acl    tableland.ACL
+	wallet common.Address
+	chains map[tableland.ChainID]Chain
+
+	// allowedSigners is nil if any signer is allowed.
+	allowedSigners map[common.Address]struct{}
+
+	// mu serializes the quota checks with the reservation of nonces.
+	mu sync.Mutex
+}
+
+var _ relay.Relay = (*Relay)(nil)
+
+// NewRelay creates a new relay sending the transactions from the wallet address.
+func NewRelay(
+	sqliteDB *database.SQLiteDB,
+	parser parsing.SQLValidator,
+	acl tableland.ACL,
+	wallet common.Address,
+	chains map[tableland.ChainID]Chain,
+	opts ...relay.Option,
+) (*Relay, error) {
+	config := relay.DefaultConfig()
+	for _, opt := range opts {
+		if err := opt(config); err != nil {
+			return nil, fmt.Errorf("applying option: %s", err)
+		}
+	}
+
+	var allowedSigners map[common.Address]struct{}
+	if len(config.AllowedSigners) > 0 {
+		allowedSigners = make(map[common.Address]struct{}, len(config.AllowedSigners))
+		for _, signer := range config.AllowedSigners {
+			allowedSigners[signer] = struct{}{}
+		}
+	}
+
+	log := logger.With().
+		Str("component", "relay").
+		Str("wallet", wallet.Hex()).
+		Logger()
+
+	return &Relay{
+		log:    log,
+		config: config,
+		db:     sqliteDB,
+		parser: parser,
+		acl:    acl,
+		wallet: wallet,
+		chains: chains,
+
+		allowedSigners: allowedSigners,
+	}, nil
+}
+
+// Submit verifies a signed write and sends a RunSQL transaction for it.
+func (r *Relay) Submit(ctx context.Context, sw relay.SignedWrite) (relay.Submission, error) {
+	chain, ok := r.chains[sw.ChainID]
+	if !ok {
+		return relay.Submission{}, relay.ErrUnsupportedChain
+	}
+	if time.Now().Unix() > sw.Deadline {
+		return relay.Submission{}, relay.ErrExpired
+	}
+	signer, err := relay.RecoverSigner(chain.ContractAddress, sw)
+	if err != nil {
+		return relay.Submission{}, err
+	}
+	if _, ok := r.allowedSigners[signer]; r.allowedSigners != nil && !ok {
+		return relay.Submission{}, fmt.Errorf("%w: the signer isn't allowed to relay writes", relay.ErrNotAllowed)
+	}
+	if err := r.checkPrivileges(ctx, sw.Write, signer); err != nil {
+		return relay.Submission{}, err
+	}
+
+	if err := r.reserveNonce(ctx, sw.ChainID, signer, sw.Nonce); err != nil {
+		return relay.Submission{}, err
+	}
+	txn, err := chain.Registry.RunSQL(ctx, r.wallet, sw.TableID, sw.Statement)
+	if err != nil {
+		// The write wasn't sent, so it doesn't count for the quota and its nonce can be reused.
+		if err := r.db.Queries.DeleteRelayWrite(ctx, db.DeleteRelayWriteParams{
+			ChainID: int64(sw.ChainID),
+			Signer:  signer.Hex(),
+			Nonce:   sw.Nonce.String(),
+		}); err != nil {
+			r.log.Error().Err(err).Str("signer", signer.Hex()).Msg("releasing relay write nonce")
+		}
+		return relay.Submission{}, fmt.Errorf("sending transaction: %s", err)
+	}
+
+	if err := r.db.Queries.SetRelayWriteTxn(ctx, db.SetRelayWriteTxnParams{
+		ChainID: int64(sw.ChainID),
+		Signer:  signer.Hex(),
+		Nonce:   sw.Nonce.String(),
+		TxnHash: sql.NullString{String: txn.Hash().Hex(), Valid: true},
+		Gas:     int64(gasLimit(txn)),
+	}); err != nil {
+		r.log.Error().Err(err).Str("txn_hash", txn.Hash().Hex()).Msg("saving relay write transaction hash")
+	}
+
+	r.log.Info().
+		Int64("chain_id", int64(sw.ChainID)).
+		Str("signer", signer.Hex()).
+		Str("txn_hash", txn.Hash().Hex()).
+		Msg("write relayed")
+
+	return relay.Submission{Signer: signer, TxnHash: txn.Hash()}, nil
+}
+
+// checkPrivileges checks that the write only has statements the signer can execute on its table. The relay
+// wallet must be able to execute them too, since it's the caller seen by the validators.
+func (r *Relay) checkPrivileges(ctx context.Context, w relay.Write, signer common.Address) error {
+	stmts, err := r.parser.ValidateMutatingQuery(w.Statement, w.ChainID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", relay.ErrInvalidStatement, err)
+	}
+
+	acl, err := r.acl.GetTableACL(ctx, w.ChainID, w.TableID)
+	if err != nil {
+		return fmt.Errorf("getting table acl: %s", err)
+	}
+	if acl.Controller != "" {
+		return fmt.Errorf("%w: writes to tables with a controller can't be relayed", relay.ErrNotAllowed)
+	}
+
+	for _, stmt := range stmts {
+		if stmt.GetTableID().String() != w.TableID.String() {
+			return fmt.Errorf("%w: the statement must only target table %s", relay.ErrInvalidStatement, w.TableID)
+		}
+		op := stmt.Operation()
+		if op != tableland.OpInsert && op != tableland.OpUpdate && op != tableland.OpDelete {
+			return fmt.Errorf("%w: only insert, update and delete statements can be relayed", relay.ErrNotAllowed)
+		}
+		if !canExecute(acl, signer, op) {
+			return fmt.Errorf("%w: missing privilege for %s", relay.ErrNotAllowed, op)
+		}
+		if !canExecute(acl, r.wallet, op) {
+			return fmt.Errorf("%w: the relay wallet is missing privilege for %s", relay.ErrNotAllowed, op)
+		}
+	}
+	return nil
+}
+
+// reserveNonce records a write of the signer, if it's within its quota and the budget of the chain, and the
+// nonce wasn't used before.
+func (r *Relay) reserveNonce(
+	ctx context.Context,
+	chainID tableland.ChainID,
+	signer common.Address,
+	nonce *big.Int,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if r.config.MaxWritesPerInterval > 0 {
+		count, err := r.db.Queries.CountRelayWritesSince(ctx, db.CountRelayWritesSinceParams{
+			Signer:    signer.Hex(),
+			CreatedAt: now.Add(-r.config.QuotaInterval).Unix(),
+		})
+		if err != nil {
+			return fmt.Errorf("counting relay writes: %s", err)
+		}
+		if count >= int64(r.config.MaxWritesPerInterval) {
+			return relay.ErrQuotaExceeded
+		}
+	}
+	if r.config.MaxChainWritesPerInterval > 0 || r.config.MaxChainGasPerInterval > 0 {
+		usage, err := r.db.Queries.GetChainRelayUsageSince(ctx, db.GetChainRelayUsageSinceParams{
+			ChainID:   int64(chainID),
+			CreatedAt: now.Add(-r.config.QuotaInterval).Unix(),
+		})
+		if err != nil {
+			return fmt.Errorf("getting chain relay usage: %s", err)
+		}
+		if r.config.MaxChainWritesPerInterval > 0 && usage.Writes >= int64(r.config.MaxChainWritesPerInterval) {
+			return relay.ErrBudgetExceeded
+		}
+		if r.config.MaxChainGasPerInterval > 0 && uint64(usage.Gas) >= r.config.MaxChainGasPerInterval {
+			return relay.ErrBudgetExceeded
+		}
+	}
+
+	if err := r.db.Queries.InsertRelayWrite(ctx, db.InsertRelayWriteParams{
+		ChainID:   int64(chainID),
+		Signer:    signer.Hex(),
+		Nonce:     nonce.String(),
+		CreatedAt: now.Unix(),
+	}); err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+			return relay.ErrNonceUsed
+		}
+		return fmt.Errorf("inserting relay write: %s", err)
+	}
+	return nil
+}
+
+// gasLimit returns the gas limit of a sent transaction, which is what the relay wallet can spend on it. It's
+// zero if the registry doesn't expose it.
+func gasLimit(txn tables.Transaction) uint64 {
+	if t, ok := txn.(interface{ Gas() uint64 }); ok {
+		return t.Gas()
+	}
+	return 0
+}
+
+func canExecute(acl tableland.TableACL, addr common.Address, op tableland.Operation) bool {
+	for _, rule := range acl.Rules {
+		if rule.Controller == addr {
+			ok, _ := rule.Privileges.CanExecute(op)
+			return ok
+		}
+	}
+	return false
+}
@@ -0,0 +1,255 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/database"
+	parserimpl "github.com/textileio/go-tableland/pkg/parsing/impl"
+	"github.com/textileio/go-tableland/pkg/relay"
+	"github.com/textileio/go-tableland/pkg/tables"
+	"github.com/textileio/go-tableland/pkg/wallet"
+	"github.com/textileio/go-tableland/tests"
+)
+
+var registryAddr = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+
+func TestSubmit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	user, relayWallet := requireWallet(t), requireWallet(t)
+	acl := aclRules{
+		user.Address():        tableland.Privileges{tableland.PrivInsert, tableland.PrivUpdate},
+		relayWallet.Address(): tableland.Privileges{tableland.PrivInsert, tableland.PrivUpdate, tableland.PrivDelete},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		registry := &fakeRegistry{}
+		r := requireRelay(t, acl, relayWallet.Address(), registry)
+
+		sw := requireSign(t, user, "insert into foo_1337_1 values (1)", 1)
+		sub, err := r.Submit(ctx, sw)
+		require.NoError(t, err)
+		require.Equal(t, user.Address(), sub.Signer)
+		require.Len(t, registry.sent, 1)
+		require.Equal(t, sub.TxnHash, registry.sent[0].Hash())
+		require.Equal(t, relayWallet.Address(), registry.callers[0])
+
+		// The nonce can't be reused.
+		_, err = r.Submit(ctx, sw)
+		require.ErrorIs(t, err, relay.ErrNonceUsed)
+		require.Len(t, registry.sent, 1)
+	})
+
+	t.Run("invalid writes", func(t *testing.T) {
+		t.Parallel()
+
+		registry := &fakeRegistry{}
+		r := requireRelay(t, acl, relayWallet.Address(), registry)
+
+		sw := requireSign(t, user, "insert into foo_1337_1 values (1)", 1)
+		sw.Deadline = time.Now().Add(-time.Minute).Unix()
+		_, err := r.Submit(ctx, sw)
+		require.ErrorIs(t, err, relay.ErrExpired)
+
+		sw = requireSign(t, user, "insert into foo_1337_1 values (1)", 1)
+		sw.Signature = sw.Signature[:10]
+		_, err = r.Submit(ctx, sw)
+		require.ErrorIs(t, err, relay.ErrInvalidSignature)
+
+		// Tampering the statement changes the recovered signer.
+		sw = requireSign(t, user, "insert into foo_1337_1 values (1)", 1)
+		sw.Statement = "delete from foo_1337_1"
+		_, err = r.Submit(ctx, sw)
+		require.ErrorIs(t, err, relay.ErrNotAllowed)
+
+		sw = requireSign(t, user, "delete from foo_1337_1", 1)
+		_, err = r.Submit(ctx, sw)
+		require.ErrorIs(t, err, relay.ErrNotAllowed)
+
+		sw = requireSign(t, user, "grant insert on foo_1337_1 to '0xd43c59d5694ec111eb9e986c233200b14249558d'", 1)
+		_, err = r.Submit(ctx, sw)
+		require.ErrorIs(t, err, relay.ErrNotAllowed)
+
+		sw = requireSign(t, user, "insert into foo_1337_2 values (1)", 1)
+		_, err = r.Submit(ctx, sw)
+		require.ErrorIs(t, err, relay.ErrInvalidStatement)
+
+		sw = requireSign(t, user, "insert into foo_1337_1 values (1)", 1)
+		sw.ChainID = 1
+		_, err = r.Submit(ctx, sw)
+		require.ErrorIs(t, err, relay.ErrUnsupportedChain)
+
+		require.Empty(t, registry.sent)
+	})
+
+	t.Run("quota", func(t *testing.T) {
+		t.Parallel()
+
+		registry := &fakeRegistry{}
+		r := requireRelay(t, acl, relayWallet.Address(), registry, relay.WithQuota(2, time.Hour))
+
+		for i := int64(1); i <= 2; i++ {
+			_, err := r.Submit(ctx, requireSign(t, user, "insert into foo_1337_1 values (1)", i))
+			require.NoError(t, err)
+		}
+		_, err := r.Submit(ctx, requireSign(t, user, "insert into foo_1337_1 values (1)", 3))
+		require.ErrorIs(t, err, relay.ErrQuotaExceeded)
+		require.Len(t, registry.sent, 2)
+	})
+
+	t.Run("chain budget", func(t *testing.T) {
+		t.Parallel()
+
+		registry := &fakeRegistry{}
+		r := requireRelay(t, acl, relayWallet.Address(), registry, relay.WithChainBudget(2, 0))
+
+		// The budget is shared by all signers.
+		_, err := r.Submit(ctx, requireSign(t, user, "insert into foo_1337_1 values (1)", 1))
+		require.NoError(t, err)
+		_, err = r.Submit(ctx, requireSign(t, relayWallet, "insert into foo_1337_1 values (1)", 1))
+		require.NoError(t, err)
+		_, err = r.Submit(ctx, requireSign(t, user, "insert into foo_1337_1 values (1)", 2))
+		require.ErrorIs(t, err, relay.ErrBudgetExceeded)
+		require.Len(t, registry.sent, 2)
+
+		registry = &fakeRegistry{gas: 30000}
+		r = requireRelay(t, acl, relayWallet.Address(), registry, relay.WithChainBudget(0, 50000))
+		for i := int64(1); i <= 2; i++ {
+			_, err := r.Submit(ctx, requireSign(t, user, "insert into foo_1337_1 values (1)", i))
+			require.NoError(t, err)
+		}
+		_, err = r.Submit(ctx, requireSign(t, user, "insert into foo_1337_1 values (1)", 3))
+		require.ErrorIs(t, err, relay.ErrBudgetExceeded)
+		require.Len(t, registry.sent, 2)
+	})
+
+	t.Run("allowed signers", func(t *testing.T) {
+		t.Parallel()
+
+		registry := &fakeRegistry{}
+		r := requireRelay(t, acl, relayWallet.Address(), registry, relay.WithAllowedSigners(relayWallet.Address()))
+
+		_, err := r.Submit(ctx, requireSign(t, user, "insert into foo_1337_1 values (1)", 1))
+		require.ErrorIs(t, err, relay.ErrNotAllowed)
+		_, err = r.Submit(ctx, requireSign(t, relayWallet, "insert into foo_1337_1 values (1)", 1))
+		require.NoError(t, err)
+		require.Len(t, registry.sent, 1)
+	})
+
+	t.Run("failed transaction", func(t *testing.T) {
+		t.Parallel()
+
+		registry := &fakeRegistry{err: errors.New("insufficient funds")}
+		r := requireRelay(t, acl, relayWallet.Address(), registry)
+
+		sw := requireSign(t, user, "insert into foo_1337_1 values (1)", 1)
+		_, err := r.Submit(ctx, sw)
+		require.Error(t, err)
+
+		// The nonce of a write that wasn't sent can be reused.
+		registry.err = nil
+		_, err = r.Submit(ctx, sw)
+		require.NoError(t, err)
+		require.Len(t, registry.sent, 1)
+	})
+}
+
+func requireRelay(
+	t *testing.T,
+	acl tableland.ACL,
+	relayWallet common.Address,
+	registry tables.TablelandTables,
+	opts ...relay.Option,
+) *Relay {
+	t.Helper()
+
+	db, err := database.Open(tests.Sqlite3URI(t))
+	require.NoError(t, err)
+	parser, err := parserimpl.New([]string{"system_", "registry", "sqlite_"})
+	require.NoError(t, err)
+
+	chains := map[tableland.ChainID]Chain{
+		1337: {Registry: registry, ContractAddress: registryAddr},
+	}
+	r, err := NewRelay(db, parser, acl, relayWallet, chains, opts...)
+	require.NoError(t, err)
+	return r
+}
+
+func requireWallet(t *testing.T) *wallet.Wallet {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	w, err := wallet.NewWallet(hex.EncodeToString(crypto.FromECDSA(key)))
+	require.NoError(t, err)
+	return w
+}
+
+func requireSign(t *testing.T, w *wallet.Wallet, stmt string, nonce int64) relay.SignedWrite {
+	t.Helper()
+
+	tableID, err := tables.NewTableID("1")
+	require.NoError(t, err)
+	sw, err := relay.Sign(w, registryAddr, relay.Write{
+		ChainID:   1337,
+		TableID:   tableID,
+		Statement: stmt,
+		Nonce:     big.NewInt(nonce),
+		Deadline:  time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	return sw
+}
+
+// aclRules is an ACL granting privileges on every table.
+type aclRules map[common.Address]tableland.Privileges
+
+func (a aclRules) GetTableACL(context.Context, tableland.ChainID, tables.TableID) (tableland.TableACL, error) {
+	var acl tableland.TableACL
+	for addr, privileges := range a {
+		acl.Rules = append(acl.Rules, tableland.ACLRule{Controller: addr, Privileges: privileges})
+	}
+	return acl, nil
+}
+
+func (a aclRules) CheckPrivileges(
+	context.Context, *sql.Tx, tableland.ChainID, common.Address, tables.TableID, tableland.Operation,
+) (bool, error) {
+	panic("not implemented")
+}
+
+type fakeRegistry struct {
+	tables.TablelandTables
+
+	err     error
+	gas     uint64
+	sent    []*types.Transaction
+	callers []common.Address
+}
+
+func (r *fakeRegistry) RunSQL(
+	_ context.Context, addr common.Address, _ tables.TableID, stmt string, _ ...tables.RunSQLOption,
+) (tables.Transaction, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	tx := types.NewTx(&types.LegacyTx{Nonce: uint64(len(r.sent)), Gas: r.gas, Data: []byte(stmt)})
+	r.sent = append(r.sent, tx)
+	r.callers = append(r.callers, addr)
+	return tx, nil
+}
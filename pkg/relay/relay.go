@@ -0,0 +1,211 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/textileio/go-tableland/internal/tableland"
+	"github.com/textileio/go-tableland/pkg/tables"
+	"github.com/textileio/go-tableland/pkg/wallet"
+)
+
+var (
+	// ErrInvalidSignature indicates that the signature of a write doesn't match its content.
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrExpired indicates that the deadline of a write has passed.
+	ErrExpired = errors.New("write deadline has passed")
+
+	// ErrInvalidStatement indicates that the statement of a write isn't a valid write statement for its table.
+	ErrInvalidStatement = errors.New("invalid statement")
+
+	// ErrNotAllowed indicates that the signer of a write can't execute it on the table.
+	ErrNotAllowed = errors.New("signer is not allowed to execute the statement")
+
+	// ErrQuotaExceeded indicates that the signer of a write used all its relayed writes in the quota interval.
+	ErrQuotaExceeded = errors.New("relay quota exceeded")
+
+	// ErrBudgetExceeded indicates that the relay used all its writes or gas of a chain in the quota interval.
+	ErrBudgetExceeded = errors.New("relay budget exceeded")
+
+	// ErrNonceUsed indicates that the signer already relayed a write with the same nonce.
+	ErrNonceUsed = errors.New("nonce already used")
+
+	// ErrUnsupportedChain indicates that the relay doesn't submit writes to a chain.
+	ErrUnsupportedChain = errors.New("chain not supported by the relay")
+)
+
+// Relay submits writes signed by users through the validator wallet, so users don't need a funded wallet.
+type Relay interface {
+	// Submit verifies a signed write and sends a RunSQL transaction for it.
+	Submit(context.Context, SignedWrite) (Submission, error)
+}
+
+// Write is a write statement to be relayed.
+type Write struct {
+	ChainID   tableland.ChainID
+	TableID   tables.TableID
+	Statement string
+	// Nonce is chosen by the signer, and can't be reused for another write on the same chain.
+	Nonce *big.Int
+	// Deadline is the unix time in seconds after which the write can't be relayed.
+	Deadline int64
+}
+
+// SignedWrite is a write with the EIP-712 signature of its typed data.
+type SignedWrite struct {
+	Write
+	Signature []byte
+}
+
+// Submission is the result of relaying a write.
+type Submission struct {
+	Signer  common.Address
+	TxnHash common.Hash
+}
+
+// TypedData returns the EIP-712 typed data signed for a write. The verifying contract is the registry contract
+// of the chain.
+func TypedData(registry common.Address, w Write) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"RunSQL": []apitypes.Type{
+				{Name: "tableId", Type: "uint256"},
+				{Name: "statement", Type: "string"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+		},
+		PrimaryType: "RunSQL",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Tableland Relay",
+			Version:           "1",
+			ChainId:           math.NewHexOrDecimal256(int64(w.ChainID)),
+			VerifyingContract: registry.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"tableId":   w.TableID.ToBigInt(),
+			"statement": w.Statement,
+			"nonce":     w.Nonce,
+			"deadline":  big.NewInt(w.Deadline),
+		},
+	}
+}
+
+// Sign signs the typed data of a write with a wallet.
+func Sign(wallet *wallet.Wallet, registry common.Address, w Write) (SignedWrite, error) {
+	hash, _, err := apitypes.TypedDataAndHash(TypedData(registry, w))
+	if err != nil {
+		return SignedWrite{}, fmt.Errorf("hashing typed data: %s", err)
+	}
+	signature, err := crypto.Sign(hash, wallet.PrivateKey())
+	if err != nil {
+		return SignedWrite{}, fmt.Errorf("signing typed data: %s", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return SignedWrite{Write: w, Signature: signature}, nil
+}
+
+// RecoverSigner returns the address that signed a write. Both 0/1 and 27/28 recovery ids are accepted.
+func RecoverSigner(registry common.Address, sw SignedWrite) (common.Address, error) {
+	if len(sw.Signature) != crypto.SignatureLength {
+		return common.Address{}, ErrInvalidSignature
+	}
+	if sw.Nonce == nil || sw.Nonce.Sign() < 0 {
+		return common.Address{}, fmt.Errorf("%w: invalid nonce", ErrInvalidSignature)
+	}
+	hash, _, err := apitypes.TypedDataAndHash(TypedData(registry, sw.Write))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: hashing typed data: %s", ErrInvalidSignature, err)
+	}
+
+	signature := make([]byte, crypto.SignatureLength)
+	copy(signature, sw.Signature)
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// Config contains configuration attributes for a relay.
+type Config struct {
+	// MaxWritesPerInterval is the number of writes a signer can relay in QuotaInterval. Zero means no limit.
+	// The writes of a signer are counted across all chains.
+	MaxWritesPerInterval int
+	QuotaInterval        time.Duration
+
+	// MaxChainWritesPerInterval is the number of writes relayed to a chain in QuotaInterval, for all signers
+	// together. Zero means no limit.
+	MaxChainWritesPerInterval int
+	// MaxChainGasPerInterval is the gas limit of the transactions sent to a chain in QuotaInterval. Zero means
+	// no limit. The gas of a transaction is known once it's sent, so concurrent writes can exceed it slightly.
+	MaxChainGasPerInterval uint64
+
+	// AllowedSigners are the only signers whose writes are relayed. Empty means any signer.
+	AllowedSigners []common.Address
+}
+
+// DefaultConfig returns the default configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		MaxWritesPerInterval:      100,
+		QuotaInterval:             24 * time.Hour,
+		MaxChainWritesPerInterval: 1000,
+	}
+}
+
+// Option modifies a configuration attribute.
+type Option func(*Config) error
+
+// WithQuota limits the number of writes each signer can relay in an interval.
+func WithQuota(maxWrites int, interval time.Duration) Option {
+	return func(c *Config) error {
+		if maxWrites < 0 {
+			return fmt.Errorf("max writes can't be negative")
+		}
+		if interval <= 0 {
+			return fmt.Errorf("quota interval must be positive")
+		}
+		c.MaxWritesPerInterval = maxWrites
+		c.QuotaInterval = interval
+		return nil
+	}
+}
+
+// WithChainBudget limits the writes and the gas the relay spends on each chain in the quota interval.
+func WithChainBudget(maxWrites int, maxGas uint64) Option {
+	return func(c *Config) error {
+		if maxWrites < 0 {
+			return fmt.Errorf("max chain writes can't be negative")
+		}
+		c.MaxChainWritesPerInterval = maxWrites
+		c.MaxChainGasPerInterval = maxGas
+		return nil
+	}
+}
+
+// WithAllowedSigners only relays the writes of the signers. No signers means any signer.
+func WithAllowedSigners(signers ...common.Address) Option {
+	return func(c *Config) error {
+		c.AllowedSigners = signers
+		return nil
+	}
+}
@@ -14,15 +14,21 @@ import (
 	"github.com/textileio/go-tableland/internal/gateway"
 	gatewayimpl "github.com/textileio/go-tableland/internal/gateway/impl"
 	"github.com/textileio/go-tableland/internal/router"
+	"github.com/textileio/go-tableland/internal/router/controllers"
+	"github.com/textileio/go-tableland/internal/router/middlewares"
 	"github.com/textileio/go-tableland/internal/tableland"
 	"github.com/textileio/go-tableland/internal/tableland/impl"
 	"github.com/textileio/go-tableland/pkg/database"
+	"github.com/textileio/go-tableland/pkg/eventprocessor"
 	"github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed"
 	efimpl "github.com/textileio/go-tableland/pkg/eventprocessor/eventfeed/impl"
 	epimpl "github.com/textileio/go-tableland/pkg/eventprocessor/impl"
 	executor "github.com/textileio/go-tableland/pkg/eventprocessor/impl/executor/impl"
+	nonceimpl "github.com/textileio/go-tableland/pkg/nonce/impl"
 	"github.com/textileio/go-tableland/pkg/parsing"
 	parserimpl "github.com/textileio/go-tableland/pkg/parsing/impl"
+	"github.com/textileio/go-tableland/pkg/pubsub"
+	relayimpl "github.com/textileio/go-tableland/pkg/relay/impl"
 
 	"github.com/textileio/go-tableland/pkg/sharedmemory"
 
@@ -53,6 +59,8 @@ type Deps struct {
 	Database       *database.SQLiteDB
 	ACL            tableland.ACL
 	GatewayService gateway.Gateway
+	// ReadSigner signs the responses of read queries if it isn't nil.
+	ReadSigner *wallet.Wallet
 }
 
 // CreateFullStack creates a running validator with the provided dependencies, or defaults otherwise.
@@ -102,11 +110,14 @@ func CreateFullStack(t *testing.T, deps Deps) FullStack {
 		addr,
 		sm,
 		eventfeed.WithNewHeadPollFreq(time.Millisecond),
-		eventfeed.WithMinBlockDepth(0))
+		eventfeed.WithMinBlockDepth(0),
+		eventfeed.WithEventPersistence(true))
 	require.NoError(t, err)
 
+	hub := pubsub.NewHub(pubsub.DefaultBufferSize)
+
 	// Create EventProcessor for our test.
-	ep, err := epimpl.New(parser, ex, ef, 1337)
+	ep, err := epimpl.New(parser, ex, ef, 1337, eventprocessor.WithReceiptPublisher(hub))
 	require.NoError(t, err)
 
 	err = ep.Start()
@@ -126,13 +137,30 @@ func CreateFullStack(t *testing.T, deps Deps) FullStack {
 			"https://testnets.tableland.network",
 			"https://tables.tableland.xyz",
 			"https://tables.tableland.xyz",
+			gateway.WithACL(acl),
 		)
 		require.NoError(t, err)
 		gatewayService, err = gateway.NewInstrumentedGateway(gatewayService)
 		require.NoError(t, err)
 	}
 
-	router, err := router.ConfiguredRouter(gatewayService, 10, time.Second, []tableland.ChainID{ChainID}, "")
+	// The relay sends transactions with the stack wallet.
+	registry, err := ethereum.NewClient(backend, ChainID, addr, wallet, nonceimpl.NewSimpleTracker(wallet, backend))
+	require.NoError(t, err)
+	relay, err := relayimpl.NewRelay(db, parser, acl, wallet.Address(), map[tableland.ChainID]relayimpl.Chain{
+		ChainID: {Registry: registry, ContractAddress: addr},
+	})
+	require.NoError(t, err)
+
+	ctrlOpts := []controllers.ControllerOption{controllers.WithRelay(relay)}
+	if deps.ReadSigner != nil {
+		ctrlOpts = append(ctrlOpts, controllers.WithSignedReads(deps.ReadSigner))
+	}
+	rateLim, err := middlewares.NewRateLimiter(middlewares.RateLimiterConfig{
+		Default: middlewares.RateLimiterRouteConfig{MaxRPI: 10, Interval: time.Second},
+	})
+	require.NoError(t, err)
+	router, err := router.ConfiguredRouter(gatewayService, hub, rateLim, []tableland.ChainID{ChainID}, ctrlOpts...)
 	require.NoError(t, err)
 
 	server := httptest.NewServer(router.Handler())